@@ -14,6 +14,10 @@ import (
 	"github.com/Neph-dev/october_backend/config"
 	"github.com/Neph-dev/october_backend/internal/domain/ai"
 	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"github.com/Neph-dev/october_backend/internal/domain/conversation"
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+	feedDomain "github.com/Neph-dev/october_backend/internal/domain/feed"
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 	aiInfra "github.com/Neph-dev/october_backend/internal/infra/ai"
 	"github.com/Neph-dev/october_backend/internal/infra/cache"
@@ -21,8 +25,8 @@ import (
 	"github.com/Neph-dev/october_backend/internal/infra/feed"
 	"github.com/Neph-dev/october_backend/internal/infra/search"
 	httpHandler "github.com/Neph-dev/october_backend/internal/interfaces/http"
+	"github.com/Neph-dev/october_backend/internal/wiring"
 	"github.com/Neph-dev/october_backend/pkg/logger"
-	"github.com/sashabaranov/go-openai"
 )
 
 const (
@@ -39,8 +43,11 @@ type Application struct {
 	companyService company.Service
 	newsService    *news.Service
 	aiService      ai.Service
+	feedService    *feedDomain.Service
 	rssService     *feed.RSSService
 	processorService *feed.ProcessorService
+	feedScheduler    *feed.Scheduler
+	embeddingService *embedding.Service
 }
 
 // main is the entry point of the application
@@ -114,12 +121,47 @@ func (app *Application) initialize() error {
 	// Initialize repositories
 	companyRepo := mongodb.NewCompanyRepository(app.dbClient.Database(), app.logger)
 	newsRepo := mongodb.NewNewsRepository(app.dbClient.Database())
+	feedStateRepo := mongodb.NewFeedStateRepository(app.dbClient.Database())
+	feedRunRepo := mongodb.NewFeedRunRepository(app.dbClient.Database())
+	contractRepo := mongodb.NewContractRepository(app.dbClient.Database())
+	chunkRepo := mongodb.NewChunkRepository(app.dbClient.Database())
+	conversationRepo := mongodb.NewConversationRepository(app.dbClient.Database())
 
 	// Initialize services
 	app.companyService = company.NewCompanyService(companyRepo, app.logger)
-	app.newsService = news.NewService(newsRepo, app.logger.Unwrap())
+	companyResolver := company.NewResolverService(companyRepo, app.logger)
+	llmProvider := wiring.NewLLMProvider(app.config.AI)
+	app.newsService = news.NewService(
+		newsRepo,
+		nil,
+		wiring.NewClassifier(app.config.AI, llmProvider, app.logger.Unwrap()),
+		wiring.NewSentimentAnalyzer(app.config.AI, llmProvider, app.logger.Unwrap()),
+		app.logger.Unwrap(),
+	)
+	contractService := contract.NewService(contractRepo, nil, wiring.NewContractFallback(app.config.AI, llmProvider), companyResolver, app.logger.Unwrap())
+	embeddingService := embedding.NewService(
+		chunkRepo,
+		wiring.NewEmbedder(app.config.AI, llmProvider),
+		wiring.NewVectorIndex(app.config.AI),
+		app.logger.Unwrap(),
+	)
+	app.embeddingService = embeddingService
+	app.feedService = feedDomain.NewService(feedRunRepo, feedStateRepo, app.logger.Unwrap())
 	app.rssService = feed.NewRSSService(app.logger.Unwrap())
-	app.processorService = feed.NewProcessorService(app.rssService, app.newsService, app.companyService, app.logger.Unwrap())
+	app.processorService = feed.NewProcessorService(
+		app.rssService,
+		app.newsService,
+		app.companyService,
+		feedStateRepo,
+		app.feedService,
+		wiring.NewPoolConfig(app.config.Feed),
+		wiring.NewSchedulePolicy(app.config.Feed),
+		wiring.NewExtractor(app.config.Extract, app.logger.Unwrap()),
+		contractService,
+		embeddingService,
+		app.logger.Unwrap(),
+	)
+	app.feedScheduler = feed.NewScheduler(app.processorService, app.config.Feed.SchedulerTick, app.logger.Unwrap())
 	
 	// Initialize Google Custom Search service
 	googleSearchService := search.NewGoogleSearchService(
@@ -131,18 +173,52 @@ func (app *Application) initialize() error {
 	// Initialize summary cache
 	summaryCache := cache.NewMemoryCache()
 	
+	// Initialize hybrid article retrieval
+	retrievalPipeline := aiInfra.NewRetrievalPipeline(
+		app.newsService,
+		embeddingService,
+		app.newsService,
+		wiring.NewReranker(app.config.AI, llmProvider, app.logger.Unwrap()),
+		wiring.NewRetrievalConfig(app.config.AI),
+		app.logger.Unwrap(),
+	)
+
 	// Initialize AI service with Google Custom Search integration and caching
-	openaiClient := openai.NewClient(app.config.AI.OpenAIAPIKey)
 	app.aiService = aiInfra.NewOpenAIService(
-		openaiClient,
+		llmProvider,
+		wiring.NewModels(app.config.AI),
 		app.newsService,
+		wiring.NewQueryAnalyzer(app.config.AI, llmProvider, app.logger.Unwrap()),
+		retrievalPipeline,
+		companyResolver,
 		googleSearchService,
 		summaryCache,
+		ai.NewTokenBudgets(app.config.AI.ContextBudgets),
 		app.logger,
 	)
 
+	// Initialize multi-turn conversations over the AI service
+	conversationService := conversation.NewService(
+		conversationRepo,
+		app.aiService,
+		wiring.NewRewriter(app.config.AI, llmProvider, app.logger.Unwrap()),
+		wiring.NewConversationSummarizer(app.config.AI, llmProvider, app.logger.Unwrap()),
+		app.logger.Unwrap(),
+	)
+
 	// Create HTTP router with dependencies
-	router := httpHandler.NewRouter(app.logger, app.companyService, app.newsService, app.aiService)
+	router := httpHandler.NewRouter(
+		app.logger,
+		app.config.Server.AdminAPIKey,
+		app.companyService,
+		companyResolver,
+		app.newsService,
+		contractService,
+		app.aiService,
+		conversationService,
+		app.feedService,
+		app.processorService,
+	)
 	router.SetupRoutes()
 
 	// Create indexes for better performance
@@ -156,6 +232,34 @@ func (app *Application) initialize() error {
 		app.logger.Info("Database indexes created successfully")
 	}
 
+	if err := feedStateRepo.CreateIndexes(ctx); err != nil {
+		app.logger.Error("Failed to create feed state indexes", "error", err)
+	}
+
+	if err := feedRunRepo.CreateIndexes(ctx); err != nil {
+		app.logger.Error("Failed to create feed run indexes", "error", err)
+	}
+
+	if err := contractRepo.CreateIndexes(ctx); err != nil {
+		app.logger.Error("Failed to create contract award indexes", "error", err)
+	}
+
+	if err := chunkRepo.CreateIndexes(ctx); err != nil {
+		app.logger.Error("Failed to create article chunk indexes", "error", err)
+	}
+
+	if err := conversationRepo.CreateIndexes(ctx); err != nil {
+		app.logger.Error("Failed to create conversation indexes", "error", err)
+	}
+
+	// Rebuild the in-process vector index from the stored chunk embeddings;
+	// retrieval falls back to full-text search for articles not loaded
+	loadCtx, cancelLoad := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancelLoad()
+	if _, err := embeddingService.Load(loadCtx); err != nil {
+		app.logger.Error("Failed to load article embeddings", "error", err)
+	}
+
 	// Create HTTP server with timeouts.
 	app.server = &http.Server{
 		Addr:         fmt.Sprintf("%s:%s", app.config.Server.Host, app.config.Server.Port),
@@ -183,8 +287,15 @@ func (app *Application) start() error {
 	// Channel to listen for server errors
 	serverErrors := make(chan error, 1)
 
-	// Start RSS feed refresh in the background
-	go app.startRSSFeedRefresh()
+	// Start the RSS feed scheduler in the background; it stops on shutdown
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go app.feedScheduler.Run(schedulerCtx)
+
+	// Load chunks stored by the feed-processor command into the vector index
+	if app.config.AI.EmbeddingReload > 0 {
+		go app.embeddingService.Watch(schedulerCtx, app.config.AI.EmbeddingReload)
+	}
 
 	// Start HTTP server in a goroutine
 	go func() {
@@ -201,6 +312,7 @@ func (app *Application) start() error {
 
 	case sig := <-interrupt:
 		app.logger.Info("Shutdown signal received", "signal", sig.String())
+		stopScheduler()
 
 		// Graceful shutdown with timeout
 		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
@@ -225,39 +337,6 @@ func (app *Application) start() error {
 
 	return nil
 }
-
-// startRSSFeedRefresh starts the background RSS feed refresh process
-func (app *Application) startRSSFeedRefresh() {
-	app.logger.Info("Starting RSS feed refresh scheduler", "interval", "2 hours")
-	
-	// Create a ticker for 2 hours
-	ticker := time.NewTicker(2 * time.Hour)
-	defer ticker.Stop()
-
-	// Process feeds immediately on startup
-	app.processRSSFeeds()
-
-	// Process feeds every 2 hours
-	for range ticker.C {
-		app.processRSSFeeds()
-	}
-}
-
-// processRSSFeeds processes RSS feeds for all companies
-func (app *Application) processRSSFeeds() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-	defer cancel()
-
-	app.logger.Info("Starting scheduled RSS feed processing")
-	
-	err := app.processorService.ProcessAllCompanyFeeds(ctx)
-	if err != nil {
-		app.logger.Error("Failed to process RSS feeds", "error", err)
-	} else {
-		app.logger.Info("Completed scheduled RSS feed processing")
-	}
-}
-
 // parseLogLevel converts string log level to slog.Level
 // Following NASA's rule: validate all inputs
 func parseLogLevel(level string) slog.Level {
@@ -10,9 +10,13 @@ import (
 
 	"github.com/Neph-dev/october_backend/config"
 	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+	feedDomain "github.com/Neph-dev/october_backend/internal/domain/feed"
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 	"github.com/Neph-dev/october_backend/internal/infra/database/mongodb"
 	"github.com/Neph-dev/october_backend/internal/infra/feed"
+	"github.com/Neph-dev/october_backend/internal/wiring"
 	"github.com/Neph-dev/october_backend/pkg/logger"
 )
 
@@ -50,27 +54,77 @@ func main() {
 	// Initialize repositories and services
 	companyRepo := mongodb.NewCompanyRepository(dbClient.Database(), appLogger)
 	newsRepo := mongodb.NewNewsRepository(dbClient.Database())
+	feedStateRepo := mongodb.NewFeedStateRepository(dbClient.Database())
+	feedRunRepo := mongodb.NewFeedRunRepository(dbClient.Database())
+	contractRepo := mongodb.NewContractRepository(dbClient.Database())
+	chunkRepo := mongodb.NewChunkRepository(dbClient.Database())
 
 	companyService := company.NewCompanyService(companyRepo, appLogger)
-	newsService := news.NewService(newsRepo, appLogger.Unwrap())
+	llmProvider := wiring.NewLLMProvider(cfg.AI)
+	newsService := news.NewService(
+		newsRepo,
+		nil,
+		wiring.NewClassifier(cfg.AI, llmProvider, appLogger.Unwrap()),
+		wiring.NewSentimentAnalyzer(cfg.AI, llmProvider, appLogger.Unwrap()),
+		appLogger.Unwrap(),
+	)
+	companyResolver := company.NewResolverService(companyRepo, appLogger)
+	contractService := contract.NewService(contractRepo, nil, wiring.NewContractFallback(cfg.AI, llmProvider), companyResolver, appLogger.Unwrap())
+	// Chunks are stored for the API server to load; this process only writes the index
+	embeddingService := embedding.NewService(chunkRepo, wiring.NewEmbedder(cfg.AI, llmProvider), nil, appLogger.Unwrap())
+	feedService := feedDomain.NewService(feedRunRepo, feedStateRepo, appLogger.Unwrap())
 	rssService := feed.NewRSSService(appLogger.Unwrap())
-	processorService := feed.NewProcessorService(rssService, newsService, companyService, appLogger.Unwrap())
+
+	processorService := feed.NewProcessorService(
+		rssService,
+		newsService,
+		companyService,
+		feedStateRepo,
+		feedService,
+		wiring.NewPoolConfig(cfg.Feed),
+		wiring.NewSchedulePolicy(cfg.Feed),
+		wiring.NewExtractor(cfg.Extract, appLogger.Unwrap()),
+		contractService,
+		embeddingService,
+		appLogger.Unwrap(),
+	)
 
 	// Create indexes if needed
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
 
 	if err := newsRepo.CreateIndexes(ctx); err != nil {
 		appLogger.Error("Failed to create news indexes", "error", err)
 	}
 
+	if err := feedStateRepo.CreateIndexes(ctx); err != nil {
+		appLogger.Error("Failed to create feed state indexes", "error", err)
+	}
+
+	if err := feedRunRepo.CreateIndexes(ctx); err != nil {
+		appLogger.Error("Failed to create feed run indexes", "error", err)
+	}
+
+	if err := contractRepo.CreateIndexes(ctx); err != nil {
+		appLogger.Error("Failed to create contract award indexes", "error", err)
+	}
+
+	if err := chunkRepo.CreateIndexes(ctx); err != nil {
+		appLogger.Error("Failed to create article chunk indexes", "error", err)
+	}
+
 	// Process feeds
+	var report *feedDomain.RunReport
 	if *companyName != "" {
 		appLogger.Info("Processing RSS feed for specific company", "company", *companyName)
-		err = processorService.ProcessCompanyFeed(ctx, *companyName)
+		report, err = processorService.ProcessCompanyFeed(ctx, *companyName)
 	} else {
 		appLogger.Info("Processing RSS feeds for all companies")
-		err = processorService.ProcessAllCompanyFeeds(ctx)
+		report, err = processorService.ProcessAllCompanyFeeds(ctx)
+	}
+
+	if report != nil {
+		printReport(report)
 	}
 
 	if err != nil {
@@ -79,4 +133,37 @@ func main() {
 	}
 
 	appLogger.Info("RSS feed processing completed successfully")
-}
\ No newline at end of file
+}
+
+// printReport prints a per-feed summary of a processing run
+func printReport(report *feedDomain.RunReport) {
+	fmt.Printf("\n%-30s %-30s %8s %6s %6s %6s %6s  %s\n", "COMPANY", "FEED", "DURATION", "ITEMS", "NEW", "DUPS", "ERRORS", "STATUS")
+	for _, result := range report.Feeds {
+		status := fmt.Sprintf("%d", result.StatusCode)
+		if result.NotModified {
+			status = "not modified"
+		}
+		if result.Failed() {
+			status = "failed: " + result.Error
+		}
+		fmt.Printf("%-30.30s %-30.30s %8s %6d %6d %6d %6d  %s\n",
+			result.CompanyName,
+			result.FeedLabel,
+			result.Duration.Round(time.Millisecond),
+			result.Items,
+			result.New,
+			result.Duplicates,
+			result.Errors,
+			status,
+		)
+	}
+	fmt.Printf("\nTotal: %d feeds (%d failed), %d items, %d new, %d duplicates, %d merged in %s\n\n",
+		len(report.Feeds),
+		report.FailedFeeds,
+		report.TotalItems,
+		report.TotalNew,
+		report.TotalDuplicates,
+		report.TotalMerged,
+		report.Duration.Round(time.Millisecond),
+	)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Neph-dev/october_backend/config"
+	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/infra/database/mongodb"
+	"github.com/Neph-dev/october_backend/pkg/logger"
+)
+
+// main converts companies that only have the legacy single feedUrl into the
+// multi-feed layout. It is safe to run more than once.
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	appLogger := logger.NewLogger(slog.LevelInfo, os.Stdout)
+
+	// Initialize MongoDB client
+	dbConfig := mongodb.Config{
+		URI:            cfg.Database.URI,
+		DatabaseName:   "october",
+		ConnectTimeout: 10 * time.Second,
+		PingTimeout:    5 * time.Second,
+		MaxPoolSize:    10,
+		MinPoolSize:    2,
+	}
+
+	dbClient, err := mongodb.NewClient(dbConfig, appLogger)
+	if err != nil {
+		appLogger.Error("Failed to connect to MongoDB", "error", err)
+		os.Exit(1)
+	}
+	defer dbClient.Close(context.Background())
+
+	companyRepo := mongodb.NewCompanyRepository(dbClient.Database(), appLogger)
+	companyService := company.NewCompanyService(companyRepo, appLogger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	migrated, err := companyService.MigrateLegacyFeeds(ctx)
+	if err != nil {
+		appLogger.Error("Feed migration failed", "error", err, "migrated", migrated)
+		os.Exit(1)
+	}
+
+	appLogger.Info("Feed migration completed successfully", "migrated", migrated)
+}
@@ -88,8 +88,15 @@ func getCompaniesToSeed() []*company.CreateCompanyRequest {
 			Country:       "United States",
 			Ticker:        "RTX",
 			StockExchange: "NYSE",
+			Aliases:       []string{"RTX", "RTX Corporation", "Raytheon", "Collins Aerospace", "Pratt & Whitney"},
+			FormerNames:   []string{"United Technologies", "Raytheon Company"},
 			Industry:      company.IndustryAerospace,
-			FeedURL:       "https://www.rtx.com/rss-feeds/news",
+			Feeds: []company.FeedRequest{
+				{
+					URL:   "https://www.rtx.com/rss-feeds/news",
+					Label: "RTX Press Releases",
+				},
+			},
 			CompanyWebsite: "https://www.rtx.com",
 			KeyPeople: []company.KeyPerson{
 				{
@@ -113,8 +120,15 @@ func getCompaniesToSeed() []*company.CreateCompanyRequest {
 			Country:       "United States",
 			Ticker:        "",
 			StockExchange: "",
+			Aliases:       []string{"Department of War", "War Department", "DoW", "Pentagon"},
+			FormerNames:   []string{"Department of Defense", "DoD", "National Military Establishment"},
 			Industry:      company.IndustryGovernment,
-			FeedURL:       "https://www.war.gov/DesktopModules/ArticleCS/RSS.ashx?ContentType=1&Site=945&max=10",
+			Feeds: []company.FeedRequest{
+				{
+					URL:   "https://www.war.gov/DesktopModules/ArticleCS/RSS.ashx?ContentType=1&Site=945&max=10",
+					Label: "War Department News",
+				},
+			},
 			CompanyWebsite: "https://www.war.gov",
 			KeyPeople: []company.KeyPerson{
 				{
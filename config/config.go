@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -16,6 +17,8 @@ type Config struct {
 	Database DatabaseConfig
 	Logger   LoggerConfig
 	AI       AIConfig
+	Feed     FeedConfig
+	Extract  ExtractConfig
 }
 
 // ServerConfig holds server-specific configuration
@@ -25,6 +28,7 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	AdminAPIKey  string
 }
 
 // DatabaseConfig holds database configuration
@@ -37,11 +41,64 @@ type LoggerConfig struct {
 	Level string
 }
 
-// AIConfig holds AI/OpenAI configuration
+// Language model providers
+const (
+	ProviderOpenAI     = "openai"
+	ProviderCompatible = "compatible" // Self-hosted OpenAI-compatible server: Ollama, vLLM, llama.cpp
+)
+
+// AIConfig holds AI and language model configuration
 type AIConfig struct {
-	OpenAIAPIKey           string
-	CustomSearchAPIKey     string
-	CustomSearchEngineID   string
+	Provider              string // Language model provider: "openai" or "compatible"
+	OpenAIAPIKey          string
+	BaseURL               string // API base URL of the compatible provider, e.g. http://localhost:11434/v1
+	ProviderAPIKey        string // API key of the compatible provider, if it requires one
+	AnalysisModel         string // Model analysing questions and classifying, scoring, extracting and reranking articles
+	AnswerModel           string // Model answering questions
+	SummaryModel          string // Model summarizing articles and conversations
+	EmbeddingModel        string // Model embedding article chunks
+	EmbeddingDimensions   int    // Vector length of the embedding model
+	CustomSearchAPIKey    string
+	CustomSearchEngineID  string
+	LLMClassifier         bool          // Classify articles with the LLM provider instead of keyword rules
+	LLMSentiment          bool          // Score article sentiment with the LLM provider instead of the lexicon
+	LLMContractExtraction bool          // Extract contract awards with the LLM provider when the rules find none
+	LLMEmbeddings         bool          // Embed article chunks with the LLM provider; when off, the offline hashing embedder is used
+	VectorIndex           string        // In-process vector index: "flat" (exact) or "hnsw" (approximate)
+	EmbeddingReload       time.Duration // How often newly stored chunks are loaded into the vector index; 0 disables reloading
+	LLMReranker           bool          // Rerank retrieved articles with the LLM provider instead of the heuristic reranker
+	LexicalWeight         float64       // Weight of full-text search in retrieval rank fusion
+	SemanticWeight        float64       // Weight of vector search in retrieval rank fusion
+	// ContextBudgets override the tokens of article text sent to each model
+	ContextBudgets map[string]int
+}
+
+// LLMConfigured reports whether the language model provider has the
+// settings it needs; without them, AI components use their offline defaults
+func (c AIConfig) LLMConfigured() bool {
+	if c.Provider == ProviderCompatible {
+		return c.BaseURL != ""
+	}
+	return c.OpenAIAPIKey != ""
+}
+
+// FeedConfig holds feed ingestion configuration
+type FeedConfig struct {
+	Workers            int
+	PerHostConcurrency int
+	PerHostDelay       time.Duration
+	FeedTimeout        time.Duration
+	MinInterval        time.Duration // Shortest interval between fetches of a feed
+	MaxInterval        time.Duration // Longest interval between fetches, including backoff
+	DefaultInterval    time.Duration // Interval for feeds without publish history
+	SchedulerTick      time.Duration // How often the scheduler checks for due feeds
+}
+
+// ExtractConfig holds full-text extraction configuration
+type ExtractConfig struct {
+	Enabled      bool
+	Timeout      time.Duration // Timeout for a single page request
+	PerHostDelay time.Duration // Minimum delay between page requests to the same host
 }
 
 // Load loads configuration from environment variables with sensible defaults
@@ -52,6 +109,8 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	provider := getEnv("AI_PROVIDER", ProviderOpenAI)
+
 	config := &Config{
 		Server: ServerConfig{
 			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
@@ -59,6 +118,7 @@ func Load() (*Config, error) {
 			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
 			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 15*time.Second),
 			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			AdminAPIKey:  getEnv("ADMIN_API_KEY", ""),
 		},
 		Database: DatabaseConfig{
 			URI: getEnv("DATABASE_URI", "mongodb://localhost:27017/october"),
@@ -67,9 +127,42 @@ func Load() (*Config, error) {
 			Level: getEnv("LOG_LEVEL", "info"),
 		},
 		AI: AIConfig{
-			OpenAIAPIKey:         getEnv("OPENAI_API_KEY", ""),
-			CustomSearchAPIKey:   getEnv("CUSTOM_SEARCH_API_KEY", ""),
-			CustomSearchEngineID: getEnv("CUSTOM_SEARCH_ENGINE_ID", ""),
+			Provider:              provider,
+			OpenAIAPIKey:          getEnv("OPENAI_API_KEY", ""),
+			BaseURL:               getEnv("AI_BASE_URL", ""),
+			ProviderAPIKey:        getEnv("AI_PROVIDER_API_KEY", ""),
+			AnalysisModel:         getEnv("AI_ANALYSIS_MODEL", "gpt-4o-mini"),
+			AnswerModel:           getEnv("AI_ANSWER_MODEL", "gpt-4o-mini"),
+			SummaryModel:          getEnv("AI_SUMMARY_MODEL", "gpt-4o-mini"),
+			EmbeddingModel:        getEnv("AI_EMBEDDING_MODEL", defaultEmbeddingModel(provider)),
+			EmbeddingDimensions:   getIntEnv("AI_EMBEDDING_DIMENSIONS", 1536),
+			CustomSearchAPIKey:    getEnv("CUSTOM_SEARCH_API_KEY", ""),
+			CustomSearchEngineID:  getEnv("CUSTOM_SEARCH_ENGINE_ID", ""),
+			LLMClassifier:         getBoolEnv("AI_LLM_CLASSIFIER", false),
+			LLMSentiment:          getBoolEnv("AI_LLM_SENTIMENT", false),
+			LLMContractExtraction: getBoolEnv("AI_LLM_CONTRACT_EXTRACTION", false),
+			LLMEmbeddings:         getBoolEnv("AI_LLM_EMBEDDINGS", provider != ProviderCompatible),
+			VectorIndex:           getEnv("AI_VECTOR_INDEX", "flat"),
+			EmbeddingReload:       getDurationEnv("AI_EMBEDDING_RELOAD_INTERVAL", 5*time.Minute),
+			LLMReranker:           getBoolEnv("AI_LLM_RERANKER", false),
+			LexicalWeight:         getFloatEnv("AI_RETRIEVAL_LEXICAL_WEIGHT", 1),
+			SemanticWeight:        getFloatEnv("AI_RETRIEVAL_SEMANTIC_WEIGHT", 1),
+			ContextBudgets:        getIntMapEnv("AI_CONTEXT_TOKEN_BUDGETS"),
+		},
+		Feed: FeedConfig{
+			Workers:            getIntEnv("FEED_WORKERS", 4),
+			PerHostConcurrency: getIntEnv("FEED_PER_HOST_CONCURRENCY", 1),
+			PerHostDelay:       getDurationEnv("FEED_PER_HOST_DELAY", 2*time.Second),
+			FeedTimeout:        getDurationEnv("FEED_TIMEOUT", 2*time.Minute),
+			MinInterval:        getDurationEnv("FEED_MIN_INTERVAL", 15*time.Minute),
+			MaxInterval:        getDurationEnv("FEED_MAX_INTERVAL", 12*time.Hour),
+			DefaultInterval:    getDurationEnv("FEED_DEFAULT_INTERVAL", 2*time.Hour),
+			SchedulerTick:      getDurationEnv("FEED_SCHEDULER_TICK", time.Minute),
+		},
+		Extract: ExtractConfig{
+			Enabled:      getBoolEnv("EXTRACT_ENABLED", true),
+			Timeout:      getDurationEnv("EXTRACT_TIMEOUT", 20*time.Second),
+			PerHostDelay: getDurationEnv("EXTRACT_PER_HOST_DELAY", time.Second),
 		},
 	}
 
@@ -110,8 +203,57 @@ func (c *Config) validate() error {
 		return fmt.Errorf("invalid log level: %s", c.Logger.Level)
 	}
 
-	if c.AI.OpenAIAPIKey == "" {
-		return fmt.Errorf("OpenAI API key cannot be empty")
+	if c.Feed.Workers < 0 || c.Feed.PerHostConcurrency < 0 {
+		return fmt.Errorf("feed worker settings cannot be negative")
+	}
+
+	if c.Feed.MinInterval < 0 || c.Feed.MaxInterval < 0 || c.Feed.DefaultInterval < 0 {
+		return fmt.Errorf("feed intervals cannot be negative")
+	}
+
+	if c.Feed.MaxInterval > 0 && c.Feed.MinInterval > c.Feed.MaxInterval {
+		return fmt.Errorf("feed min interval %s exceeds max interval %s", c.Feed.MinInterval, c.Feed.MaxInterval)
+	}
+
+	if c.Extract.Timeout < 0 || c.Extract.PerHostDelay < 0 {
+		return fmt.Errorf("extract settings cannot be negative")
+	}
+
+	if c.AI.VectorIndex != "" && c.AI.VectorIndex != "flat" && c.AI.VectorIndex != "hnsw" {
+		return fmt.Errorf("invalid vector index: %s", c.AI.VectorIndex)
+	}
+
+	if c.AI.EmbeddingReload < 0 {
+		return fmt.Errorf("embedding reload interval cannot be negative")
+	}
+
+	if c.AI.LexicalWeight < 0 || c.AI.SemanticWeight < 0 {
+		return fmt.Errorf("retrieval weights cannot be negative")
+	}
+
+	switch c.AI.Provider {
+	case "", ProviderOpenAI:
+		if c.AI.OpenAIAPIKey == "" {
+			return fmt.Errorf("OpenAI API key cannot be empty")
+		}
+	case ProviderCompatible:
+		if c.AI.BaseURL == "" {
+			return fmt.Errorf("AI base URL cannot be empty for the compatible provider")
+		}
+	default:
+		return fmt.Errorf("invalid AI provider: %s", c.AI.Provider)
+	}
+
+	if c.AI.AnalysisModel == "" || c.AI.AnswerModel == "" || c.AI.SummaryModel == "" {
+		return fmt.Errorf("AI models cannot be empty")
+	}
+
+	if c.AI.LLMEmbeddings && c.AI.EmbeddingModel == "" {
+		return fmt.Errorf("embedding model cannot be empty when LLM embeddings are enabled")
+	}
+
+	if c.AI.EmbeddingDimensions <= 0 {
+		return fmt.Errorf("embedding dimensions must be positive")
 	}
 
 	if c.AI.CustomSearchAPIKey == "" {
@@ -125,6 +267,16 @@ func (c *Config) validate() error {
 	return nil
 }
 
+// defaultEmbeddingModel returns the embedding model used when none is set.
+// Self-hosted servers have no model in common, so the compatible provider
+// has no default and its embeddings are off unless a model is configured.
+func defaultEmbeddingModel(provider string) string {
+	if provider == ProviderCompatible {
+		return ""
+	}
+	return "text-embedding-3-small"
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -141,4 +293,50 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getIntEnv gets an integer from environment variable or returns default
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getFloatEnv gets a float from environment variable or returns default
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getIntMapEnv gets comma-separated key=integer pairs from environment
+// variable, skipping malformed pairs
+func getIntMapEnv(key string) map[string]int {
+	values := make(map[string]int)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if parsed, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			values[strings.TrimSpace(name)] = parsed
+		}
+	}
+	return values
+}
+
+// getBoolEnv gets a boolean from environment variable or returns default
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
@@ -130,6 +130,69 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "compatible provider without base URL",
+			config: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: "8080",
+				},
+				Database: DatabaseConfig{
+					URI: "mongodb://localhost:27017/test",
+				},
+				Logger: LoggerConfig{
+					Level: "info",
+				},
+				AI: AIConfig{
+					Provider: ProviderCompatible,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "compatible provider embedding without a model",
+			config: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: "8080",
+				},
+				Database: DatabaseConfig{
+					URI: "mongodb://localhost:27017/test",
+				},
+				Logger: LoggerConfig{
+					Level: "info",
+				},
+				AI: AIConfig{
+					Provider:      ProviderCompatible,
+					BaseURL:       "http://localhost:11434/v1",
+					AnalysisModel: "llama3.1:8b",
+					AnswerModel:   "llama3.1:8b",
+					SummaryModel:  "llama3.1:8b",
+					LLMEmbeddings: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown provider",
+			config: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: "8080",
+				},
+				Database: DatabaseConfig{
+					URI: "mongodb://localhost:27017/test",
+				},
+				Logger: LoggerConfig{
+					Level: "info",
+				},
+				AI: AIConfig{
+					Provider:     "azure",
+					OpenAIAPIKey: "test-key",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
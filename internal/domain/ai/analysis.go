@@ -0,0 +1,175 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// maxAnalysisTerms bounds the keywords and search terms of an analysis
+const maxAnalysisTerms = 10
+
+// QueryAnalyzer extracts the intent, entities and filters of a question.
+// Company names are returned as written in the question; the service
+// resolves them to known companies. A time window may hold only the
+// expression naming it, for the service to resolve.
+type QueryAnalyzer interface {
+	Analyze(ctx context.Context, question string) (*QueryAnalysisResult, error)
+}
+
+// Valid reports whether t is one of the known query types
+func (t QueryType) Valid() bool {
+	switch t {
+	case QueryTypeFinancial, QueryTypeContracts, QueryTypeGeneral, QueryTypeComparison, QueryTypeNews:
+		return true
+	}
+	return false
+}
+
+// Normalize trims, deduplicates and bounds the analysis terms, and reports
+// a query type or time window period that is not recognised
+func (r *QueryAnalysisResult) Normalize() error {
+	if !r.QueryType.Valid() {
+		return fmt.Errorf("%w: unknown query type %q", ErrInvalidQuery, r.QueryType)
+	}
+	if r.TimeWindow != nil {
+		if r.TimeWindow.Period != "" && !validPeriods[r.TimeWindow.Period] {
+			return fmt.Errorf("%w: unknown time window %q", ErrInvalidQuery, r.TimeWindow.Period)
+		}
+		r.TimeWindow.Expression = strings.TrimSpace(r.TimeWindow.Expression)
+		if r.TimeWindow.StartDate == nil && r.TimeWindow.EndDate == nil && r.TimeWindow.Period == "" && r.TimeWindow.Expression == "" {
+			r.TimeWindow = nil
+		}
+	}
+
+	r.CompanyNames = cleanTerms(r.CompanyNames, maxAnalysisTerms)
+	r.Keywords = cleanTerms(r.Keywords, maxAnalysisTerms)
+	r.SearchTerms = cleanTerms(r.SearchTerms, maxAnalysisTerms)
+	return nil
+}
+
+// cleanTerms trims terms and drops empty and duplicate ones, ignoring case,
+// keeping at most limit
+func cleanTerms(terms []string, limit int) []string {
+	cleaned := make([]string, 0, len(terms))
+	for _, term := range terms {
+		term = strings.Join(strings.Fields(term), " ")
+		if term == "" || slices.ContainsFunc(cleaned, func(existing string) bool { return strings.EqualFold(existing, term) }) {
+			continue
+		}
+		cleaned = append(cleaned, term)
+		if len(cleaned) == limit {
+			break
+		}
+	}
+	return cleaned
+}
+
+// QueryCategories maps the query type and the question's own keywords to
+// article categories
+func QueryCategories(queryType QueryType, question string) []news.Category {
+	categories := news.NewRuleClassifier().ClassifyText(question, "")
+
+	var implied news.Category
+	switch queryType {
+	case QueryTypeFinancial:
+		implied = news.CategoryEarnings
+	case QueryTypeContracts:
+		implied = news.CategoryContracts
+	}
+	if implied != "" && !slices.Contains(categories, implied) {
+		categories = append(categories, implied)
+	}
+
+	return categories
+}
+
+// queryTypeWords are the words that indicate each query type, checked in order
+var queryTypeWords = []struct {
+	queryType QueryType
+	words     []string
+}{
+	{QueryTypeComparison, []string{"compare", "compared", "comparison", "versus", "vs"}},
+	{QueryTypeFinancial, []string{"earnings", "revenue", "revenues", "financial", "financials", "profit", "profits", "guidance", "quarterly", "quarter", "sales", "eps", "margin", "margins"}},
+	{QueryTypeContracts, []string{"contract", "contracts", "award", "awards", "awarded", "deal", "deals", "order", "orders", "procurement", "idiq"}},
+	{QueryTypeNews, []string{"latest", "news", "recent", "recently", "update", "updates", "happening"}},
+}
+
+// fillerWords carry no search value in questions
+var fillerWords = map[string]bool{
+	"tell": true, "me": true, "any": true, "there": true, "give": true, "show": true, "list": true,
+	"please": true, "can": true, "you": true, "we": true, "they": true, "their": true, "been": true,
+	"latest": true, "recent": true, "recently": true, "news": true, "update": true, "updates": true,
+	"happening": true, "happened": true, "know": true, "some": true, "much": true, "many": true,
+	"why": true, "where": true, "should": true, "would": true, "could": true, "into": true,
+}
+
+// RuleQueryAnalyzer analyses questions deterministically with word rules.
+// It works offline and is the fallback of the model-based analyzer.
+type RuleQueryAnalyzer struct {
+	times *TimeResolver
+}
+
+// NewRuleQueryAnalyzer creates a rule-based query analyzer
+func NewRuleQueryAnalyzer() *RuleQueryAnalyzer {
+	return &RuleQueryAnalyzer{times: NewTimeResolver(nil, nil)}
+}
+
+// Analyze implements QueryAnalyzer. Company names are left to the service,
+// which resolves the companies mentioned in the question.
+func (a *RuleQueryAnalyzer) Analyze(ctx context.Context, question string) (*QueryAnalysisResult, error) {
+	words := news.Keywords(question)
+
+	queryType := QueryTypeGeneral
+	for _, rule := range queryTypeWords {
+		if slices.ContainsFunc(words, func(word string) bool { return slices.Contains(rule.words, word) }) {
+			queryType = rule.queryType
+			break
+		}
+	}
+
+	var keywords []string
+	for _, word := range words {
+		if !fillerWords[word] {
+			keywords = append(keywords, word)
+		}
+	}
+
+	analysis := &QueryAnalysisResult{
+		QueryType:    queryType,
+		CompanyNames: []string{},
+		Keywords:     keywords,
+		SearchTerms:  quotedPhrases(question),
+		Categories:   QueryCategories(queryType, question),
+	}
+	if window, ok := a.times.Resolve(question); ok {
+		analysis.TimeWindow = window
+	}
+	if err := analysis.Normalize(); err != nil {
+		return nil, err
+	}
+	return analysis, nil
+}
+
+// quotedPhrases returns the phrases quoted in the question
+func quotedPhrases(question string) []string {
+	var phrases []string
+	var phrase strings.Builder
+	inQuote := false
+	for _, r := range question {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			if inQuote {
+				phrases = append(phrases, phrase.String())
+				phrase.Reset()
+			}
+			inQuote = !inQuote
+		case inQuote:
+			phrase.WriteRune(r)
+		}
+	}
+	return phrases
+}
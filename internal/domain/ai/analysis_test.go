@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRuleQueryAnalyzer(t *testing.T) {
+	tests := []struct {
+		question  string
+		queryType QueryType
+		period    string
+		keywords  []string
+	}{
+		{"What were RTX earnings this quarter?", QueryTypeFinancial, PeriodThisQuarter, []string{"rtx", "earnings", "quarter"}},
+		{"Compare Lockheed and Boeing contract wins", QueryTypeComparison, "", []string{"compare", "lockheed", "boeing", "contract", "wins"}},
+		{"Tell me about the latest Army contract awards", QueryTypeContracts, PeriodRecent, []string{"army", "contract", "awards"}},
+		{"Any news on hypersonic tests?", QueryTypeNews, "", []string{"hypersonic", "tests"}},
+		{"Who builds the B-21?", QueryTypeGeneral, "", []string{"builds", "21"}},
+	}
+
+	analyzer := NewRuleQueryAnalyzer()
+	for _, tt := range tests {
+		t.Run(tt.question, func(t *testing.T) {
+			analysis, err := analyzer.Analyze(context.Background(), tt.question)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if analysis.QueryType != tt.queryType {
+				t.Errorf("QueryType = %s, want %s", analysis.QueryType, tt.queryType)
+			}
+			period := ""
+			if analysis.TimeWindow != nil {
+				period = analysis.TimeWindow.Period
+			}
+			if period != tt.period {
+				t.Errorf("Period = %q, want %q", period, tt.period)
+			}
+			if !slices.Equal(analysis.Keywords, tt.keywords) {
+				t.Errorf("Keywords = %q, want %q", analysis.Keywords, tt.keywords)
+			}
+		})
+	}
+}
+
+func TestRuleQueryAnalyzerQuotedPhrases(t *testing.T) {
+	analysis, err := NewRuleQueryAnalyzer().Analyze(context.Background(), `Status of the "Next Generation Interceptor" and “Golden Dome” programs`)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if !slices.Equal(analysis.SearchTerms, []string{"Next Generation Interceptor", "Golden Dome"}) {
+		t.Errorf("SearchTerms = %q", analysis.SearchTerms)
+	}
+}
+
+func TestQueryAnalysisNormalize(t *testing.T) {
+	analysis := &QueryAnalysisResult{
+		QueryType:    QueryTypeContracts,
+		CompanyNames: []string{" RTX ", "rtx", ""},
+		Keywords:     []string{"contract", "  award\tvalue ", "Contract"},
+	}
+	if err := analysis.Normalize(); err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if !slices.Equal(analysis.CompanyNames, []string{"RTX"}) || !slices.Equal(analysis.Keywords, []string{"contract", "award value"}) {
+		t.Errorf("Unexpected normalized terms %q and %q", analysis.CompanyNames, analysis.Keywords)
+	}
+
+	invalid := &QueryAnalysisResult{QueryType: "gossip"}
+	if err := invalid.Normalize(); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery for an unknown query type, got %v", err)
+	}
+	invalid = &QueryAnalysisResult{QueryType: QueryTypeNews, TimeWindow: &TimeWindow{Period: "someday"}}
+	if err := invalid.Normalize(); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery for an unknown period, got %v", err)
+	}
+}
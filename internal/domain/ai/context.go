@@ -0,0 +1,185 @@
+package ai
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// DefaultContextBudget is the article context budget, in tokens, of models
+// without a budget of their own
+const DefaultContextBudget = 3000
+
+// sourceHeaderTokens approximates the tokens of a source's title, company,
+// date and URL lines in the context
+const sourceHeaderTokens = 40
+
+// Citation points an answer's [n] marker at a passage of a source article.
+// Start and End are character offsets into the article's body text.
+type Citation struct {
+	Marker int `json:"marker"`
+	Chunk  int `json:"chunk"` // Index of the chunk in the article
+	Start  int `json:"start"`
+	End    int `json:"end"`
+}
+
+// TokenBudgets are the tokens of article context given to each model
+type TokenBudgets map[string]int
+
+// DefaultTokenBudgets returns budgets that leave room in each model's
+// context window for the prompts, the conversation and the answer
+func DefaultTokenBudgets() TokenBudgets {
+	return TokenBudgets{
+		"gpt-4o-mini":   6000,
+		"gpt-4o":        6000,
+		"gpt-4-turbo":   6000,
+		"gpt-4":         3000,
+		"gpt-3.5-turbo": 2500,
+	}
+}
+
+// NewTokenBudgets returns the default budgets with overrides applied
+func NewTokenBudgets(overrides map[string]int) TokenBudgets {
+	budgets := DefaultTokenBudgets()
+	for model, budget := range overrides {
+		if budget > 0 {
+			budgets[model] = budget
+		}
+	}
+	return budgets
+}
+
+// For returns the budget of the model, or DefaultContextBudget
+func (b TokenBudgets) For(model string) int {
+	if budget, ok := b[model]; ok {
+		return budget
+	}
+	return DefaultContextBudget
+}
+
+// ContextPassage is a chunk of a source article selected as context
+type ContextPassage struct {
+	Marker int // The passage's [n] marker, numbered from 1 in context order
+	Source int // Index of the source the passage comes from
+	Chunk  news.ContentChunk
+}
+
+// ContextBuilder selects the article passages that best answer a question
+// within a token budget
+type ContextBuilder struct {
+	budget int
+}
+
+// NewContextBuilder creates a context builder packing up to budget tokens
+func NewContextBuilder(budget int) *ContextBuilder {
+	if budget <= 0 {
+		budget = DefaultContextBudget
+	}
+	return &ContextBuilder{budget: budget}
+}
+
+// Pack selects passages of the sources' articles, given in the same order,
+// and sets each source's Citations. Every source in rank order first gets its
+// passage sharing most terms with the question, then further matching
+// passages are added round by round until the budget is spent. A nil article
+// is represented by the source's summary. Passages are returned grouped by
+// source in reading order.
+func (b *ContextBuilder) Pack(question string, keywords []string, sources []SourceReference, articles []*news.Article) []ContextPassage {
+	queryTerms := make(map[string]bool)
+	for _, term := range news.SearchTerms(question) {
+		queryTerms[term] = true
+	}
+	for _, keyword := range keywords {
+		for _, term := range news.SearchTerms(keyword) {
+			queryTerms[term] = true
+		}
+	}
+
+	// Rank each source's chunks by how well they match the question
+	type scoredChunk struct {
+		chunk news.ContentChunk
+		score float64
+	}
+	ranked := make([][]scoredChunk, len(sources))
+	for i := range sources {
+		article := &news.Article{Summary: sources[i].Summary}
+		if i < len(articles) && articles[i] != nil {
+			article = articles[i]
+		}
+		for _, chunk := range news.ArticleContentChunks(article) {
+			ranked[i] = append(ranked[i], scoredChunk{chunk: chunk, score: termOverlap(chunk.Text, queryTerms)})
+		}
+		sort.SliceStable(ranked[i], func(a, c int) bool {
+			return ranked[i][a].score > ranked[i][c].score
+		})
+	}
+
+	selected := make([][]news.ContentChunk, len(sources))
+	remaining := b.budget
+	for round := 0; ; round++ {
+		added := false
+		for i := range sources {
+			if round >= len(ranked[i]) {
+				continue
+			}
+			candidate := ranked[i][round]
+			if round > 0 && candidate.score == 0 {
+				continue
+			}
+			cost := candidate.chunk.Tokens
+			if len(selected[i]) == 0 {
+				cost += sourceHeaderTokens
+			}
+			if cost > remaining {
+				continue
+			}
+			selected[i] = append(selected[i], candidate.chunk)
+			remaining -= cost
+			added = true
+		}
+		if !added {
+			break
+		}
+	}
+
+	var passages []ContextPassage
+	for i := range sources {
+		sort.Slice(selected[i], func(a, c int) bool {
+			return selected[i][a].Start < selected[i][c].Start
+		})
+		sources[i].Citations = nil
+		for _, chunk := range selected[i] {
+			marker := len(passages) + 1
+			passages = append(passages, ContextPassage{Marker: marker, Source: i, Chunk: chunk})
+			sources[i].Citations = append(sources[i].Citations, Citation{
+				Marker: marker,
+				Chunk:  chunk.Index,
+				Start:  chunk.Start,
+				End:    chunk.End,
+			})
+		}
+	}
+	return passages
+}
+
+// termOverlap scores text by the question terms it contains, with
+// diminishing returns for repeated terms
+func termOverlap(text string, queryTerms map[string]bool) float64 {
+	if len(queryTerms) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int)
+	for _, term := range news.SearchTerms(text) {
+		if queryTerms[term] {
+			counts[term]++
+		}
+	}
+
+	score := 0.0
+	for _, count := range counts {
+		score += 1 + math.Log(float64(count))
+	}
+	return score
+}
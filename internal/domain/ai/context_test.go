@@ -0,0 +1,69 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+func TestContextBuilderPack(t *testing.T) {
+	filler := strings.Repeat("The company also discussed its commercial aviation business at length. ", 20)
+	articles := []*news.Article{
+		{Content: filler + "The Army awarded a hypersonic missile contract worth $2 billion. " + filler},
+		{Content: "Shipbuilding output rose this quarter. " + filler},
+	}
+	sources := []SourceReference{{ArticleID: "a"}, {ArticleID: "b"}}
+
+	passages := NewContextBuilder(700).Pack("Who won the hypersonic missile contract?", nil, sources, articles)
+	if len(passages) == 0 {
+		t.Fatal("Expected passages")
+	}
+
+	tokens := 0
+	for i, passage := range passages {
+		if passage.Marker != i+1 {
+			t.Errorf("Passage %d has marker %d", i, passage.Marker)
+		}
+		tokens += passage.Chunk.Tokens
+	}
+	if tokens > 700 {
+		t.Errorf("Packed %d tokens, over the budget", tokens)
+	}
+
+	if passages[0].Source != 0 || !strings.Contains(passages[0].Chunk.Text, "hypersonic missile contract") {
+		t.Errorf("Expected the matching passage of the first source first, got %+v", passages[0])
+	}
+	if len(sources[1].Citations) == 0 {
+		t.Error("Expected the second source to get a passage")
+	}
+
+	citation := sources[0].Citations[0]
+	body := []rune(articles[0].BodyText())
+	if string(body[citation.Start:citation.End]) != passages[0].Chunk.Text {
+		t.Error("Expected the citation offsets to locate the passage in the body text")
+	}
+}
+
+func TestContextBuilderUsesSummaryWithoutArticle(t *testing.T) {
+	sources := []SourceReference{{ArticleID: "a", Summary: "Boeing delayed the Starliner flight."}}
+
+	passages := NewContextBuilder(0).Pack("Starliner delay", nil, sources, nil)
+	if len(passages) != 1 || passages[0].Chunk.Text != sources[0].Summary {
+		t.Errorf("Expected the summary as the only passage, got %+v", passages)
+	}
+}
+
+func TestTokenBudgets(t *testing.T) {
+	budgets := NewTokenBudgets(map[string]int{"gpt-4o": 12000, "llama3": 2000})
+
+	if budgets.For("gpt-4o") != 12000 || budgets.For("llama3") != 2000 {
+		t.Errorf("Expected overrides applied, got %v", budgets)
+	}
+	if budgets.For("gpt-4o-mini") != DefaultTokenBudgets()["gpt-4o-mini"] {
+		t.Error("Expected default budgets kept")
+	}
+	if budgets.For("unknown") != DefaultContextBudget {
+		t.Error("Expected the default budget for unknown models")
+	}
+}
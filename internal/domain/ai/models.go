@@ -32,6 +32,13 @@ type SourceReference struct {
 	PublishedDate time.Time `json:"published_date"`
 	SourceURL string `json:"source_url"`
 	RelevanceScore float64 `json:"relevance_score"`
+	// Scores break down how retrieval ranked the article
+	Scores *RetrievalScores `json:"scores,omitempty"`
+	// Citations locate the passages of the article given to the model
+	Citations []Citation `json:"citations,omitempty"`
+	// Article is the retrieved article, kept to build the answer's context
+	// without loading it again; it is never serialized
+	Article *news.Article `json:"-" bson:"-"`
 }
 
 // WebSearchSource represents a web search result used as context
@@ -66,18 +73,20 @@ const (
 
 // TimeWindow represents a time-based filter for queries
 type TimeWindow struct {
-	StartDate *time.Time
-	EndDate   *time.Time
-	Period    string // "this quarter", "this year", "recent", etc.
+	StartDate  *time.Time `json:"start_date,omitempty"`
+	EndDate    *time.Time `json:"end_date,omitempty"`   // Inclusive
+	Period     string     `json:"period"`               // "this_quarter", "fiscal_year", "since", etc.
+	Expression string     `json:"expression,omitempty"` // The words of the question naming the window
 }
 
 // QueryAnalysisResult represents the analysis of a user query
 type QueryAnalysisResult struct {
-	QueryType QueryType
-	CompanyNames []string
-	Keywords []string
-	TimeWindow *TimeWindow
-	SearchTerms []string
+	QueryType    QueryType       `json:"query_type"`
+	CompanyNames []string        `json:"company_names"`
+	Keywords     []string        `json:"keywords"`
+	TimeWindow   *TimeWindow     `json:"time_window,omitempty"`
+	SearchTerms  []string        `json:"search_terms"`
+	Categories   []news.Category `json:"categories,omitempty"` // Article categories matching the question
 }
 
 // ArticleSummaryResponse represents the AI-generated summary of an article
@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// RetrievalScores records how each retrieval stage scored an article. Ranks
+// start at 1; zero means the stage did not return the article.
+type RetrievalScores struct {
+	Lexical      float64 `json:"lexical,omitempty"` // Full-text search score
+	LexicalRank  int     `json:"lexical_rank,omitempty"`
+	Semantic     float64 `json:"semantic,omitempty"` // Cosine similarity of the best chunk
+	SemanticRank int     `json:"semantic_rank,omitempty"`
+	Fusion       float64 `json:"fusion"` // Weighted reciprocal rank fusion of both rankings
+	Rerank       float64 `json:"rerank"` // Final score the sources are ordered by
+}
+
+// RetrievalCandidate is an article retrieved for a question, before reranking
+type RetrievalCandidate struct {
+	Article *news.Article
+	Scores  RetrievalScores
+}
+
+// Reranker scores retrieval candidates for a question by setting their
+// Rerank score; higher is more relevant
+type Reranker interface {
+	Rerank(ctx context.Context, question string, analysis *QueryAnalysisResult, candidates []*RetrievalCandidate) error
+}
+
+// HeuristicWeights weigh the signals of the heuristic reranker
+type HeuristicWeights struct {
+	Relevance float64 // The article's company relevance score
+	Match     float64 // Fusion score relative to the best candidate
+	Category  float64 // Article classified in a category the question asks about
+	Recency   float64 // Freshness, halving every RecencyHalfLife
+	// RecencyHalfLife is the article age at which the recency signal halves
+	RecencyHalfLife time.Duration
+}
+
+// DefaultHeuristicWeights returns weights that favour the retrieval match,
+// with a small preference for fresh articles
+func DefaultHeuristicWeights() HeuristicWeights {
+	return HeuristicWeights{
+		Relevance:       0.5,
+		Match:           1.0,
+		Category:        0.2,
+		Recency:         0.1,
+		RecencyHalfLife: 30 * 24 * time.Hour,
+	}
+}
+
+// HeuristicReranker scores candidates offline from their retrieval match,
+// relevance score, categories and age
+type HeuristicReranker struct {
+	weights HeuristicWeights
+	now     func() time.Time
+}
+
+// NewHeuristicReranker creates a heuristic reranker
+func NewHeuristicReranker(weights HeuristicWeights) *HeuristicReranker {
+	return &HeuristicReranker{weights: weights, now: time.Now}
+}
+
+// Rerank implements Reranker
+func (r *HeuristicReranker) Rerank(ctx context.Context, question string, analysis *QueryAnalysisResult, candidates []*RetrievalCandidate) error {
+	maxFusion := 0.0
+	for _, candidate := range candidates {
+		maxFusion = math.Max(maxFusion, candidate.Scores.Fusion)
+	}
+
+	now := r.now()
+	for _, candidate := range candidates {
+		article := candidate.Article
+		score := r.weights.Relevance * article.RelevanceScore
+
+		if maxFusion > 0 {
+			score += r.weights.Match * candidate.Scores.Fusion / maxFusion
+		}
+
+		if analysis != nil {
+			for _, category := range analysis.Categories {
+				if article.HasCategory(category) {
+					score += r.weights.Category
+					break
+				}
+			}
+		}
+
+		if r.weights.RecencyHalfLife > 0 && !article.PublishedDate.IsZero() {
+			age := max(now.Sub(article.PublishedDate), 0)
+			score += r.weights.Recency * math.Pow(0.5, float64(age)/float64(r.weights.RecencyHalfLife))
+		}
+
+		candidate.Scores.Rerank = math.Round(score*1000) / 1000
+	}
+	return nil
+}
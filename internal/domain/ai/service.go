@@ -16,6 +16,9 @@ type Service interface {
 	// ProcessQuery processes a natural language query and returns an AI-generated response
 	ProcessQuery(ctx context.Context, req *QueryRequest) (*QueryResponse, error)
 	
+	// StreamQuery processes a query like ProcessQuery, sending the sources and then the answer as it is generated
+	StreamQuery(ctx context.Context, req *QueryRequest, send StreamSender) error
+	
 	// AnalyzeQuery analyzes the query to extract intent, companies, and search terms
 	AnalyzeQuery(ctx context.Context, question string) (*QueryAnalysisResult, error)
 	
@@ -28,9 +31,3 @@ type Service interface {
 	// GetCacheStats returns statistics about the summary cache
 	GetCacheStats() map[string]interface{}
 }
-
-// Repository defines the interface for AI-related data operations
-type Repository interface {
-	// SearchArticles performs semantic search on articles based on the analyzed query
-	SearchArticles(ctx context.Context, analysis *QueryAnalysisResult, limit int) ([]*SourceReference, error)
-}
\ No newline at end of file
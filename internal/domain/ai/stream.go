@@ -0,0 +1,46 @@
+package ai
+
+import "time"
+
+// Stream event types, in the order they are sent
+const (
+	StreamEventSources = "sources" // Once, before the answer
+	StreamEventDelta   = "delta"   // For each part of the answer
+	StreamEventDone    = "done"    // Once, after the answer
+	StreamEventError   = "error"   // Instead of done, when the answer fails
+)
+
+// StreamEvent is an event of a streamed answer; Data is a StreamSources,
+// StreamDelta, StreamDone or StreamError
+type StreamEvent struct {
+	Type string
+	Data interface{}
+}
+
+// StreamSources is sent before the answer with the sources it is based on
+type StreamSources struct {
+	Sources             []SourceReference `json:"sources"`
+	WebSources          []WebSearchSource `json:"web_sources,omitempty"`
+	UsedWebSearch       bool              `json:"used_web_search"`
+	CompaniesReferenced []string          `json:"companies_referenced"`
+}
+
+// StreamDelta is the next part of the answer
+type StreamDelta struct {
+	Text string `json:"text"`
+}
+
+// StreamDone is sent when the answer is complete
+type StreamDone struct {
+	Confidence     float64       `json:"confidence"`
+	ProcessingTime time.Duration `json:"processing_time"`
+}
+
+// StreamError is sent when the answer fails after streaming has started
+type StreamError struct {
+	Message string `json:"message"`
+}
+
+// StreamSender sends an event to the client. An error, such as the client
+// having disconnected, stops the stream.
+type StreamSender func(event StreamEvent) error
@@ -0,0 +1,499 @@
+package ai
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time window periods, describing how a window was resolved
+const (
+	PeriodRecent        = "recent"       // The last RecentWindow
+	PeriodThisQuarter   = "this_quarter" // The current calendar quarter
+	PeriodThisYear      = "this_year"    // The current calendar year, or the year to date
+	PeriodDay           = "day"
+	PeriodWeek          = "week"
+	PeriodMonth         = "month"
+	PeriodQuarter       = "quarter"
+	PeriodYear          = "year"
+	PeriodFiscalYear    = "fiscal_year"    // A US government fiscal year
+	PeriodFiscalQuarter = "fiscal_quarter" // A quarter of a US government fiscal year
+	PeriodRolling       = "rolling"        // A number of days, weeks, months or years up to now
+	PeriodSince         = "since"          // From a date or event up to now
+	PeriodEvent         = "event"          // A trade show or other known event
+)
+
+// RecentWindow is the window of questions about recent news
+const RecentWindow = 30 * 24 * time.Hour
+
+// eventTrailingDays extends event windows to cover the coverage published
+// after an event closes
+const eventTrailingDays = 7
+
+// validPeriods are the periods a time window may have
+var validPeriods = map[string]bool{
+	PeriodRecent: true, PeriodThisQuarter: true, PeriodThisYear: true, PeriodDay: true,
+	PeriodWeek: true, PeriodMonth: true, PeriodQuarter: true, PeriodYear: true,
+	PeriodFiscalYear: true, PeriodFiscalQuarter: true, PeriodRolling: true, PeriodSince: true,
+	PeriodEvent: true,
+}
+
+// DateRange is a range of days; End is the last day
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Event is a recurring event questions refer to, such as a trade show
+type Event struct {
+	Name        string
+	Aliases     []string // Lower-case names the event is referred to by
+	Occurrences []DateRange
+}
+
+// DefaultEvents returns the major defense and aerospace trade shows
+func DefaultEvents() []Event {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	return []Event{
+		{
+			Name:    "Paris Air Show",
+			Aliases: []string{"paris air show", "paris airshow", "le bourget"},
+			Occurrences: []DateRange{
+				{day(2019, time.June, 17), day(2019, time.June, 23)},
+				{day(2023, time.June, 19), day(2023, time.June, 25)},
+				{day(2025, time.June, 16), day(2025, time.June, 22)},
+			},
+		},
+		{
+			Name:    "Farnborough International Airshow",
+			Aliases: []string{"farnborough"},
+			Occurrences: []DateRange{
+				{day(2022, time.July, 18), day(2022, time.July, 22)},
+				{day(2024, time.July, 22), day(2024, time.July, 26)},
+				{day(2026, time.July, 20), day(2026, time.July, 24)},
+			},
+		},
+		{
+			Name:    "Dubai Airshow",
+			Aliases: []string{"dubai airshow", "dubai air show"},
+			Occurrences: []DateRange{
+				{day(2023, time.November, 13), day(2023, time.November, 17)},
+				{day(2025, time.November, 17), day(2025, time.November, 21)},
+			},
+		},
+		{
+			Name:    "AUSA Annual Meeting",
+			Aliases: []string{"ausa"},
+			Occurrences: []DateRange{
+				{day(2023, time.October, 9), day(2023, time.October, 11)},
+				{day(2024, time.October, 14), day(2024, time.October, 16)},
+				{day(2025, time.October, 13), day(2025, time.October, 15)},
+			},
+		},
+		{
+			Name:    "DSEI",
+			Aliases: []string{"dsei"},
+			Occurrences: []DateRange{
+				{day(2023, time.September, 12), day(2023, time.September, 15)},
+				{day(2025, time.September, 9), day(2025, time.September, 12)},
+			},
+		},
+		{
+			Name:    "Sea-Air-Space",
+			Aliases: []string{"sea-air-space", "sea air space"},
+			Occurrences: []DateRange{
+				{day(2024, time.April, 8), day(2024, time.April, 10)},
+				{day(2025, time.April, 7), day(2025, time.April, 9)},
+			},
+		},
+	}
+}
+
+// TimeResolver turns time expressions such as "this quarter", "Q2 2025",
+// "last 6 months", "since the Paris Air Show" or "FY24" into UTC date
+// ranges, relative to its clock. Fiscal years are US government fiscal
+// years, which start on October 1: FY24 runs from October 2023 to
+// September 2024.
+type TimeResolver struct {
+	now    func() time.Time
+	events []Event
+}
+
+// NewTimeResolver creates a time resolver. now is optional and defaults to
+// time.Now; events is optional and defaults to DefaultEvents.
+func NewTimeResolver(now func() time.Time, events []Event) *TimeResolver {
+	if now == nil {
+		now = time.Now
+	}
+	if events == nil {
+		events = DefaultEvents()
+	}
+	return &TimeResolver{now: now, events: events}
+}
+
+// timeRule resolves the matches of a pattern to a window [start, end), or
+// reports that the match is not a time expression
+type timeRule struct {
+	pattern *regexp.Regexp
+	resolve func(r *TimeResolver, match []string, now time.Time, anchored bool) (start, end time.Time, period string, ok bool)
+}
+
+const (
+	yearPattern  = `((?:19|20)\d{2})`
+	monthPattern = `(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sept|sep|oct|nov|dec)`
+	countPattern = `(\d+|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve)`
+)
+
+// timeRules are tried in order; the first that matches resolves the text
+var timeRules = []timeRule{
+	{
+		// Fiscal quarters: "Q1 FY25", "Q1 of fiscal 2025", "FY2025 Q1"
+		pattern: regexp.MustCompile(`\bq([1-4])\s+(?:of\s+)?(?:fy|fiscal(?:\s+year)?)\s*'?(\d{4}|\d{2})\b|\b(?:fy|fiscal(?:\s+year)?)\s*'?(\d{4}|\d{2})\s+q([1-4])\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			quarter, year := m[1], m[2]
+			if quarter == "" {
+				quarter, year = m[4], m[3]
+			}
+			q, _ := strconv.Atoi(quarter)
+			start := fiscalYearStart(parseYear(year)).AddDate(0, 3*(q-1), 0)
+			return start, start.AddDate(0, 3, 0), PeriodFiscalQuarter, true
+		},
+	},
+	{
+		// Fiscal years: "FY24", "FY 2024", "fiscal 2024", "fiscal year 2024"
+		pattern: regexp.MustCompile(`\b(?:fy|fiscal\s+year|fiscal)\s*'?(\d{4}|\d{2})\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			start := fiscalYearStart(parseYear(m[1]))
+			return start, start.AddDate(1, 0, 0), PeriodFiscalYear, true
+		},
+	},
+	{
+		// Relative fiscal years: "this fiscal year", "last fiscal year"
+		pattern: regexp.MustCompile(`\b(this|current|last|previous)\s+fiscal\s+year\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			year := now.Year()
+			if now.Month() >= time.October {
+				year++
+			}
+			if m[1] == "last" || m[1] == "previous" {
+				year--
+			}
+			start := fiscalYearStart(year)
+			return start, start.AddDate(1, 0, 0), PeriodFiscalYear, true
+		},
+	},
+	{
+		// Calendar quarters: "Q2 2025", "Q2 of 2025", "2025 Q2", "Q2"
+		pattern: regexp.MustCompile(`\bq([1-4])(?:\s+(?:of\s+)?` + yearPattern + `)?\b|\b` + yearPattern + `\s+q([1-4])\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			quarter, year := m[1], m[2]
+			if quarter == "" {
+				quarter, year = m[4], m[3]
+			}
+			q, _ := strconv.Atoi(quarter)
+			start := time.Date(now.Year(), time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, time.UTC)
+			if year != "" {
+				start = start.AddDate(parseYear(year)-now.Year(), 0, 0)
+			} else if start.After(now) {
+				// A quarter without a year is the last one that has started
+				start = start.AddDate(-1, 0, 0)
+			}
+			return start, start.AddDate(0, 3, 0), PeriodQuarter, true
+		},
+	},
+	{
+		// Rolling windows: "last 6 months", "past two weeks"
+		pattern: regexp.MustCompile(`\b(?:last|past|previous)\s+` + countPattern + `\s+(day|week|month|quarter|year)s?\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			n := parseCount(m[1])
+			if n <= 0 {
+				return time.Time{}, time.Time{}, "", false
+			}
+			return subtractUnits(now, m[2], n), now, PeriodRolling, true
+		},
+	},
+	{
+		// Calendar periods: "this quarter", "last month"; "past week" is rolling
+		pattern: regexp.MustCompile(`\b(this|current|last|previous|past)\s+(week|month|quarter|year)\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			if m[1] == "past" {
+				return subtractUnits(now, m[2], 1), now, PeriodRolling, true
+			}
+
+			start, period := periodStart(now, m[2])
+			if m[1] == "last" || m[1] == "previous" {
+				start = addUnits(start, m[2], -1)
+			} else {
+				switch m[2] {
+				case "quarter":
+					period = PeriodThisQuarter
+				case "year":
+					period = PeriodThisYear
+				}
+			}
+			return start, addUnits(start, m[2], 1), period, true
+		},
+	},
+	{
+		// The year to date
+		pattern: regexp.MustCompile(`\b(?:year to date|year-to-date|ytd)\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			start, _ := periodStart(now, "year")
+			return start, now, PeriodThisYear, true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`\b(today|yesterday)\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			start, _ := periodStart(now, "day")
+			if m[1] == "yesterday" {
+				start = start.AddDate(0, 0, -1)
+			}
+			return start, start.AddDate(0, 0, 1), PeriodDay, true
+		},
+	},
+	{
+		// Months: "March 2025", "in March". A month needs a year or a
+		// preposition, as "may" and "march" are also common words.
+		pattern: regexp.MustCompile(`\b(?:(in|during)\s+)?` + monthPattern + `\b(?:\s+(?:of\s+)?` + yearPattern + `)?`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			if m[1] == "" && m[3] == "" && !anchored {
+				return time.Time{}, time.Time{}, "", false
+			}
+			month := parseMonth(m[2])
+			start := time.Date(now.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+			if m[3] != "" {
+				start = start.AddDate(parseYear(m[3])-now.Year(), 0, 0)
+			} else if start.After(now) {
+				// A month without a year is the last one that has started
+				start = start.AddDate(-1, 0, 0)
+			}
+			return start, start.AddDate(0, 1, 0), PeriodMonth, true
+		},
+	},
+	{
+		// Years: "in 2024". A bare year needs a preposition, as it may be a figure.
+		pattern: regexp.MustCompile(`\b(?:(in|during)\s+)?` + yearPattern + `\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			if m[1] == "" && !anchored {
+				return time.Time{}, time.Time{}, "", false
+			}
+			start := time.Date(parseYear(m[2]), time.January, 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(1, 0, 0), PeriodYear, true
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`\b(?:recent|recently|latest|lately)\b`),
+		resolve: func(r *TimeResolver, m []string, now time.Time, anchored bool) (time.Time, time.Time, string, bool) {
+			return now.Add(-RecentWindow), now, PeriodRecent, true
+		},
+	},
+}
+
+// sincePattern matches "since" followed by the expression of the start date
+var sincePattern = regexp.MustCompile(`\bsince\s+(?:the\s+)?(.+)$`)
+
+// eventYearPattern matches the year following an event name
+var eventYearPattern = regexp.MustCompile(`^\s+` + yearPattern + `\b`)
+
+// Resolve finds the first time expression in text and returns its window,
+// or false when text names no period. Windows of past periods end at the
+// last instant of the period; windows up to now end now.
+func (r *TimeResolver) Resolve(text string) (*TimeWindow, bool) {
+	text = strings.ToLower(text)
+	now := r.now().UTC()
+
+	// "since X" runs from the start of X until now
+	if loc := sincePattern.FindStringSubmatchIndex(text); loc != nil {
+		if start, _, _, expression, ok := r.resolve(text[loc[2]:], now, true); ok && !start.After(now) {
+			return newTimeWindow(start, now, PeriodSince, strings.TrimSpace(text[loc[0]:loc[2]]+expression)), true
+		}
+	}
+
+	start, end, period, expression, ok := r.resolve(text, now, false)
+	if !ok {
+		return nil, false
+	}
+	if !end.Equal(now) {
+		// Periods end at the start of the next one
+		end = end.Add(-time.Nanosecond)
+	}
+	return newTimeWindow(start, end, period, expression), true
+}
+
+// resolve applies the time rules and events to text. When anchored, the
+// expression must start the text, and bare months and years are accepted.
+func (r *TimeResolver) resolve(text string, now time.Time, anchored bool) (start, end time.Time, period, expression string, ok bool) {
+	for _, rule := range timeRules {
+		for _, loc := range rule.pattern.FindAllStringSubmatchIndex(text, -1) {
+			if anchored && loc[0] != 0 {
+				break
+			}
+			match := submatches(text, loc)
+			if start, end, period, ok := rule.resolve(r, match, now, anchored); ok {
+				return start, end, period, match[0], true
+			}
+		}
+	}
+
+	return r.resolveEvent(text, now, anchored)
+}
+
+// resolveEvent finds a known event in text. It resolves to the occurrence
+// named by a following year, or else the last one that has started,
+// extended by eventTrailingDays. A year without a listed occurrence
+// resolves to the whole year.
+func (r *TimeResolver) resolveEvent(text string, now time.Time, anchored bool) (start, end time.Time, period, expression string, ok bool) {
+	for _, event := range r.events {
+		for _, alias := range event.Aliases {
+			index := indexWord(text, alias)
+			if index < 0 || (anchored && index != 0) {
+				continue
+			}
+
+			expression = alias
+			year := 0
+			if m := eventYearPattern.FindStringSubmatch(text[index+len(alias):]); m != nil {
+				year = parseYear(m[1])
+				expression += m[0]
+			}
+
+			var occurrence *DateRange
+			for i := range event.Occurrences {
+				o := &event.Occurrences[i]
+				if (year != 0 && o.Start.Year() == year) || (year == 0 && !o.Start.After(now) && (occurrence == nil || o.Start.After(occurrence.Start))) {
+					occurrence = o
+				}
+			}
+			if occurrence == nil && year != 0 {
+				start = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+				return start, start.AddDate(1, 0, 0), PeriodYear, expression, true
+			}
+			if occurrence == nil {
+				continue
+			}
+			return occurrence.Start, occurrence.End.AddDate(0, 0, 1+eventTrailingDays), PeriodEvent, expression, true
+		}
+	}
+	return time.Time{}, time.Time{}, "", "", false
+}
+
+// indexWord returns the index of the first occurrence of word in text that
+// is not part of a longer word, or -1
+func indexWord(text, word string) int {
+	for offset := 0; offset < len(text); {
+		index := strings.Index(text[offset:], word)
+		if index < 0 {
+			return -1
+		}
+		index += offset
+		end := index + len(word)
+		if (index == 0 || !isWordByte(text[index-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return index
+		}
+		offset = index + 1
+	}
+	return -1
+}
+
+// isWordByte reports whether b is a letter or digit
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// newTimeWindow creates a window between two UTC instants
+func newTimeWindow(start, end time.Time, period, expression string) *TimeWindow {
+	start, end = start.UTC(), end.UTC()
+	return &TimeWindow{StartDate: &start, EndDate: &end, Period: period, Expression: expression}
+}
+
+// submatches returns the matched groups at loc, with "" for groups that did not match
+func submatches(text string, loc []int) []string {
+	match := make([]string, len(loc)/2)
+	for i := range match {
+		if loc[2*i] >= 0 {
+			match[i] = text[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return match
+}
+
+// fiscalYearStart returns October 1 of the calendar year before the fiscal year
+func fiscalYearStart(fiscalYear int) time.Time {
+	return time.Date(fiscalYear-1, time.October, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// periodStart returns the start of the calendar unit containing t, and the unit's period
+func periodStart(t time.Time, unit string) (time.Time, string) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch unit {
+	case "week":
+		// Weeks start on Monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)), PeriodWeek
+	case "month":
+		return day.AddDate(0, 0, 1-day.Day()), PeriodMonth
+	case "quarter":
+		return time.Date(t.Year(), time.Month(3*((int(t.Month())-1)/3)+1), 1, 0, 0, 0, 0, time.UTC), PeriodQuarter
+	case "year":
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), PeriodYear
+	}
+	return day, PeriodDay
+}
+
+// addUnits adds n calendar units to t
+func addUnits(t time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "month":
+		return t.AddDate(0, n, 0)
+	case "quarter":
+		return t.AddDate(0, 3*n, 0)
+	case "year":
+		return t.AddDate(n, 0, 0)
+	}
+	return t.AddDate(0, 0, n)
+}
+
+// subtractUnits goes back n units from t
+func subtractUnits(t time.Time, unit string, n int) time.Time {
+	return addUnits(t, unit, -n)
+}
+
+// parseYear parses a four-digit year, or a two-digit year of this century
+func parseYear(s string) int {
+	year, _ := strconv.Atoi(s)
+	if year < 100 {
+		year += 2000
+	}
+	return year
+}
+
+// countWords are the numbers written out in rolling windows
+var countWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+// parseCount parses a count written in digits or words
+func parseCount(s string) int {
+	if n, ok := countWords[s]; ok {
+		return n
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// parseMonth parses a lower-case month name or abbreviation
+func parseMonth(s string) time.Month {
+	for month := time.January; month <= time.December; month++ {
+		if strings.HasPrefix(strings.ToLower(month.String()), s) {
+			return month
+		}
+	}
+	return time.January
+}
@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeResolverResolve(t *testing.T) {
+	now := time.Date(2025, time.August, 15, 12, 0, 0, 0, time.UTC) // A Friday
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	endOf := func(year int, month time.Month, d int) time.Time {
+		return day(year, month, d+1).Add(-time.Nanosecond)
+	}
+
+	tests := []struct {
+		text       string
+		start, end time.Time
+		period     string
+	}{
+		{"What did RTX report this quarter?", day(2025, time.July, 1), endOf(2025, time.September, 30), PeriodThisQuarter},
+		{"Boeing deliveries in Q2 2025", day(2025, time.April, 1), endOf(2025, time.June, 30), PeriodQuarter},
+		{"Lockheed Q4 results", day(2024, time.October, 1), endOf(2024, time.December, 31), PeriodQuarter},
+		{"Army awards over the last 6 months", now.AddDate(0, -6, 0), now, PeriodRolling},
+		{"Orders since the Paris Air Show", day(2025, time.June, 16), now, PeriodSince},
+		{"Northrop contracts since 2023", day(2023, time.January, 1), now, PeriodSince},
+		{"Navy shipbuilding budget for FY24", day(2023, time.October, 1), endOf(2024, time.September, 30), PeriodFiscalYear},
+		{"Awards in Q1 FY25", day(2024, time.October, 1), endOf(2024, time.December, 31), PeriodFiscalQuarter},
+		{"Spending in fiscal year 2026", day(2025, time.October, 1), endOf(2026, time.September, 30), PeriodFiscalYear},
+		{"Obligations this fiscal year", day(2024, time.October, 1), endOf(2025, time.September, 30), PeriodFiscalYear},
+		{"What happened last month?", day(2025, time.July, 1), endOf(2025, time.July, 31), PeriodMonth},
+		{"Contracts awarded last week", day(2025, time.August, 4), endOf(2025, time.August, 10), PeriodWeek},
+		{"GD earnings last year", day(2024, time.January, 1), endOf(2024, time.December, 31), PeriodYear},
+		{"Launches in March", day(2025, time.March, 1), endOf(2025, time.March, 31), PeriodMonth},
+		{"Launches in December", day(2024, time.December, 1), endOf(2024, time.December, 31), PeriodMonth},
+		{"Revenue September 2024", day(2024, time.September, 1), endOf(2024, time.September, 30), PeriodMonth},
+		{"Backlog year to date", day(2025, time.January, 1), now, PeriodThisYear},
+		{"Any awards yesterday?", day(2025, time.August, 14), endOf(2025, time.August, 14), PeriodDay},
+		{"Deals at Farnborough 2024", day(2024, time.July, 22), endOf(2024, time.August, 2), PeriodEvent},
+		{"Orders announced at the Paris Air Show", day(2025, time.June, 16), endOf(2025, time.June, 29), PeriodEvent},
+		{"Orders expected at the Paris Air Show 2027", day(2027, time.January, 1), endOf(2027, time.December, 31), PeriodYear},
+		{"Is there a causal link between AUSA and the awards?", day(2024, time.October, 14), endOf(2024, time.October, 23), PeriodEvent},
+		{"Drone news from the past week", now.AddDate(0, 0, -7), now, PeriodRolling},
+		{"Latest hypersonic tests", now.Add(-RecentWindow), now, PeriodRecent},
+	}
+
+	resolver := NewTimeResolver(func() time.Time { return now }, nil)
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			window, ok := resolver.Resolve(tt.text)
+			if !ok {
+				t.Fatal("Expected a time window")
+			}
+			if !window.StartDate.Equal(tt.start) || !window.EndDate.Equal(tt.end) {
+				t.Errorf("Window = %s to %s, want %s to %s", window.StartDate, window.EndDate, tt.start, tt.end)
+			}
+			if window.Period != tt.period {
+				t.Errorf("Period = %q, want %q", window.Period, tt.period)
+			}
+			if window.Expression == "" {
+				t.Error("Expected the expression")
+			}
+		})
+	}
+}
+
+func TestTimeResolverIgnoresOtherWords(t *testing.T) {
+	resolver := NewTimeResolver(func() time.Time { return time.Date(2025, time.August, 15, 0, 0, 0, 0, time.UTC) }, nil)
+
+	for _, text := range []string{
+		"How may Boeing respond to the strike?",
+		"Will the Marines march on with the ACV?",
+		"Who builds the B-21?",
+		"Was the 2024 million dollar order cancelled?",
+		"Is there a causal link between the F-35 delays and Lockheed's margins?",
+	} {
+		if window, ok := resolver.Resolve(text); ok {
+			t.Errorf("Resolve(%q) = %+v, want no window", text, window)
+		}
+	}
+}
@@ -12,9 +12,12 @@ type Company struct {
 	Name           string             `bson:"name" json:"name" validate:"required,min=1,max=200"`
 	Country        string             `bson:"country" json:"country" validate:"required,min=2,max=100"`
 	Ticker         string             `bson:"ticker" json:"ticker" validate:"omitempty,min=1,max=10"`
+	Aliases        []string           `bson:"aliases,omitempty" json:"aliases,omitempty"`
+	FormerNames    []string           `bson:"formerNames,omitempty" json:"formerNames,omitempty"`
 	StockExchange  string             `bson:"stockExchange" json:"stockExchange" validate:"omitempty,min=1,max=50"`
 	Industry       Industry           `bson:"industry" json:"industry" validate:"required"`
-	FeedURL        string             `bson:"feedUrl" json:"feedUrl" validate:"required,url"`
+	FeedURL        string             `bson:"feedUrl" json:"feedUrl" validate:"omitempty,url"`
+	Feeds          []Feed             `bson:"feeds,omitempty" json:"feeds"`
 	CompanyWebsite string             `bson:"companyWebsite" json:"companyWebsite" validate:"required,url"`
 	KeyPeople      []KeyPerson        `bson:"keyPeople" json:"keyPeople" validate:"required,min=1"`
 	Founded        time.Time          `bson:"founded" json:"founded" validate:"required"`
@@ -47,6 +50,109 @@ type KeyPerson struct {
 	Position string `bson:"position" json:"position" validate:"required,min=2,max=100"`
 }
 
+// Feed represents a single news feed belonging to a company
+type Feed struct {
+	URL                 string  `bson:"url" json:"url" validate:"required,url"`
+	Label               string  `bson:"label" json:"label" validate:"required,min=1,max=100"`
+	PollIntervalMinutes int     `bson:"pollIntervalMinutes" json:"pollIntervalMinutes" validate:"omitempty,min=1"`
+	Enabled             bool    `bson:"enabled" json:"enabled"`
+	TrustWeight         float64 `bson:"trustWeight" json:"trustWeight" validate:"omitempty,min=0,max=1"`
+}
+
+const (
+	// DefaultFeedLabel is used for feeds migrated from the legacy single feedUrl field
+	DefaultFeedLabel = "primary"
+
+	// DefaultFeedTrustWeight is applied when a feed does not specify a trust weight
+	DefaultFeedTrustWeight = 1.0
+)
+
+// FeedRequest is a feed given when creating a company. Unset optional fields
+// take their defaults: the feed is enabled and fully trusted.
+type FeedRequest struct {
+	URL                 string   `json:"url" validate:"required,url"`
+	Label               string   `json:"label" validate:"required,min=1,max=100"`
+	PollIntervalMinutes int      `json:"pollIntervalMinutes" validate:"omitempty,min=1"`
+	Enabled             *bool    `json:"enabled,omitempty"`
+	TrustWeight         *float64 `json:"trustWeight,omitempty" validate:"omitempty,gt=0,max=1"`
+}
+
+// PollInterval returns the configured poll interval, or zero when the feed uses the default
+func (f Feed) PollInterval() time.Duration {
+	return time.Duration(f.PollIntervalMinutes) * time.Minute
+}
+
+// EffectiveFeeds returns the company's configured feeds. Documents created
+// before multiple feeds were supported only carry feedUrl; that URL is
+// returned as a single enabled feed so they keep working until migrated.
+func (c *Company) EffectiveFeeds() []Feed {
+	if len(c.Feeds) == 0 {
+		if c.FeedURL == "" {
+			return nil
+		}
+		return []Feed{legacyFeed(c.FeedURL)}
+	}
+	return c.Feeds
+}
+
+// EnabledFeeds returns the feeds that should be polled
+func (c *Company) EnabledFeeds() []Feed {
+	return enabledFeeds(c.EffectiveFeeds())
+}
+
+// MigrateLegacyFeed moves the legacy feedUrl into Feeds.
+// It reports whether the company was changed.
+func (c *Company) MigrateLegacyFeed() bool {
+	if len(c.Feeds) > 0 || c.FeedURL == "" {
+		return false
+	}
+	c.Feeds = []Feed{legacyFeed(c.FeedURL)}
+	return true
+}
+
+// legacyFeed builds the feed used for a legacy single feedUrl
+func legacyFeed(url string) Feed {
+	return Feed{
+		URL:         url,
+		Label:       DefaultFeedLabel,
+		Enabled:     true,
+		TrustWeight: DefaultFeedTrustWeight,
+	}
+}
+
+// enabledFeeds filters out disabled feeds
+func enabledFeeds(feeds []Feed) []Feed {
+	enabled := make([]Feed, 0, len(feeds))
+	for _, feed := range feeds {
+		if feed.Enabled {
+			enabled = append(enabled, feed)
+		}
+	}
+	return enabled
+}
+
+// normalizeFeeds converts requested feeds, filling in defaults for unset
+// optional fields
+func normalizeFeeds(requests []FeedRequest) []Feed {
+	feeds := make([]Feed, len(requests))
+	for i, req := range requests {
+		feeds[i] = Feed{
+			URL:                 req.URL,
+			Label:               req.Label,
+			PollIntervalMinutes: req.PollIntervalMinutes,
+			Enabled:             true,
+			TrustWeight:         DefaultFeedTrustWeight,
+		}
+		if req.Enabled != nil {
+			feeds[i].Enabled = *req.Enabled
+		}
+		if req.TrustWeight != nil {
+			feeds[i].TrustWeight = *req.TrustWeight
+		}
+	}
+	return feeds
+}
+
 type CompanyMetadata struct {
 	LastFeedUpdate time.Time `bson:"lastFeedUpdate" json:"lastFeedUpdate"`
 	IsActive       bool      `bson:"isActive" json:"isActive"`
@@ -55,28 +161,44 @@ type CompanyMetadata struct {
 
 // CreateCompanyRequest represents the request payload for creating a company
 type CreateCompanyRequest struct {
-	Name           string      `json:"name" validate:"required,min=1,max=200"`
-	Country        string      `json:"country" validate:"required,min=2,max=100"`
-	Ticker         string      `json:"ticker" validate:"omitempty,min=1,max=10"`
-	StockExchange  string      `json:"stockExchange" validate:"omitempty,min=1,max=50"`
-	Industry       Industry    `json:"industry" validate:"required"`
-	FeedURL        string      `json:"feedUrl" validate:"required,url"`
-	CompanyWebsite string      `json:"companyWebsite" validate:"required,url"`
-	KeyPeople      []KeyPerson `json:"keyPeople" validate:"required,min=1"`
-	Founded        time.Time   `json:"founded" validate:"required"`
-	NumEmployees   int         `json:"numEmployees" validate:"required,min=1"`
+	Name           string        `json:"name" validate:"required,min=1,max=200"`
+	Country        string        `json:"country" validate:"required,min=2,max=100"`
+	Ticker         string        `json:"ticker" validate:"omitempty,min=1,max=10"`
+	Aliases        []string      `json:"aliases" validate:"omitempty,dive,min=1,max=200"`
+	FormerNames    []string      `json:"formerNames" validate:"omitempty,dive,min=1,max=200"`
+	StockExchange  string        `json:"stockExchange" validate:"omitempty,min=1,max=50"`
+	Industry       Industry      `json:"industry" validate:"required"`
+	FeedURL        string        `json:"feedUrl" validate:"omitempty,url"`
+	Feeds          []FeedRequest `json:"feeds" validate:"omitempty,dive"`
+	CompanyWebsite string        `json:"companyWebsite" validate:"required,url"`
+	KeyPeople      []KeyPerson   `json:"keyPeople" validate:"required,min=1"`
+	Founded        time.Time     `json:"founded" validate:"required"`
+	NumEmployees   int           `json:"numEmployees" validate:"required,min=1"`
 }
 
 // ToCompany converts a CreateCompanyRequest to a Company domain object
 func (req *CreateCompanyRequest) ToCompany() *Company {
 	now := time.Now()
+
+	feeds := normalizeFeeds(req.Feeds)
+	feedURL := req.FeedURL
+	if len(feeds) == 0 && feedURL != "" {
+		feeds = []Feed{legacyFeed(feedURL)}
+	}
+	if feedURL == "" && len(feeds) > 0 {
+		feedURL = feeds[0].URL
+	}
+
 	return &Company{
 		Name:           req.Name,
 		Country:        req.Country,
 		Ticker:         req.Ticker,
+		Aliases:        req.Aliases,
+		FormerNames:    req.FormerNames,
 		StockExchange:  req.StockExchange,
 		Industry:       req.Industry,
-		FeedURL:        req.FeedURL,
+		FeedURL:        feedURL,
+		Feeds:          feeds,
 		CompanyWebsite: req.CompanyWebsite,
 		KeyPeople:      req.KeyPeople,
 		Founded:        req.Founded,
@@ -96,9 +218,12 @@ type CompanyResponse struct {
 	Name           string      `json:"name"`
 	Country        string      `json:"country"`
 	Ticker         string      `json:"ticker"`
+	Aliases        []string    `json:"aliases,omitempty"`
+	FormerNames    []string    `json:"formerNames,omitempty"`
 	StockExchange  string      `json:"stockExchange"`
 	Industry       Industry    `json:"industry"`
 	FeedURL        string      `json:"feedUrl"`
+	Feeds          []Feed      `json:"feeds"`
 	CompanyWebsite string      `json:"companyWebsite"`
 	KeyPeople      []KeyPerson `json:"keyPeople"`
 	Founded        time.Time   `json:"founded"`
@@ -106,6 +231,11 @@ type CompanyResponse struct {
 	Metadata       CompanyMetadata `json:"metadata"`
 }
 
+// EnabledFeeds returns the feeds that should be polled
+func (r *CompanyResponse) EnabledFeeds() []Feed {
+	return enabledFeeds(r.Feeds)
+}
+
 // ToResponse converts a Company to a CompanyResponse
 func (c *Company) ToResponse() *CompanyResponse {
 	return &CompanyResponse{
@@ -113,9 +243,12 @@ func (c *Company) ToResponse() *CompanyResponse {
 		Name:           c.Name,
 		Country:        c.Country,
 		Ticker:         c.Ticker,
+		Aliases:        c.Aliases,
+		FormerNames:    c.FormerNames,
 		StockExchange:  c.StockExchange,
 		Industry:       c.Industry,
 		FeedURL:        c.FeedURL,
+		Feeds:          c.EffectiveFeeds(),
 		CompanyWebsite: c.CompanyWebsite,
 		KeyPeople:      c.KeyPeople,
 		Founded:        c.Founded,
@@ -110,4 +110,106 @@ func TestCompanyToResponse(t *testing.T) {
 	if len(response.KeyPeople) != len(company.KeyPeople) {
 		t.Errorf("Expected %d key people, got %d", len(company.KeyPeople), len(response.KeyPeople))
 	}
-}
\ No newline at end of file
+}
+func TestEffectiveFeeds(t *testing.T) {
+	tests := []struct {
+		name        string
+		company     *Company
+		wantFeeds   int
+		wantEnabled int
+	}{
+		{
+			name:        "Legacy feed URL only",
+			company:     &Company{FeedURL: "https://example.com/feed"},
+			wantFeeds:   1,
+			wantEnabled: 1,
+		},
+		{
+			name: "Multiple feeds with one disabled",
+			company: &Company{
+				FeedURL: "https://example.com/feed",
+				Feeds: []Feed{
+					{URL: "https://example.com/press", Label: "Press", Enabled: true},
+					{URL: "https://example.com/ir", Label: "Investors", Enabled: false},
+				},
+			},
+			wantFeeds:   2,
+			wantEnabled: 1,
+		},
+		{
+			name:        "No feeds",
+			company:     &Company{},
+			wantFeeds:   0,
+			wantEnabled: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(tt.company.EffectiveFeeds()); got != tt.wantFeeds {
+				t.Errorf("EffectiveFeeds() returned %d feeds, want %d", got, tt.wantFeeds)
+			}
+			if got := len(tt.company.EnabledFeeds()); got != tt.wantEnabled {
+				t.Errorf("EnabledFeeds() returned %d feeds, want %d", got, tt.wantEnabled)
+			}
+		})
+	}
+}
+
+func TestMigrateLegacyFeed(t *testing.T) {
+	company := &Company{FeedURL: "https://example.com/feed"}
+
+	if !company.MigrateLegacyFeed() {
+		t.Fatal("Expected legacy company to be migrated")
+	}
+	if len(company.Feeds) != 1 {
+		t.Fatalf("Expected 1 feed, got %d", len(company.Feeds))
+	}
+	if company.Feeds[0].Label != DefaultFeedLabel || !company.Feeds[0].Enabled {
+		t.Errorf("Unexpected migrated feed: %+v", company.Feeds[0])
+	}
+	if company.MigrateLegacyFeed() {
+		t.Error("Expected second migration to be a no-op")
+	}
+}
+
+func TestCreateCompanyRequestFeedDefaults(t *testing.T) {
+	disabled := false
+	weight := 0.5
+	req := &CreateCompanyRequest{
+		Name: "Test Company",
+		Feeds: []FeedRequest{
+			{URL: "https://example.com/press", Label: "Press"},
+			{URL: "https://example.com/blog", Label: "Blog", Enabled: &disabled, TrustWeight: &weight},
+		},
+	}
+
+	company := req.ToCompany()
+
+	if company.FeedURL != "https://example.com/press" {
+		t.Errorf("Expected legacy feedUrl to mirror first feed, got %s", company.FeedURL)
+	}
+	if !company.Feeds[0].Enabled || company.Feeds[0].TrustWeight != DefaultFeedTrustWeight {
+		t.Errorf("Expected an unset feed enabled with the default trust weight, got %+v", company.Feeds[0])
+	}
+	if company.Feeds[1].Enabled || company.Feeds[1].TrustWeight != 0.5 {
+		t.Errorf("Expected the given settings kept, got %+v", company.Feeds[1])
+	}
+	if len(company.EnabledFeeds()) != 1 {
+		t.Errorf("Expected 1 enabled feed, got %d", len(company.EnabledFeeds()))
+	}
+}
+
+func TestValidateFeedsTrustWeight(t *testing.T) {
+	for _, weight := range []float64{0, -0.5, 1.5} {
+		feeds := []FeedRequest{{URL: "https://example.com/press", Label: "Press", TrustWeight: &weight}}
+		if err := validateFeeds(feeds); err == nil {
+			t.Errorf("Expected trust weight %v rejected", weight)
+		}
+	}
+
+	weight := 0.1
+	if err := validateFeeds([]FeedRequest{{URL: "https://example.com/press", Label: "Press", TrustWeight: &weight}}); err != nil {
+		t.Errorf("Expected trust weight 0.1 accepted, got %v", err)
+	}
+}
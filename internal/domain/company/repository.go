@@ -3,6 +3,7 @@ package company
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -22,6 +23,7 @@ type Repository interface {
 	Update(ctx context.Context, company *Company) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	List(ctx context.Context, limit, offset int) ([]*Company, error)
+	UpdateLastFeedUpdate(ctx context.Context, id primitive.ObjectID, updatedAt time.Time) error
 }
 
 // Service defines the business logic interface for company operations
@@ -30,4 +32,12 @@ type Service interface {
 	GetCompanyByName(ctx context.Context, name string) (*CompanyResponse, error)
 	GetCompanyByTicker(ctx context.Context, ticker string) (*CompanyResponse, error)
 	ListCompanies(ctx context.Context, limit, offset int) ([]*CompanyResponse, error)
-}
\ No newline at end of file
+	RecordFeedUpdate(ctx context.Context, name string, updatedAt time.Time) error
+	MigrateLegacyFeeds(ctx context.Context) (int, error)
+}
+
+// Resolver maps free text to known companies
+type Resolver interface {
+	Resolve(ctx context.Context, name string) (*Resolution, error)
+	ResolveMentions(ctx context.Context, text string) ([]*Resolution, error)
+}
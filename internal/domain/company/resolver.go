@@ -0,0 +1,352 @@
+package company
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Neph-dev/october_backend/pkg/logger"
+)
+
+// MatchType describes how free text was matched to a company
+type MatchType string
+
+const (
+	MatchExact           MatchType = "exact"
+	MatchCaseInsensitive MatchType = "case_insensitive"
+	MatchAlias           MatchType = "alias"
+	MatchFuzzy           MatchType = "fuzzy"
+)
+
+// Scores of each match type; fuzzy matches scale FuzzyMatchScore by similarity
+const (
+	ExactMatchScore           = 1.0
+	CaseInsensitiveMatchScore = 0.95
+	AliasMatchScore           = 0.9
+	FuzzyMatchScore           = 0.85
+)
+
+const (
+	// MinFuzzySimilarity is the lowest normalised edit-distance similarity
+	// accepted as a fuzzy match
+	MinFuzzySimilarity = 0.8
+	// minFuzzyLength avoids fuzzy matching short words to tickers and acronyms
+	minFuzzyLength = 5
+	// maxResolveRunes bounds the text compared with company names
+	maxResolveRunes = 200
+	// maxMentionWords bounds the length of a company name searched for in free text
+	maxMentionWords = 6
+	// resolverCacheTTL is how long the company list is reused between lookups
+	resolverCacheTTL = 5 * time.Minute
+	// resolverPageSize and resolverMaxPages bound loading the company list
+	resolverPageSize = 100
+	resolverMaxPages = 100
+)
+
+// corporateSuffixes are dropped before fuzzy comparison
+var corporateSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "ltd": true, "limited": true, "plc": true,
+	"llc": true, "ag": true, "sa": true, "nv": true, "the": true,
+}
+
+// Resolution is a company matched from free text
+type Resolution struct {
+	Company     *CompanyResponse `json:"company"`
+	MatchType   MatchType        `json:"matchType"`
+	MatchedTerm string           `json:"matchedTerm"` // Name, alias, former name or ticker that matched
+	Score       float64          `json:"score"`
+}
+
+// ResolverService maps free text to companies by exact, case-insensitive,
+// alias and fuzzy matching. The company list is cached for a few minutes.
+type ResolverService struct {
+	repo   Repository
+	logger logger.Logger
+
+	mu        sync.Mutex
+	companies []*Company
+	loadedAt  time.Time
+}
+
+// NewResolverService creates a new company resolver
+func NewResolverService(repo Repository, logger logger.Logger) Resolver {
+	return &ResolverService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Resolve returns the company best matching the name, or ErrCompanyNotFound
+func (s *ResolverService) Resolve(ctx context.Context, name string) (*Resolution, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: company name cannot be empty", ErrInvalidCompanyData)
+	}
+
+	companies, err := s.loadCompanies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution := ResolveName(companies, name)
+	if resolution == nil {
+		s.logger.Info("Company could not be resolved", "name", name)
+		return nil, ErrCompanyNotFound
+	}
+
+	s.logger.Debug("Resolved company", "name", name, "company", resolution.Company.Name,
+		"match", resolution.MatchType, "score", resolution.Score)
+	return resolution, nil
+}
+
+// ResolveMentions returns every company mentioned in the text, in order of appearance
+func (s *ResolverService) ResolveMentions(ctx context.Context, text string) ([]*Resolution, error) {
+	companies, err := s.loadCompanies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return FindMentions(companies, text), nil
+}
+
+// loadCompanies returns the cached company list, reloading it when stale
+func (s *ResolverService) loadCompanies(ctx context.Context) ([]*Company, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.companies != nil && time.Since(s.loadedAt) < resolverCacheTTL {
+		return s.companies, nil
+	}
+
+	var companies []*Company
+	for page := 0; page < resolverMaxPages; page++ {
+		batch, err := s.repo.List(ctx, resolverPageSize, page*resolverPageSize)
+		if err != nil {
+			s.logger.Error("Failed to load companies for resolution", "error", err)
+			return nil, fmt.Errorf("failed to load companies: %w", err)
+		}
+		companies = append(companies, batch...)
+		if len(batch) < resolverPageSize {
+			break
+		}
+	}
+
+	s.companies = companies
+	s.loadedAt = time.Now()
+	return companies, nil
+}
+
+// ResolveName matches a company name against the companies, preferring an
+// exact name, then a case-insensitive name, then an alias, former name or
+// ticker, then the closest fuzzy match. It returns nil when nothing matches.
+func ResolveName(companies []*Company, name string) *Resolution {
+	name = truncateRunes(strings.TrimSpace(name), maxResolveRunes)
+
+	if resolution := resolveExact(companies, name); resolution != nil {
+		return resolution
+	}
+	return fuzzyMatch(companies, normalizeName(name))
+}
+
+// resolveExact matches a company name by exact name, case-insensitive name,
+// then alias, former name or ticker. It returns nil when nothing matches.
+func resolveExact(companies []*Company, name string) *Resolution {
+	for _, c := range companies {
+		if c.Name == name {
+			return &Resolution{Company: c.ToResponse(), MatchType: MatchExact, MatchedTerm: c.Name, Score: ExactMatchScore}
+		}
+	}
+	for _, c := range companies {
+		if strings.EqualFold(c.Name, name) {
+			return &Resolution{Company: c.ToResponse(), MatchType: MatchCaseInsensitive, MatchedTerm: c.Name, Score: CaseInsensitiveMatchScore}
+		}
+	}
+	for _, c := range companies {
+		for _, term := range c.alternateNames() {
+			if strings.EqualFold(term, name) {
+				return &Resolution{Company: c.ToResponse(), MatchType: MatchAlias, MatchedTerm: term, Score: AliasMatchScore}
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindMentions scans free text for company names, aliases, former names and
+// tickers, trying the longest phrases first. Exact and alias matches are
+// found first; the remaining proper-noun phrases are then fuzzy matched so
+// that misspelled names still resolve without ordinary words such as
+// "boring" resolving to "Boeing".
+func FindMentions(companies []*Company, text string) []*Resolution {
+	words := strings.FieldsFunc(truncateRunes(text, maxResolveRunes*5), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '&'
+	})
+
+	found := make([]*Resolution, len(words))
+	covered := make([]bool, len(words))
+	scanMentions(words, found, covered, func(phrase []string) *Resolution {
+		return resolveExact(companies, strings.Join(phrase, " "))
+	})
+	scanMentions(words, found, covered, func(phrase []string) *Resolution {
+		if !isProperNounPhrase(phrase) {
+			return nil
+		}
+		return fuzzyMatch(companies, normalizeName(strings.Join(phrase, " ")))
+	})
+
+	var mentions []*Resolution
+	seen := make(map[string]bool)
+	for _, resolution := range found {
+		if resolution == nil || seen[resolution.Company.ID] {
+			continue
+		}
+		seen[resolution.Company.ID] = true
+		mentions = append(mentions, resolution)
+	}
+	return mentions
+}
+
+// scanMentions resolves the longest phrases of words not yet covered by a
+// mention, recording each resolution at the phrase's first word
+func scanMentions(words []string, found []*Resolution, covered []bool, resolve func(phrase []string) *Resolution) {
+	for i := 0; i < len(words); {
+		matched := 0
+		for n := min(maxMentionWords, len(words)-i); n > 0 && matched == 0; n-- {
+			if slices.Contains(covered[i:i+n], true) {
+				continue
+			}
+			resolution := resolve(words[i : i+n])
+			if resolution == nil {
+				continue
+			}
+			matched = n
+			found[i] = resolution
+			for j := i; j < i+n; j++ {
+				covered[j] = true
+			}
+		}
+		i += max(matched, 1)
+	}
+}
+
+// nameConnectors are lower-case words allowed inside a proper-noun phrase
+var nameConnectors = map[string]bool{"of": true, "and": true, "&": true, "the": true, "for": true}
+
+// isProperNounPhrase reports whether the phrase looks like a name: it starts
+// and ends with a capitalised word, and its other words are capitalised or
+// connectors such as "of"
+func isProperNounPhrase(phrase []string) bool {
+	for i, word := range phrase {
+		first, _ := utf8.DecodeRuneInString(word)
+		if unicode.IsUpper(first) {
+			continue
+		}
+		if i == 0 || i == len(phrase)-1 || !nameConnectors[word] {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyMatch returns the company whose name or alternate name is closest to
+// the normalised name, when similar enough
+func fuzzyMatch(companies []*Company, normalized string) *Resolution {
+	if len([]rune(normalized)) < minFuzzyLength {
+		return nil
+	}
+
+	var best *Resolution
+	for _, c := range companies {
+		for _, term := range append([]string{c.Name}, c.alternateNames()...) {
+			candidate := normalizeName(term)
+			if len([]rune(candidate)) < minFuzzyLength {
+				continue
+			}
+			similarity := Similarity(normalized, candidate)
+			if similarity < MinFuzzySimilarity {
+				continue
+			}
+			score := FuzzyMatchScore * similarity
+			if best == nil || score > best.Score {
+				best = &Resolution{Company: c.ToResponse(), MatchType: MatchFuzzy, MatchedTerm: term, Score: score}
+			}
+		}
+	}
+
+	return best
+}
+
+// alternateNames returns the aliases, former names and ticker of the company
+func (c *Company) alternateNames() []string {
+	names := make([]string, 0, len(c.Aliases)+len(c.FormerNames)+1)
+	names = append(names, c.Aliases...)
+	names = append(names, c.FormerNames...)
+	if c.Ticker != "" {
+		names = append(names, c.Ticker)
+	}
+	return names
+}
+
+// normalizeName lower-cases a name, drops punctuation and corporate suffixes
+// and collapses whitespace
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '&'
+	})
+
+	kept := words[:0]
+	for _, word := range words {
+		if !corporateSuffixes[word] {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// Similarity returns 1 minus the edit distance of a and b divided by the
+// length of the longer string
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein computes the edit distance between two rune slices using two rows
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// truncateRunes limits text to maxRunes runes
+func truncateRunes(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	return string(runes[:maxRunes])
+}
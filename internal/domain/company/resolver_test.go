@@ -0,0 +1,113 @@
+package company
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testResolverCompanies() []*Company {
+	return []*Company{
+		{
+			ID:          primitive.NewObjectID(),
+			Name:        "Raytheon Technologies",
+			Ticker:      "RTX",
+			Aliases:     []string{"RTX", "Raytheon", "Collins Aerospace"},
+			FormerNames: []string{"United Technologies"},
+		},
+		{
+			ID:          primitive.NewObjectID(),
+			Name:        "US War Department",
+			Aliases:     []string{"Department of War", "Pentagon"},
+			FormerNames: []string{"Department of Defense"},
+		},
+		{
+			ID:     primitive.NewObjectID(),
+			Name:   "Lockheed Martin",
+			Ticker: "LMT",
+		},
+	}
+}
+
+func TestResolveName(t *testing.T) {
+	companies := testResolverCompanies()
+
+	tests := []struct {
+		input     string
+		company   string
+		matchType MatchType
+	}{
+		{"Raytheon Technologies", "Raytheon Technologies", MatchExact},
+		{"raytheon technologies", "Raytheon Technologies", MatchCaseInsensitive},
+		{"collins aerospace", "Raytheon Technologies", MatchAlias},
+		{"United Technologies", "Raytheon Technologies", MatchAlias},
+		{"lmt", "Lockheed Martin", MatchAlias},
+		{"Lockheed Martin Corporation", "Lockheed Martin", MatchFuzzy},
+		{"Lockhead Martin", "Lockheed Martin", MatchFuzzy},
+		{"Departmnt of Defense", "US War Department", MatchFuzzy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			resolution := ResolveName(companies, tt.input)
+			if resolution == nil {
+				t.Fatalf("Expected %q to resolve", tt.input)
+			}
+			if resolution.Company.Name != tt.company || resolution.MatchType != tt.matchType {
+				t.Errorf("Expected %s (%s), got %s (%s)", tt.company, tt.matchType, resolution.Company.Name, resolution.MatchType)
+			}
+			if resolution.Score <= 0 || resolution.Score > 1 {
+				t.Errorf("Expected score in (0, 1], got %f", resolution.Score)
+			}
+		})
+	}
+
+	for _, input := range []string{"Boeing", "Northrop Grumman", "RT"} {
+		if resolution := ResolveName(companies, input); resolution != nil {
+			t.Errorf("Expected %q not to resolve, got %s", input, resolution.Company.Name)
+		}
+	}
+}
+
+func TestFindMentions(t *testing.T) {
+	companies := testResolverCompanies()
+
+	mentions := FindMentions(companies, "How did RTX and the Pentagon's contracts with Lockheed Martn change this quarter?")
+
+	expected := []string{"Raytheon Technologies", "US War Department", "Lockheed Martin"}
+	if len(mentions) != len(expected) {
+		t.Fatalf("Expected %d mentions, got %d", len(expected), len(mentions))
+	}
+	for i, name := range expected {
+		if mentions[i].Company.Name != name {
+			t.Errorf("Mention %d: expected %s, got %s", i, name, mentions[i].Company.Name)
+		}
+	}
+
+	if len(FindMentions(companies, "What is the latest defense news?")) != 0 {
+		t.Error("Expected no mentions in a question without company names")
+	}
+}
+
+func TestFindMentionsFuzzyOnlyForNames(t *testing.T) {
+	companies := append(testResolverCompanies(), &Company{ID: primitive.NewObjectID(), Name: "Boeing", Ticker: "BA"})
+
+	mentions := FindMentions(companies, "Was it a boring quarter for Lockheed Martin?")
+	if len(mentions) != 1 || mentions[0].Company.Name != "Lockheed Martin" || mentions[0].MatchType != MatchExact {
+		t.Errorf("Expected only an exact Lockheed Martin mention, got %+v", mentions)
+	}
+
+	mentions = FindMentions(companies, "Did Boing win the tanker contract?")
+	if len(mentions) != 1 || mentions[0].Company.Name != "Boeing" || mentions[0].MatchType != MatchFuzzy {
+		t.Errorf("Expected a misspelled capitalised name fuzzy matched, got %+v", mentions)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := Similarity("lockheed", "lockheed"); got != 1 {
+		t.Errorf("Expected identical strings to have similarity 1, got %f", got)
+	}
+	if got := Similarity("kitten", "sitting"); got < 0.57 || got > 0.58 {
+		t.Errorf("Expected similarity of 3 edits over 7 runes, got %f", got)
+	}
+}
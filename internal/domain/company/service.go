@@ -122,6 +122,67 @@ func (s *CompanyService) ListCompanies(ctx context.Context, limit, offset int) (
 	return responses, nil
 }
 
+// RecordFeedUpdate stores the time the company's feed was last fetched successfully
+func (s *CompanyService) RecordFeedUpdate(ctx context.Context, name string, updatedAt time.Time) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: company name cannot be empty", ErrInvalidCompanyData)
+	}
+
+	company, err := s.repo.GetByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		s.logger.Error("Failed to get company for feed update", "error", err, "name", name)
+		return err
+	}
+
+	if err := s.repo.UpdateLastFeedUpdate(ctx, company.ID, updatedAt); err != nil {
+		s.logger.Error("Failed to record feed update", "error", err, "name", name)
+		return fmt.Errorf("failed to record feed update: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateLegacyFeeds converts companies that only have the legacy feedUrl
+// into the multi-feed layout. It returns the number of companies migrated.
+func (s *CompanyService) MigrateLegacyFeeds(ctx context.Context) (int, error) {
+	const (
+		pageSize = 100
+		// maxPages bounds the scan at 100,000 companies. Companies beyond
+		// the bound are not migrated; a warning is logged when it is reached.
+		maxPages = 1000
+	)
+
+	migrated := 0
+	for page := 0; page < maxPages; page++ {
+		offset := page * pageSize
+		companies, err := s.repo.List(ctx, pageSize, offset)
+		if err != nil {
+			s.logger.Error("Failed to list companies for feed migration", "error", err, "offset", offset)
+			return migrated, fmt.Errorf("failed to list companies: %w", err)
+		}
+
+		for _, company := range companies {
+			if !company.MigrateLegacyFeed() {
+				continue
+			}
+			if err := s.repo.Update(ctx, company); err != nil {
+				s.logger.Error("Failed to migrate company feeds", "error", err, "name", company.Name)
+				return migrated, fmt.Errorf("failed to migrate company %s: %w", company.Name, err)
+			}
+			migrated++
+			s.logger.Info("Migrated legacy feed URL", "name", company.Name, "url", company.FeedURL)
+		}
+
+		if len(companies) < pageSize {
+			return migrated, nil
+		}
+	}
+
+	s.logger.Warn("Stopped feed migration at the page limit; later companies were not migrated",
+		"migrated", migrated, "scanned", maxPages*pageSize)
+	return migrated, nil
+}
+
 // validateCreateRequest validates a company creation request
 func (s *CompanyService) validateCreateRequest(req *CreateCompanyRequest) error {
 	if req == nil {
@@ -147,8 +208,11 @@ func (s *CompanyService) validateCreateRequest(req *CreateCompanyRequest) error
 	if !req.Industry.IsValid() {
 		return fmt.Errorf("invalid industry: must be %s, %s, or %s", IndustryDefense, IndustryAerospace, IndustryGovernment)
 	}
-	if strings.TrimSpace(req.FeedURL) == "" {
-		return fmt.Errorf("feed URL is required")
+	if strings.TrimSpace(req.FeedURL) == "" && len(req.Feeds) == 0 {
+		return fmt.Errorf("feed URL or at least one feed is required")
+	}
+	if err := validateFeeds(req.Feeds); err != nil {
+		return err
 	}
 	if strings.TrimSpace(req.CompanyWebsite) == "" {
 		return fmt.Errorf("company website is required")
@@ -177,4 +241,32 @@ func (s *CompanyService) validateCreateRequest(req *CreateCompanyRequest) error
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// validateFeeds validates the per-feed configuration of a company
+func validateFeeds(feeds []FeedRequest) error {
+	seen := make(map[string]bool, len(feeds))
+	for i, feed := range feeds {
+		url := strings.TrimSpace(feed.URL)
+		if url == "" {
+			return fmt.Errorf("feed %d: URL is required", i+1)
+		}
+		if seen[url] {
+			return fmt.Errorf("feed %d: duplicate URL %s", i+1, url)
+		}
+		seen[url] = true
+
+		if strings.TrimSpace(feed.Label) == "" {
+			return fmt.Errorf("feed %d: label is required", i+1)
+		}
+		if feed.PollIntervalMinutes < 0 {
+			return fmt.Errorf("feed %d: poll interval cannot be negative", i+1)
+		}
+		// Relevance scoring treats a zero weight as unset, so it is rejected
+		// rather than stored
+		if feed.TrustWeight != nil && (*feed.TrustWeight <= 0 || *feed.TrustWeight > 1) {
+			return fmt.Errorf("feed %d: trust weight must be greater than 0 and at most 1", i+1)
+		}
+	}
+	return nil
+}
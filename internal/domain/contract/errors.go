@@ -0,0 +1,8 @@
+package contract
+
+import "errors"
+
+// Domain errors for contract awards
+var (
+	ErrInvalidFilter = errors.New("invalid contract filter parameters")
+)
@@ -0,0 +1,429 @@
+package contract
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+const (
+	// maxExtractRunes bounds the article text scanned for awards
+	maxExtractRunes = 100000
+	// maxBlockSentences bounds the sentences following an award sentence
+	// that are searched for its details
+	maxBlockSentences = 10
+	// maxAwardsPerArticle bounds the awards taken from one article
+	maxAwardsPerArticle = 200
+	// maxDescriptionRunes bounds the stored award description
+	maxDescriptionRunes = 300
+	// maxLocationWords bounds each comma-separated part of a location
+	maxLocationWords = 4
+)
+
+var (
+	awardedRe = regexp.MustCompile(`(?i)\bawarded\b`)
+	// auxiliaryRe matches the auxiliary verb of "was awarded", "has been awarded", ...
+	auxiliaryRe = regexp.MustCompile(`(?i),?\s+(?:has been|have been|was|were|is being|will be|is|are)\s*$`)
+	activeRe    = regexp.MustCompile(`(?i)\s+(?:has|have)\s*$`)
+	// awardeeEndRe ends the awardee of an active sentence ("awarded Boeing a $1 billion contract")
+	awardeeEndRe = regexp.MustCompile(`(?i)\s+(?:an?|the)\s+|\s+\$`)
+	// amountRe matches "$1.2 billion", "$450 mln" or "$12.5M"; one-letter
+	// units must follow the digits directly, so "$250 B-52" is $250
+	amountRe = regexp.MustCompile(`\$\s?(\d[\d,]*(?:\.\d+)?)(?:(\s*(?i:billion|million|thousand|bn|mln|mn)|(?i:b|m|k))\b)?`)
+	// contractNumberRe matches procurement instrument identifiers such as W31P4Q-19-C-0008
+	contractNumberRe = regexp.MustCompile(`\b[A-Z][A-Z0-9]{5}-\d{2}-[A-Z]-[A-Z0-9]{4}\b`)
+	byAgencyRe       = regexp.MustCompile(`\bby\s+(?:the\s+)?([A-Z][\w.&'-]*(?:\s+(?:of\s+(?:the\s+)?)?[A-Z][\w.&'-]*)*)`)
+	descriptionRe    = regexp.MustCompile(`(?i)\b(?:for|to (?:provide|support|develop|produce|deliver|build|procure|manufacture|supply|design|maintain|sustain|upgrade|modernize))\s+.+$`)
+	activityRe       = regexp.MustCompile(`^(.+?),?\s+(?:is|was|are|will be)\s+the\s+contracting\s+activit`)
+	activityIsRe     = regexp.MustCompile(`(?i)\bcontracting\s+activity\s+is\s+(?:the\s+)?([^,;(]+)`)
+	workLocationRe   = regexp.MustCompile(`(?i)\bwork\s+will\s+be\s+performed\s+(?:in|at)\s+(.+?)(?:\s*\(|;|,\s+(?:and|with)\s|,?\s+and\s+is\s+expected|$)`)
+	completionRe     = regexp.MustCompile(`(?i)\b(?:completed\s+by|completion\s+date\s+of|completed\s+in|complete\s+by|through)\s+((?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+(?:\d{1,2},?\s+)?\d{4})`)
+	monthRe          = regexp.MustCompile(`(?i)^(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec|january|february|march|april|june|july|august|september|october|november|december)\.?$`)
+	dateRe           = regexp.MustCompile(`(?i)^([a-z]{3})[a-z]*\.?\s+(?:(\d{1,2}),?\s+)?(\d{4})$`)
+	parentheticalRe  = regexp.MustCompile(`\s*\([^)]*\)`)
+)
+
+// amountMultipliers scale amounts written with a unit
+var amountMultipliers = map[string]float64{
+	"billion": 1e9, "bn": 1e9, "b": 1e9,
+	"million": 1e6, "mln": 1e6, "mn": 1e6, "m": 1e6,
+	"thousand": 1e3, "k": 1e3,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// corporateSuffixes are comma-separated parts that belong to the awardee
+// name rather than its location ("Boeing Co., Inc., St. Louis, Missouri")
+var corporateSuffixes = map[string]bool{
+	"inc": true, "inc.": true, "llc": true, "l.l.c.": true, "corp": true, "corp.": true,
+	"co": true, "co.": true, "ltd": true, "ltd.": true, "l.p.": true, "lp": true,
+	"llp": true, "plc": true, "n.a.": true,
+}
+
+// nameConnectors may appear inside proper names ("Bureau of the Fiscal Service")
+var nameConnectors = map[string]bool{"and": true, "of": true, "the": true, "&": true, "de": true}
+
+// abbreviations end with a period without ending the sentence
+var abbreviations = map[string]bool{
+	"co": true, "corp": true, "inc": true, "ltd": true, "jr": true, "sr": true, "st": true,
+	"ft": true, "mt": true, "no": true, "dr": true, "mr": true, "mrs": true, "ms": true,
+	"gen": true, "col": true, "lt": true, "capt": true, "adm": true, "sgt": true, "rep": true,
+	"sen": true, "gov": true, "dept": true, "vs": true, "jan": true, "feb": true, "mar": true,
+	"apr": true, "jun": true, "jul": true, "aug": true, "sep": true, "sept": true, "oct": true,
+	"nov": true, "dec": true,
+}
+
+// RuleExtractor extracts contract awards with regular expressions tuned to
+// Department of War contract announcements and press releases
+type RuleExtractor struct{}
+
+// NewRuleExtractor creates a rule-based contract award extractor
+func NewRuleExtractor() *RuleExtractor {
+	return &RuleExtractor{}
+}
+
+// Extract implements Extractor. Awards are searched in the article body,
+// then in its title.
+func (e *RuleExtractor) Extract(ctx context.Context, article *news.Article) ([]*ContractAward, error) {
+	awards := ExtractText(article.BodyText())
+	if len(awards) == 0 {
+		awards = ExtractText(article.Title)
+	}
+
+	now := time.Now()
+	for _, award := range awards {
+		award.ArticleID = article.ID
+		award.AnnouncedDate = article.PublishedDate
+		award.SourceURL = article.SourceURL
+		award.Method = ExtractionRules
+		award.ExtractedAt = now
+	}
+	return awards, nil
+}
+
+// ExtractText returns the contract awards announced in the text. Each
+// sentence announcing an award starts a block; the sentences up to the next
+// award sentence supply its contract number, place of performance,
+// completion date and contracting activity.
+func ExtractText(text string) []*ContractAward {
+	if runes := []rune(text); len(runes) > maxExtractRunes {
+		text = string(runes[:maxExtractRunes])
+	}
+
+	var awards []*ContractAward
+	var current *ContractAward
+	blockSentences := 0
+	seen := make(map[string]bool)
+
+	for _, sentence := range splitSentences(text) {
+		if award := parseAwardSentence(sentence); award != nil {
+			current = nil
+			key := strings.ToLower(award.Awardee) + "|" + strconv.FormatFloat(award.Amount, 'f', 0, 64)
+			if seen[key] || len(awards) >= maxAwardsPerArticle {
+				continue
+			}
+			seen[key] = true
+			awards = append(awards, award)
+			current = award
+			blockSentences = 0
+			continue
+		}
+
+		if current == nil || blockSentences >= maxBlockSentences {
+			continue
+		}
+		blockSentences++
+		applyDetails(current, sentence)
+	}
+
+	return awards
+}
+
+// parseAwardSentence parses a sentence announcing an award, in the passive
+// ("Lockheed Martin Corp., Orlando, Florida, was awarded a $1,000,000
+// contract"), headline ("Boeing Awarded $2B Contract") or active ("The Army
+// awarded RTX a $1 billion contract") form. It returns nil when the sentence
+// names no awardee or amount.
+func parseAwardSentence(sentence string) *ContractAward {
+	loc := awardedRe.FindStringIndex(sentence)
+	if loc == nil {
+		return nil
+	}
+	before := sentence[:loc[0]]
+	after := strings.TrimSpace(sentence[loc[1]:])
+
+	award := &ContractAward{}
+	var awardee, rest string
+
+	switch {
+	case strings.HasPrefix(strings.ToLower(after), "to "):
+		awardee = firstName(after[3:])
+		rest = sentence
+	case auxiliaryRe.MatchString(before) || startsWithAmount(after):
+		awardee = auxiliaryRe.ReplaceAllString(before, "")
+		rest = after
+	default:
+		end := awardeeEndRe.FindStringIndex(after)
+		if end == nil {
+			return nil
+		}
+		award.Agency = properNameSuffix(activeRe.ReplaceAllString(before, ""))
+		awardee = after[:end[0]]
+		rest = after[end[0]:]
+	}
+
+	awardee = parentheticalRe.ReplaceAllString(awardee, "")
+	award.Awardee, award.Location = splitAwardee(awardee)
+	if award.Awardee == "" {
+		return nil
+	}
+
+	amount := amountRe.FindStringSubmatch(rest)
+	if amount == nil {
+		return nil
+	}
+	award.Amount = parseAmount(amount[1], amount[2])
+	if award.Amount <= 0 {
+		return nil
+	}
+
+	if award.Agency == "" {
+		if match := byAgencyRe.FindStringSubmatch(rest); match != nil && !isDate(match[1]) {
+			award.Agency = strings.TrimRight(match[1], ".,")
+		}
+	}
+	if description := descriptionRe.FindString(rest); description != "" {
+		description = strings.TrimPrefix(description, "for ")
+		award.Description = truncateRunes(strings.TrimRight(description, ". "), maxDescriptionRunes)
+	}
+
+	applyDetails(award, sentence)
+	return award
+}
+
+// applyDetails fills the contract number, place of performance, completion
+// date and contracting activity of an award from one of its sentences
+func applyDetails(award *ContractAward, sentence string) {
+	if award.ContractNumber == "" {
+		award.ContractNumber = contractNumberRe.FindString(sentence)
+	}
+
+	if match := workLocationRe.FindStringSubmatch(sentence); match != nil {
+		if location := strings.Trim(match[1], " ,."); location != "" {
+			award.Location = location
+		}
+	}
+
+	if award.CompletionDate == nil {
+		if match := completionRe.FindStringSubmatch(sentence); match != nil {
+			award.CompletionDate = parseDate(match[1])
+		}
+	}
+
+	// The contracting activity overrides an agency named in the award sentence
+	if match := activityRe.FindStringSubmatch(sentence); match != nil {
+		if agency := properNameSuffix(firstName(match[1])); agency != "" {
+			award.Agency = agency
+		}
+	} else if match := activityIsRe.FindStringSubmatch(sentence); match != nil {
+		award.Agency = strings.TrimSpace(match[1])
+	}
+}
+
+// splitAwardee splits "Raytheon Co., Tucson, Arizona" into the awardee name
+// and its location
+func splitAwardee(text string) (string, string) {
+	parts := strings.Split(text, ",")
+	name := strings.TrimSpace(parts[0])
+	i := 1
+	for ; i < len(parts) && corporateSuffixes[strings.ToLower(strings.TrimSpace(parts[i]))]; i++ {
+		name += ", " + strings.TrimSpace(parts[i])
+	}
+
+	var location []string
+	for ; i < len(parts); i++ {
+		part := strings.TrimSpace(parts[i])
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > maxLocationWords || !startsUpper(part) {
+			continue
+		}
+		location = append(location, part)
+	}
+
+	return properNameSuffix(name), strings.Join(location, ", ")
+}
+
+// properNameSuffix keeps the trailing run of capitalised words of the text,
+// dropping sentence openers such as "WASHINGTON —" or "The Pentagon said that"
+// and a leading article
+func properNameSuffix(text string) string {
+	words := strings.Fields(text)
+	start := len(words)
+	for start > 0 {
+		word := words[start-1]
+		if !startsUpper(word) && !nameConnectors[strings.ToLower(word)] {
+			break
+		}
+		start--
+	}
+	for start < len(words)-1 && nameConnectors[strings.ToLower(words[start])] {
+		start++
+	}
+	return strings.TrimRight(strings.Join(words[start:], " "), ",;:")
+}
+
+// firstName returns the text up to its first comma or clause break
+func firstName(text string) string {
+	if i := strings.IndexAny(text, ",;("); i >= 0 {
+		text = text[:i]
+	}
+	for _, stop := range []string{" for ", " by ", " to ", " under "} {
+		if i := strings.Index(text, stop); i >= 0 {
+			text = text[:i]
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+// splitSentences splits text into sentences at line breaks and at sentence
+// punctuation followed by a capital letter, ignoring common abbreviations
+// and initials such as "U.S." and "D.C."
+func splitSentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+
+	flush := func(end int) {
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' {
+			flush(i + 1)
+			continue
+		}
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		if i+2 >= len(runes) || !unicode.IsSpace(runes[i+1]) {
+			continue
+		}
+		next := i + 1
+		for next < len(runes) && runes[next] == ' ' {
+			next++
+		}
+		if next >= len(runes) || !(unicode.IsUpper(runes[next]) || unicode.IsDigit(runes[next]) || runes[next] == '"') {
+			continue
+		}
+		if r == '.' && isAbbreviation(runes[start:i]) {
+			continue
+		}
+		flush(i + 1)
+	}
+	flush(len(runes))
+
+	return sentences
+}
+
+// isAbbreviation reports whether the word before a period is an
+// abbreviation or an initial
+func isAbbreviation(before []rune) bool {
+	end := len(before)
+	begin := end
+	for begin > 0 && !unicode.IsSpace(before[begin-1]) {
+		begin--
+	}
+	word := string(before[begin:end])
+	if word == "" {
+		return false
+	}
+	if len([]rune(word)) == 1 || strings.Contains(word, ".") {
+		return true
+	}
+	return abbreviations[strings.ToLower(strings.TrimLeft(word, "(\""))]
+}
+
+// parseAmount converts an amount and its optional unit into US dollars
+func parseAmount(number, unit string) float64 {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	if multiplier, ok := amountMultipliers[strings.ToLower(strings.TrimSpace(unit))]; ok {
+		value *= multiplier
+	}
+	return value
+}
+
+// parseDate parses "Dec. 31, 2027" or "September 2027"; dates without a day
+// fall on the last day of the month
+func parseDate(text string) *time.Time {
+	match := dateRe.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return nil
+	}
+	month, ok := months[strings.ToLower(match[1])]
+	if !ok {
+		return nil
+	}
+	year, err := strconv.Atoi(match[3])
+	if err != nil {
+		return nil
+	}
+
+	var date time.Time
+	if match[2] == "" {
+		date = time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	} else {
+		day, err := strconv.Atoi(match[2])
+		if err != nil || day < 1 || day > 31 {
+			return nil
+		}
+		date = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	return &date
+}
+
+// isDate reports whether a capitalised run is a month ("by Dec. 31")
+func isDate(text string) bool {
+	words := strings.Fields(text)
+	return len(words) > 0 && monthRe.MatchString(words[0])
+}
+
+// startsWithAmount reports whether the text starts with "a $", "an $" or "$"
+func startsWithAmount(text string) bool {
+	lower := strings.ToLower(text)
+	lower = strings.TrimPrefix(lower, "an ")
+	lower = strings.TrimPrefix(lower, "a ")
+	return strings.HasPrefix(lower, "$")
+}
+
+// startsUpper reports whether the text starts with an upper-case letter or a digit
+func startsUpper(text string) bool {
+	for _, r := range text {
+		return unicode.IsUpper(r) || unicode.IsDigit(r)
+	}
+	return false
+}
+
+// truncateRunes limits text to maxRunes runes
+func truncateRunes(text string, maxRunes int) string {
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	return string(runes[:maxRunes])
+}
@@ -0,0 +1,151 @@
+package contract
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractTextDoDAnnouncement(t *testing.T) {
+	text := `ARMY
+
+Lockheed Martin Corp., Grand Prairie, Texas, was awarded a $4,512,300,000 modification (P00045) to contract W31P4Q-19-C-0008 for Patriot Advanced Capability-3 missile production. Work will be performed in Grand Prairie, Texas, with an estimated completion date of Dec. 31, 2027. Fiscal 2024 procurement funds in the amount of $4,512,300,000 were obligated at the time of the award. Army Contracting Command, Redstone Arsenal, Alabama, is the contracting activity.
+
+NAVY
+
+Raytheon Co., Tucson, Arizona, has been awarded a $215,000,000 firm-fixed-price contract (N00024-24-C-5400) for Standard Missile-6 production. Work will be performed in Tucson, Arizona (60%); and Andover, Massachusetts (40%), and is expected to be completed by September 2026. Naval Sea Systems Command, Washington, D.C., is the contracting activity.`
+
+	awards := ExtractText(text)
+	if len(awards) != 2 {
+		t.Fatalf("Expected 2 awards, got %d", len(awards))
+	}
+
+	lockheed := awards[0]
+	if lockheed.Awardee != "Lockheed Martin Corp." {
+		t.Errorf("Expected awardee Lockheed Martin Corp., got %q", lockheed.Awardee)
+	}
+	if lockheed.Amount != 4512300000 {
+		t.Errorf("Expected amount 4512300000, got %f", lockheed.Amount)
+	}
+	if lockheed.ContractNumber != "W31P4Q-19-C-0008" {
+		t.Errorf("Expected contract number W31P4Q-19-C-0008, got %q", lockheed.ContractNumber)
+	}
+	if lockheed.Agency != "Army Contracting Command" {
+		t.Errorf("Expected agency Army Contracting Command, got %q", lockheed.Agency)
+	}
+	if lockheed.Location != "Grand Prairie, Texas" {
+		t.Errorf("Expected location Grand Prairie, Texas, got %q", lockheed.Location)
+	}
+	if lockheed.CompletionDate == nil || !lockheed.CompletionDate.Equal(time.Date(2027, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected completion date 2027-12-31, got %v", lockheed.CompletionDate)
+	}
+	if lockheed.Description != "Patriot Advanced Capability-3 missile production" {
+		t.Errorf("Unexpected description %q", lockheed.Description)
+	}
+
+	raytheon := awards[1]
+	if raytheon.Awardee != "Raytheon Co." || raytheon.Amount != 215000000 {
+		t.Errorf("Expected Raytheon Co. for $215M, got %q for %f", raytheon.Awardee, raytheon.Amount)
+	}
+	if raytheon.ContractNumber != "N00024-24-C-5400" {
+		t.Errorf("Expected contract number N00024-24-C-5400, got %q", raytheon.ContractNumber)
+	}
+	if raytheon.Agency != "Naval Sea Systems Command" {
+		t.Errorf("Expected agency Naval Sea Systems Command, got %q", raytheon.Agency)
+	}
+	if raytheon.Location != "Tucson, Arizona" {
+		t.Errorf("Expected location Tucson, Arizona, got %q", raytheon.Location)
+	}
+	if raytheon.CompletionDate == nil || !raytheon.CompletionDate.Equal(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected completion date 2026-09-30, got %v", raytheon.CompletionDate)
+	}
+}
+
+func TestExtractTextPressRelease(t *testing.T) {
+	tests := []struct {
+		text    string
+		awardee string
+		agency  string
+		amount  float64
+	}{
+		{
+			text:    "WASHINGTON — The U.S. Army awarded Raytheon a $1.2 billion contract to produce Patriot interceptors.",
+			awardee: "Raytheon",
+			agency:  "U.S. Army",
+			amount:  1.2e9,
+		},
+		{
+			text:    "Boeing has been awarded a $2.3 billion contract by the U.S. Air Force for KC-46 tankers.",
+			awardee: "Boeing",
+			agency:  "U.S. Air Force",
+			amount:  2.3e9,
+		},
+		{
+			text:    "A $450 million contract was awarded to General Dynamics by the Navy.",
+			awardee: "General Dynamics",
+			agency:  "Navy",
+			amount:  450e6,
+		},
+		{
+			text:    "Northrop Grumman Awarded $12.5M Contract for Sentinel Support",
+			awardee: "Northrop Grumman",
+			amount:  12.5e6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.awardee, func(t *testing.T) {
+			awards := ExtractText(tt.text)
+			if len(awards) != 1 {
+				t.Fatalf("Expected 1 award, got %d", len(awards))
+			}
+			award := awards[0]
+			if award.Awardee != tt.awardee || award.Agency != tt.agency || award.Amount != tt.amount {
+				t.Errorf("Expected %q by %q for %f, got %q by %q for %f",
+					tt.awardee, tt.agency, tt.amount, award.Awardee, award.Agency, award.Amount)
+			}
+		})
+	}
+}
+
+func TestExtractTextIgnoresNonAwards(t *testing.T) {
+	texts := []string{
+		"Lockheed Martin reported quarterly sales of $18 billion.",
+		"The company was awarded a contract, the value of which was not disclosed.",
+	}
+
+	for _, text := range texts {
+		if awards := ExtractText(text); len(awards) != 0 {
+			t.Errorf("Expected no awards in %q, got %+v", text, awards[0])
+		}
+	}
+}
+
+func TestAmountUnits(t *testing.T) {
+	tests := []struct {
+		text   string
+		amount float64
+	}{
+		{"$1.2 billion", 1.2e9},
+		{"$450 million", 450e6},
+		{"$45 mln", 45e6},
+		{"$2.3bn", 2.3e9},
+		{"$3.1 bn", 3.1e9},
+		{"$12.5M", 12.5e6},
+		{"$300K", 300e3},
+		{"$250 B-52 engine kit", 250},
+		{"$96 k-band radar", 96},
+		{"$18 mission kits", 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			match := amountRe.FindStringSubmatch(tt.text)
+			if match == nil {
+				t.Fatalf("Expected an amount in %q", tt.text)
+			}
+			if amount := parseAmount(match[1], match[2]); amount != tt.amount {
+				t.Errorf("Expected %f, got %f", tt.amount, amount)
+			}
+		})
+	}
+}
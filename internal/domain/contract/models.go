@@ -0,0 +1,63 @@
+package contract
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExtractionMethod records how a contract award was extracted
+type ExtractionMethod string
+
+const (
+	ExtractionRules ExtractionMethod = "rules"
+	ExtractionLLM   ExtractionMethod = "llm"
+)
+
+// ContractAward is a contract award announced in a news article
+type ContractAward struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ArticleID      primitive.ObjectID `json:"article_id" bson:"article_id"`
+	Awardee        string             `json:"awardee" bson:"awardee"`                               // Awardee as written in the announcement
+	CompanyName    string             `json:"company_name,omitempty" bson:"company_name,omitempty"` // Tracked company the awardee resolves to
+	Amount         float64            `json:"amount" bson:"amount"`                                 // Award value in US dollars
+	Agency         string             `json:"agency,omitempty" bson:"agency,omitempty"`             // Contracting agency or activity
+	ContractNumber string             `json:"contract_number,omitempty" bson:"contract_number,omitempty"`
+	CompletionDate *time.Time         `json:"completion_date,omitempty" bson:"completion_date,omitempty"`
+	Location       string             `json:"location,omitempty" bson:"location,omitempty"` // Place of performance, or the awardee's location
+	Description    string             `json:"description,omitempty" bson:"description,omitempty"`
+	AnnouncedDate  time.Time          `json:"announced_date" bson:"announced_date"`
+	SourceURL      string             `json:"source_url" bson:"source_url"`
+	Method         ExtractionMethod   `json:"method" bson:"method"`
+	ExtractedAt    time.Time          `json:"extracted_at" bson:"extracted_at"`
+}
+
+// Company returns the tracked company of the award, or its awardee when the
+// awardee is not a tracked company
+func (a *ContractAward) Company() string {
+	if a.CompanyName != "" {
+		return a.CompanyName
+	}
+	return a.Awardee
+}
+
+// Filter represents filtering options for contract award queries
+type Filter struct {
+	Company   string     `json:"company,omitempty"`
+	Agency    string     `json:"agency,omitempty"`
+	MinAmount *float64   `json:"min_amount,omitempty"`
+	MaxAmount *float64   `json:"max_amount,omitempty"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Limit     int        `json:"limit,omitempty"`
+	Offset    int        `json:"offset,omitempty"`
+}
+
+// QuarterlyTotal is the total awarded to a company in a calendar quarter
+type QuarterlyTotal struct {
+	Company string  `json:"company" bson:"company"`
+	Year    int     `json:"year" bson:"year"`
+	Quarter int     `json:"quarter" bson:"quarter"`
+	Total   float64 `json:"total" bson:"total"`
+	Count   int     `json:"count" bson:"count"`
+}
@@ -0,0 +1,29 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository defines the interface for contract award data access
+type Repository interface {
+	// ReplaceForArticle replaces the awards extracted from an article
+	ReplaceForArticle(ctx context.Context, articleID primitive.ObjectID, awards []*ContractAward) error
+
+	// List retrieves awards matching the filter, newest first
+	List(ctx context.Context, filter *Filter) ([]*ContractAward, error)
+
+	// Count returns the number of awards matching the filter
+	Count(ctx context.Context, filter *Filter) (int64, error)
+
+	// TotalsByQuarter sums the awards matching the filter per company and
+	// calendar quarter of the announcement
+	TotalsByQuarter(ctx context.Context, filter *Filter) ([]*QuarterlyTotal, error)
+}
+
+// Extractor turns a contract announcement into contract awards
+type Extractor interface {
+	Extract(ctx context.Context, article *news.Article) ([]*ContractAward, error)
+}
@@ -0,0 +1,164 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 1000
+)
+
+// Service handles contract award extraction and queries
+type Service struct {
+	repo      Repository
+	extractor Extractor
+	fallback  Extractor
+	resolver  company.Resolver
+	logger    *slog.Logger
+}
+
+// NewService creates a new contract award service.
+// extractor is optional; when nil, the rule-based extractor is used.
+// fallback is optional and is tried when the extractor finds no award.
+func NewService(repo Repository, extractor Extractor, fallback Extractor, resolver company.Resolver, logger *slog.Logger) *Service {
+	if extractor == nil {
+		extractor = NewRuleExtractor()
+	}
+
+	return &Service{
+		repo:      repo,
+		extractor: extractor,
+		fallback:  fallback,
+		resolver:  resolver,
+		logger:    logger,
+	}
+}
+
+// ExtractFromArticle extracts the contract awards of an article classified
+// as contract news and stores them, replacing earlier extractions of the
+// same article. It returns the number of awards stored.
+func (s *Service) ExtractFromArticle(ctx context.Context, article *news.Article) (int, error) {
+	if !article.HasCategory(news.CategoryContracts) {
+		return 0, nil
+	}
+
+	awards, err := s.extractor.Extract(ctx, article)
+	if err != nil {
+		s.logger.Warn("Contract extraction failed", "error", err, "title", article.Title)
+		if s.fallback == nil {
+			return 0, fmt.Errorf("failed to extract contract awards: %w", err)
+		}
+	}
+	if len(awards) == 0 && s.fallback != nil {
+		awards, err = s.fallback.Extract(ctx, article)
+		if err != nil {
+			s.logger.Warn("Fallback contract extraction failed", "error", err, "title", article.Title)
+			return 0, fmt.Errorf("failed to extract contract awards: %w", err)
+		}
+	}
+	if len(awards) == 0 {
+		return 0, nil
+	}
+
+	for _, award := range awards {
+		award.CompanyName = s.resolveCompany(ctx, award.Awardee, article.Companies)
+	}
+
+	if err := s.repo.ReplaceForArticle(ctx, article.ID, awards); err != nil {
+		s.logger.Error("Failed to store contract awards", "error", err, "article_id", article.ID.Hex())
+		return 0, err
+	}
+
+	s.logger.Info("Extracted contract awards", "count", len(awards), "article_id", article.ID.Hex())
+	return len(awards), nil
+}
+
+// resolveCompany maps an awardee to a tracked company, first through the
+// company resolver and then by looking for one of the article's companies in
+// the awardee name ("Lockheed Martin Rotary and Mission Systems")
+func (s *Service) resolveCompany(ctx context.Context, awardee string, companies []string) string {
+	if s.resolver != nil {
+		resolution, err := s.resolver.Resolve(ctx, awardee)
+		switch {
+		case err == nil:
+			return resolution.Company.Name
+		case !errors.Is(err, company.ErrCompanyNotFound):
+			s.logger.Warn("Failed to resolve awardee", "error", err, "awardee", awardee)
+		}
+	}
+
+	lower := strings.ToLower(awardee)
+	for _, name := range companies {
+		if strings.Contains(lower, strings.ToLower(name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// ListAwards retrieves contract awards with filtering and pagination
+func (s *Service) ListAwards(ctx context.Context, filter *Filter) ([]*ContractAward, int64, error) {
+	if err := s.validateFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	awards, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list contract awards", "error", err)
+		return nil, 0, err
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to count contract awards", "error", err)
+		return nil, 0, err
+	}
+
+	return awards, total, nil
+}
+
+// QuarterlyTotals returns the total awarded per company and calendar quarter
+func (s *Service) QuarterlyTotals(ctx context.Context, filter *Filter) ([]*QuarterlyTotal, error) {
+	if err := s.validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.TotalsByQuarter(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to aggregate contract awards", "error", err)
+		return nil, err
+	}
+	return totals, nil
+}
+
+// validateFilter validates filter parameters and applies the default limit
+func (s *Service) validateFilter(filter *Filter) error {
+	if filter == nil {
+		return ErrInvalidFilter
+	}
+
+	if filter.Limit < 0 || filter.Limit > maxListLimit || filter.Offset < 0 {
+		return ErrInvalidFilter
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultListLimit
+	}
+
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return ErrInvalidFilter
+	}
+
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return ErrInvalidFilter
+	}
+
+	return nil
+}
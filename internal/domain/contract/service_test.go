@@ -0,0 +1,32 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// failingExtractor fails every extraction
+type failingExtractor struct {
+	err error
+}
+
+func (e *failingExtractor) Extract(ctx context.Context, article *news.Article) ([]*ContractAward, error) {
+	return nil, e.err
+}
+
+func TestExtractFromArticleReturnsExtractorError(t *testing.T) {
+	extractErr := errors.New("model unavailable")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	service := NewService(nil, &failingExtractor{err: extractErr}, nil, nil, logger)
+
+	article := &news.Article{Title: "Boeing awarded $2.3 billion contract", Categories: []news.Category{news.CategoryContracts}}
+	count, err := service.ExtractFromArticle(context.Background(), article)
+	if count != 0 || !errors.Is(err, extractErr) {
+		t.Errorf("Expected the extractor error without a fallback, got %d (%v)", count, err)
+	}
+}
@@ -0,0 +1,12 @@
+package conversation
+
+import "errors"
+
+// Domain errors for conversations
+var (
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrConversationFull     = errors.New("conversation has reached its message limit")
+	ErrConversationConflict = errors.New("conversation was updated concurrently")
+	ErrInvalidFilter        = errors.New("invalid conversation filter")
+	ErrInvalidQuestion      = errors.New("invalid question")
+)
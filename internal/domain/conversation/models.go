@@ -0,0 +1,93 @@
+package conversation
+
+import (
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Role is the author of a message
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+// Conversation is a chat session of questions and answers. Older turns are
+// folded into a rolling summary so that follow-ups keep their context
+// without replaying the whole session.
+type Conversation struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Title          string             `json:"title" bson:"title"`
+	CompanyContext []string           `json:"company_context,omitempty" bson:"company_context,omitempty"`
+	Messages       []Message          `json:"messages,omitempty" bson:"messages"`
+	MessageCount   int                `json:"message_count" bson:"message_count"`
+	// Summary summarises the first SummarizedMessages messages
+	Summary            string    `json:"summary,omitempty" bson:"summary,omitempty"`
+	SummarizedMessages int       `json:"summarized_messages" bson:"summarized_messages"`
+	CreatedAt          time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// History returns the context of the conversation's next question: its
+// summary and the messages not yet summarised
+func (c *Conversation) History() History {
+	from := min(c.SummarizedMessages, len(c.Messages))
+	return History{Summary: c.Summary, Messages: c.Messages[from:]}
+}
+
+// Message is a question or an answer of a conversation
+type Message struct {
+	Role    Role   `json:"role" bson:"role"`
+	Content string `json:"content" bson:"content"`
+	// StandaloneQuestion is the question as answered, rewritten to stand
+	// without the conversation; set on follow-up questions
+	StandaloneQuestion string `json:"standalone_question,omitempty" bson:"standalone_question,omitempty"`
+	// Sources are the articles and web pages the answer is based on
+	Sources       []ai.SourceReference `json:"sources,omitempty" bson:"sources,omitempty"`
+	WebSources    []ai.WebSearchSource `json:"web_sources,omitempty" bson:"web_sources,omitempty"`
+	UsedWebSearch bool                 `json:"used_web_search,omitempty" bson:"used_web_search,omitempty"`
+	Confidence    float64              `json:"confidence,omitempty" bson:"confidence,omitempty"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+}
+
+// Question returns the question a user message asks, standing on its own
+func (m *Message) Question() string {
+	if m.StandaloneQuestion != "" {
+		return m.StandaloneQuestion
+	}
+	return m.Content
+}
+
+// History is the context of a follow-up question: the summary of the
+// earlier turns and the recent messages
+type History struct {
+	Summary  string
+	Messages []Message
+}
+
+// Empty reports whether there is no earlier turn
+func (h History) Empty() bool {
+	return h.Summary == "" && len(h.Messages) == 0
+}
+
+// Turn is the outcome of a question asked in a conversation
+type Turn struct {
+	ConversationID     primitive.ObjectID `json:"conversation_id"`
+	Question           string             `json:"question"`
+	StandaloneQuestion string             `json:"standalone_question"`
+	Response           *ai.QueryResponse  `json:"response"`
+}
+
+// CreateRequest is the request to start a conversation
+type CreateRequest struct {
+	Title          string   `json:"title,omitempty"`
+	CompanyContext []string `json:"company_context,omitempty"`
+}
+
+// Filter is the filter of conversation listings
+type Filter struct {
+	Limit int
+}
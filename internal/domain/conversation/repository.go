@@ -0,0 +1,46 @@
+package conversation
+
+import (
+	"context"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+)
+
+// Repository defines the interface for persisting conversations
+type Repository interface {
+	// Create saves a new conversation
+	Create(ctx context.Context, conversation *Conversation) error
+
+	// GetByID retrieves a conversation with its messages
+	GetByID(ctx context.Context, id string) (*Conversation, error)
+
+	// List retrieves conversations without their messages, most recently
+	// updated first
+	List(ctx context.Context, filter *Filter) ([]*Conversation, error)
+
+	// AppendMessages adds messages to a stored conversation and saves its
+	// title, message count, summary and update time. The conversation's
+	// MessageCount includes the messages; when the stored conversation no
+	// longer has the count it was read with, nothing is saved and
+	// ErrConversationConflict is returned.
+	AppendMessages(ctx context.Context, conversation *Conversation, messages []Message) error
+
+	// Delete removes a conversation
+	Delete(ctx context.Context, id string) error
+}
+
+// Answerer answers standalone questions
+type Answerer interface {
+	ProcessQuery(ctx context.Context, req *ai.QueryRequest) (*ai.QueryResponse, error)
+}
+
+// Rewriter rewrites a follow-up question into a standalone question, using
+// the conversation history to resolve what it refers to
+type Rewriter interface {
+	Rewrite(ctx context.Context, history History, question string) (string, error)
+}
+
+// Summarizer folds messages into the rolling summary of a conversation
+type Summarizer interface {
+	Summarize(ctx context.Context, summary string, messages []Message) (string, error)
+}
@@ -0,0 +1,309 @@
+package conversation
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+const (
+	// maxSummaryTurns bounds the turns kept in a rule-based summary
+	maxSummaryTurns = 10
+
+	// summaryAnswerTokens bounds the answer kept for each summarised turn
+	summaryAnswerTokens = 40
+
+	// shortFollowUpWords is the length under which a question is taken as a follow-up
+	shortFollowUpWords = 4
+)
+
+// followUpPrefixes start questions that continue the previous one
+var followUpPrefixes = []string{
+	"and ", "what about", "how about", "what of", "same for", "same question", "also ",
+	"but ", "compared to", "how does that", "why is that", "why did they", "and?",
+}
+
+// additivePrefixes start follow-ups whose names are asked about alongside
+// those of the previous question rather than instead of them, with the
+// words joining them to the previous question
+var additivePrefixes = map[string]string{"compared to": "compared to", "also ": "and"}
+
+// questionWords start questions and are capitalised without being names
+var questionWords = map[string]bool{
+	"what": true, "which": true, "who": true, "whom": true, "whose": true, "when": true, "where": true,
+	"why": true, "how": true, "is": true, "are": true, "was": true, "were": true, "do": true, "does": true,
+	"did": true, "has": true, "have": true, "had": true, "can": true, "could": true, "will": true,
+	"would": true, "should": true, "show": true, "list": true, "tell": true, "give": true, "any": true,
+	"compare": true, "summarize": true, "summarise": true, "find": true, "the": true, "and": true,
+}
+
+// nameConnectors join the words of a name, as in "Bank of America"
+var nameConnectors = map[string]bool{"of": true, "&": true, "and": true}
+
+// substitutionWords may surround the names of a follow-up that only
+// substitutes names or a period, as in "and for Lockheed and Boeing?"
+var substitutionWords = map[string]bool{
+	"": true, "and": true, "or": true, "for": true, "in": true, "at": true, "with": true, "the": true,
+	"then": true, "instead": true, "now": true, "&": true,
+}
+
+// referenceWords refer back to something named earlier in the conversation
+var referenceWords = []string{"it", "its", "they", "them", "their", "theirs", "those", "these", "he", "she", "his", "her"}
+
+// RuleRewriter rewrites follow-up questions without a model. It works
+// offline and is the fallback of the model-based rewriter.
+type RuleRewriter struct {
+	times *ai.TimeResolver
+}
+
+// NewRuleRewriter creates a rule-based question rewriter
+func NewRuleRewriter() *RuleRewriter {
+	return &RuleRewriter{times: ai.NewTimeResolver(nil, nil)}
+}
+
+// Rewrite implements Rewriter. The questions of the recent messages are
+// replayed from the last one asked on its own, as the user wrote them. A
+// follow-up naming only companies or a period, such as "what about
+// Lockheed?", substitutes them for those of the previous question; other
+// follow-ups are completed with the previous question's companies and period.
+func (r *RuleRewriter) Rewrite(ctx context.Context, history History, question string) (string, error) {
+	question = strings.TrimSpace(question)
+
+	var asked []Message
+	for _, message := range history.Messages {
+		if message.Role == RoleUser {
+			asked = append(asked, message)
+		}
+	}
+	if len(asked) == 0 || !IsFollowUp(question) {
+		return question, nil
+	}
+
+	// Start from the last question asked on its own, or else from the
+	// earliest question as it was answered
+	start := 0
+	for i := len(asked) - 1; i >= 0; i-- {
+		if !IsFollowUp(asked[i].Content) {
+			start = i
+			break
+		}
+	}
+	previous := asked[start].Content
+	if start == 0 && IsFollowUp(previous) {
+		previous = asked[0].Question()
+	}
+	for _, message := range asked[start+1:] {
+		previous = r.rewrite(previous, strings.TrimSpace(message.Content))
+	}
+
+	return r.rewrite(previous, question), nil
+}
+
+// rewrite completes a question with the previous one, which stands on its own
+func (r *RuleRewriter) rewrite(previous, question string) string {
+	if !IsFollowUp(question) {
+		return question
+	}
+
+	rest := strings.TrimRight(strings.TrimSpace(question), "?!. ")
+	joining := ""
+	for trimmed := true; trimmed; {
+		trimmed = false
+		lower := strings.ToLower(rest)
+		for _, prefix := range followUpPrefixes {
+			if strings.HasPrefix(lower, prefix) {
+				if words, ok := additivePrefixes[prefix]; ok {
+					joining = words
+				}
+				rest = strings.TrimSpace(rest[len(prefix):])
+				trimmed = true
+				break
+			}
+		}
+	}
+
+	newTime, rest := r.cutTime(rest)
+	newNames := names(rest, false)
+	if !isSubstitution(rest, newNames) || (newTime == "" && len(newNames) == 0) {
+		// The follow-up asks something else about the same subject
+		previousTime, previousRest := r.cutTime(previous)
+		subject := names(previousRest, true)
+		if previousTime != "" {
+			subject = append(subject, previousTime)
+		}
+		if len(subject) == 0 {
+			return question
+		}
+		return question + " " + strings.Join(subject, " ")
+	}
+
+	rewritten := previous
+	if newTime != "" {
+		if previousTime, _ := r.cutTime(previous); previousTime != "" {
+			rewritten = strings.Replace(rewritten, previousTime, newTime, 1)
+		} else {
+			rewritten = insertBeforeEnd(rewritten, newTime)
+		}
+	}
+
+	_, previousRest := r.cutTime(previous)
+	previousNames := names(previousRest, true)
+	switch {
+	case len(newNames) == 0:
+	case joining == "and" && len(previousNames) > 0:
+		last := previousNames[len(previousNames)-1]
+		rewritten = strings.Replace(rewritten, last, last+" and "+strings.Join(newNames, " and "), 1)
+	case joining != "":
+		rewritten = insertBeforeEnd(rewritten, joining+" "+strings.Join(newNames, " and "))
+	case len(previousNames) == 0:
+		rewritten = insertBeforeEnd(rewritten, "for "+strings.Join(newNames, " and "))
+	default:
+		for i, name := range previousNames {
+			switch {
+			case i >= len(newNames):
+			case i == len(previousNames)-1:
+				rewritten = strings.Replace(rewritten, name, strings.Join(newNames[i:], " and "), 1)
+			default:
+				rewritten = strings.Replace(rewritten, name, newNames[i], 1)
+			}
+		}
+	}
+	return rewritten
+}
+
+// cutTime returns the time expression of text as written, and text without it
+func (r *RuleRewriter) cutTime(text string) (expression, rest string) {
+	window, ok := r.times.Resolve(text)
+	if !ok {
+		return "", text
+	}
+	index := strings.Index(strings.ToLower(text), window.Expression)
+	if index < 0 || index+len(window.Expression) > len(text) {
+		return "", text
+	}
+	expression = text[index : index+len(window.Expression)]
+	return expression, strings.TrimSpace(text[:index] + " " + text[index+len(expression):])
+}
+
+// names returns the runs of capitalised words of text, such as company
+// names. When text is a whole question, its first word only counts when it
+// is not a question word.
+func names(text string, question bool) []string {
+	var found []string
+	var run []string
+	flush := func() {
+		// Connectors only join names
+		for len(run) > 0 && nameConnectors[strings.ToLower(run[len(run)-1])] {
+			run = run[:len(run)-1]
+		}
+		if len(run) > 0 {
+			found = append(found, strings.Join(run, " "))
+		}
+		run = nil
+	}
+
+	for i, word := range strings.Fields(text) {
+		word = strings.TrimRight(word, "?!.,;:")
+		word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s")
+		switch {
+		case i == 0 && question && questionWords[strings.ToLower(word)]:
+			flush()
+		case isNameWord(word):
+			run = append(run, word)
+		case len(run) > 0 && nameConnectors[strings.ToLower(word)]:
+			run = append(run, word)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return found
+}
+
+// isNameWord reports whether a word is capitalised, as names are, or is a
+// designation such as F-35
+func isNameWord(word string) bool {
+	first, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(first) && word != "I"
+}
+
+// isSubstitution reports whether the rest of a follow-up holds nothing but
+// names and connecting words, as in "Lockheed and Boeing"
+func isSubstitution(rest string, found []string) bool {
+	for _, name := range found {
+		rest = strings.Replace(rest, name, " ", 1)
+	}
+	for _, word := range strings.Fields(strings.ToLower(rest)) {
+		word = strings.Trim(word, "?!.,;:")
+		if !substitutionWords[strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s")] {
+			return false
+		}
+	}
+	return true
+}
+
+// insertBeforeEnd inserts words before the final punctuation of a question
+func insertBeforeEnd(question, words string) string {
+	trimmed := strings.TrimRight(question, "?!. ")
+	return trimmed + " " + words + question[len(trimmed):]
+}
+
+// IsFollowUp reports whether a question depends on the previous ones, as
+// when it starts with "and" or "what about", refers back with a pronoun, or
+// is only a few words long
+func IsFollowUp(question string) bool {
+	lower := strings.ToLower(strings.TrimSpace(question))
+	for _, prefix := range followUpPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+
+	words := strings.FieldsFunc(lower, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-'
+	})
+	if len(words) < shortFollowUpWords {
+		return true
+	}
+	return slices.ContainsFunc(words, func(word string) bool { return slices.Contains(referenceWords, word) })
+}
+
+// RuleSummarizer summarises conversations without a model, keeping the
+// last questions with the start of their answers. It works offline and is
+// the fallback of the model-based summarizer.
+type RuleSummarizer struct{}
+
+// NewRuleSummarizer creates a rule-based summarizer
+func NewRuleSummarizer() *RuleSummarizer {
+	return &RuleSummarizer{}
+}
+
+// Summarize implements Summarizer
+func (s *RuleSummarizer) Summarize(ctx context.Context, summary string, messages []Message) (string, error) {
+	var turns []string
+	if summary != "" {
+		turns = strings.Split(summary, "\n")
+	}
+
+	for _, message := range messages {
+		switch message.Role {
+		case RoleUser:
+			turns = append(turns, "Q: "+message.Question())
+		case RoleAssistant:
+			if len(turns) > 0 && strings.HasPrefix(turns[len(turns)-1], "Q: ") {
+				answer := strings.Join(strings.Fields(news.TruncateTokens(message.Content, summaryAnswerTokens)), " ")
+				turns[len(turns)-1] += " A: " + answer
+			}
+		}
+	}
+
+	if len(turns) > maxSummaryTurns {
+		turns = turns[len(turns)-maxSummaryTurns:]
+	}
+	return strings.Join(turns, "\n"), nil
+}
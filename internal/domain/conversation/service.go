@@ -0,0 +1,252 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+
+	// RecentMessages is the number of latest messages kept word for word in
+	// the history of follow-ups; earlier ones are folded into the summary
+	RecentMessages = 6
+
+	// MaxMessages bounds the messages of a conversation
+	MaxMessages = 200
+
+	// maxQuestionLength bounds questions, as for single queries
+	maxQuestionLength = 1000
+
+	// maxTitleLength bounds titles taken from the first question
+	maxTitleLength = 80
+
+	// maxSaveAttempts bounds the attempts to save a turn while other turns
+	// of the conversation are being saved
+	maxSaveAttempts = 3
+)
+
+// Service manages conversations: it answers their questions in the
+// context of the earlier turns and keeps their rolling summaries
+type Service struct {
+	repo       Repository
+	answerer   Answerer
+	rewriter   Rewriter
+	summarizer Summarizer
+	logger     *slog.Logger
+}
+
+// NewService creates a new conversation service. rewriter and summarizer
+// are optional and default to the rule-based ones.
+func NewService(repo Repository, answerer Answerer, rewriter Rewriter, summarizer Summarizer, logger *slog.Logger) *Service {
+	if rewriter == nil {
+		rewriter = NewRuleRewriter()
+	}
+	if summarizer == nil {
+		summarizer = NewRuleSummarizer()
+	}
+
+	return &Service{
+		repo:       repo,
+		answerer:   answerer,
+		rewriter:   rewriter,
+		summarizer: summarizer,
+		logger:     logger,
+	}
+}
+
+// Create starts a conversation
+func (s *Service) Create(ctx context.Context, req *CreateRequest) (*Conversation, error) {
+	now := time.Now().UTC()
+	conversation := &Conversation{
+		Title:          strings.TrimSpace(req.Title),
+		CompanyContext: req.CompanyContext,
+		Messages:       []Message{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.repo.Create(ctx, conversation); err != nil {
+		s.logger.Error("Failed to create conversation", "error", err)
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// Get retrieves a conversation with its messages
+func (s *Service) Get(ctx context.Context, id string) (*Conversation, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// List retrieves conversations without their messages, most recently updated first
+func (s *Service) List(ctx context.Context, filter *Filter) ([]*Conversation, error) {
+	if filter == nil {
+		filter = &Filter{}
+	}
+
+	if filter.Limit < 0 || filter.Limit > maxListLimit {
+		return nil, ErrInvalidFilter
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultListLimit
+	}
+
+	conversations, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list conversations", "error", err)
+		return nil, err
+	}
+	return conversations, nil
+}
+
+// Delete removes a conversation
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Ask answers a question in a conversation. A follow-up is first rewritten
+// into a standalone question from the conversation's history, so that
+// query analysis and retrieval see what it refers to.
+func (s *Service) Ask(ctx context.Context, id string, question string) (*Turn, error) {
+	question = strings.TrimSpace(question)
+	if question == "" || len(question) > maxQuestionLength {
+		return nil, fmt.Errorf("%w: question must be 1 to %d characters", ErrInvalidQuestion, maxQuestionLength)
+	}
+
+	conversation, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(conversation.Messages)+2 > MaxMessages {
+		return nil, ErrConversationFull
+	}
+
+	standalone := s.standaloneQuestion(ctx, conversation, question)
+
+	response, err := s.answerer.ProcessQuery(ctx, &ai.QueryRequest{
+		Question:       standalone,
+		CompanyContext: conversation.CompanyContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	asked := Message{Role: RoleUser, Content: question, CreatedAt: now}
+	if standalone != question {
+		asked.StandaloneQuestion = standalone
+	}
+	answered := Message{
+		Role:          RoleAssistant,
+		Content:       response.Answer,
+		Sources:       response.Sources,
+		WebSources:    response.WebSources,
+		UsedWebSearch: response.UsedWebSearch,
+		Confidence:    response.Confidence,
+		CreatedAt:     now,
+	}
+
+	if err := s.saveTurn(ctx, conversation, []Message{asked, answered}, now); err != nil {
+		s.logger.Error("Failed to save conversation turn", "error", err, "conversation_id", id)
+		return nil, err
+	}
+
+	return &Turn{
+		ConversationID:     conversation.ID,
+		Question:           question,
+		StandaloneQuestion: standalone,
+		Response:           response,
+	}, nil
+}
+
+// saveTurn appends the messages of a turn to the conversation and saves it
+// with its summary. When another turn was saved since the conversation was
+// read, the turn is added after it from the stored conversation.
+func (s *Service) saveTurn(ctx context.Context, conversation *Conversation, messages []Message, now time.Time) error {
+	for attempt := 1; ; attempt++ {
+		conversation.Messages = append(conversation.Messages, messages...)
+		conversation.MessageCount = len(conversation.Messages)
+		conversation.UpdatedAt = now
+		if conversation.Title == "" {
+			conversation.Title = title(messages[0].Content)
+		}
+		s.summarize(ctx, conversation)
+
+		err := s.repo.AppendMessages(ctx, conversation, messages)
+		if !errors.Is(err, ErrConversationConflict) || attempt == maxSaveAttempts {
+			return err
+		}
+
+		stored, err := s.repo.GetByID(ctx, conversation.ID.Hex())
+		if err != nil {
+			return err
+		}
+		if len(stored.Messages)+len(messages) > MaxMessages {
+			return ErrConversationFull
+		}
+		*conversation = *stored
+	}
+}
+
+// standaloneQuestion rewrites a follow-up question, keeping the question as
+// asked when it opens the conversation or cannot be rewritten. Rewrites are
+// bounded like questions.
+func (s *Service) standaloneQuestion(ctx context.Context, conversation *Conversation, question string) string {
+	history := conversation.History()
+	if history.Empty() {
+		return question
+	}
+
+	standalone, err := s.rewriter.Rewrite(ctx, history, question)
+	if err != nil || strings.TrimSpace(standalone) == "" {
+		s.logger.Warn("Failed to rewrite follow-up question, asking it as is", "error", err, "conversation_id", conversation.ID.Hex())
+		return question
+	}
+	return truncateQuestion(strings.TrimSpace(standalone))
+}
+
+// truncateQuestion bounds a rewritten question like an asked one, cutting
+// it at a word
+func truncateQuestion(question string) string {
+	if len(question) <= maxQuestionLength {
+		return question
+	}
+	cut := strings.ToValidUTF8(question[:maxQuestionLength], "")
+	if space := strings.LastIndexByte(cut, ' '); space > 0 {
+		cut = cut[:space]
+	}
+	return strings.TrimSpace(cut)
+}
+
+// summarize folds the messages before the recent ones into the summary.
+// When summarising fails, the messages are folded in on a later turn.
+func (s *Service) summarize(ctx context.Context, conversation *Conversation) {
+	upTo := len(conversation.Messages) - RecentMessages
+	if upTo <= conversation.SummarizedMessages {
+		return
+	}
+
+	summary, err := s.summarizer.Summarize(ctx, conversation.Summary, conversation.Messages[conversation.SummarizedMessages:upTo])
+	if err != nil {
+		s.logger.Warn("Failed to summarize conversation", "error", err, "conversation_id", conversation.ID.Hex())
+		return
+	}
+	conversation.Summary = summary
+	conversation.SummarizedMessages = upTo
+}
+
+// title returns a conversation title from its first question
+func title(question string) string {
+	if utf8.RuneCountInString(question) <= maxTitleLength {
+		return question
+	}
+	return strings.TrimSpace(string([]rune(question)[:maxTitleLength-1])) + "…"
+}
@@ -0,0 +1,306 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryRepository stores conversations in memory
+type memoryRepository struct {
+	conversations map[string]*Conversation
+}
+
+func (r *memoryRepository) Create(ctx context.Context, conversation *Conversation) error {
+	conversation.ID = primitive.NewObjectID()
+	stored := *conversation
+	r.conversations[conversation.ID.Hex()] = &stored
+	return nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id string) (*Conversation, error) {
+	stored, ok := r.conversations[id]
+	if !ok {
+		return nil, ErrConversationNotFound
+	}
+	copied := *stored
+	copied.Messages = append([]Message{}, stored.Messages...)
+	return &copied, nil
+}
+
+func (r *memoryRepository) List(ctx context.Context, filter *Filter) ([]*Conversation, error) {
+	var conversations []*Conversation
+	for _, stored := range r.conversations {
+		conversations = append(conversations, stored)
+	}
+	return conversations, nil
+}
+
+func (r *memoryRepository) AppendMessages(ctx context.Context, conversation *Conversation, messages []Message) error {
+	stored, ok := r.conversations[conversation.ID.Hex()]
+	if !ok {
+		return ErrConversationNotFound
+	}
+	if stored.MessageCount != conversation.MessageCount-len(messages) {
+		return ErrConversationConflict
+	}
+	stored.Messages = append(stored.Messages, messages...)
+	stored.MessageCount += len(messages)
+	stored.Title = conversation.Title
+	stored.Summary = conversation.Summary
+	stored.SummarizedMessages = conversation.SummarizedMessages
+	stored.UpdatedAt = conversation.UpdatedAt
+	return nil
+}
+
+func (r *memoryRepository) Delete(ctx context.Context, id string) error {
+	if _, ok := r.conversations[id]; !ok {
+		return ErrConversationNotFound
+	}
+	delete(r.conversations, id)
+	return nil
+}
+
+// recordingAnswerer answers with the question it was asked. answering is
+// called once, while the next question is being answered.
+type recordingAnswerer struct {
+	asked     []*ai.QueryRequest
+	answering func()
+}
+
+func (a *recordingAnswerer) ProcessQuery(ctx context.Context, req *ai.QueryRequest) (*ai.QueryResponse, error) {
+	a.asked = append(a.asked, req)
+	if answering := a.answering; answering != nil {
+		a.answering = nil
+		answering()
+	}
+	return &ai.QueryResponse{
+		Answer:     "Answer to: " + req.Question + ". More detail follows.",
+		Sources:    []ai.SourceReference{{ArticleID: fmt.Sprintf("article-%d", len(a.asked))}},
+		Confidence: 0.8,
+	}, nil
+}
+
+func newTestService() (*Service, *memoryRepository, *recordingAnswerer) {
+	repo := &memoryRepository{conversations: make(map[string]*Conversation)}
+	answerer := &recordingAnswerer{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewService(repo, answerer, nil, nil, logger), repo, answerer
+}
+
+func TestServiceAskRewritesFollowUps(t *testing.T) {
+	service, repo, answerer := newTestService()
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, &CreateRequest{CompanyContext: []string{"Raytheon Technologies"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	id := created.ID.Hex()
+
+	first, err := service.Ask(ctx, id, "Which contracts did RTX win this quarter?")
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if first.StandaloneQuestion != "Which contracts did RTX win this quarter?" {
+		t.Errorf("Expected the first question asked as is, got %q", first.StandaloneQuestion)
+	}
+
+	second, err := service.Ask(ctx, id, "And what about Lockheed?")
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if second.StandaloneQuestion != "Which contracts did Lockheed win this quarter?" {
+		t.Errorf("Expected Lockheed substituted for RTX in the previous question, got %q", second.StandaloneQuestion)
+	}
+	if answerer.asked[1].Question != second.StandaloneQuestion {
+		t.Error("Expected the standalone question to be answered")
+	}
+	if answerer.asked[1].CompanyContext[0] != "Raytheon Technologies" {
+		t.Error("Expected the conversation's company context passed on")
+	}
+
+	stored := repo.conversations[id]
+	if len(stored.Messages) != 4 || stored.MessageCount != 4 {
+		t.Fatalf("Expected 4 stored messages, got %d", len(stored.Messages))
+	}
+	if stored.Messages[2].StandaloneQuestion != second.StandaloneQuestion || stored.Messages[3].Sources[0].ArticleID != "article-2" {
+		t.Errorf("Expected the rewritten question and sources stored with the turn, got %+v", stored.Messages[2:])
+	}
+	if stored.Title != "Which contracts did RTX win this quarter?" {
+		t.Errorf("Expected the title taken from the first question, got %q", stored.Title)
+	}
+}
+
+func TestServiceAskRollsSummary(t *testing.T) {
+	service, repo, _ := newTestService()
+	ctx := context.Background()
+
+	created, _ := service.Create(ctx, &CreateRequest{Title: "Hypersonics"})
+	id := created.ID.Hex()
+	for i := 1; i <= 5; i++ {
+		if _, err := service.Ask(ctx, id, fmt.Sprintf("What happened with hypersonic test number %d?", i)); err != nil {
+			t.Fatalf("Ask() error = %v", err)
+		}
+	}
+
+	stored := repo.conversations[id]
+	if stored.SummarizedMessages != len(stored.Messages)-RecentMessages {
+		t.Errorf("Expected all but the recent messages summarised, got %d of %d", stored.SummarizedMessages, len(stored.Messages))
+	}
+	if !strings.Contains(stored.Summary, "test number 1?") || !strings.Contains(stored.Summary, "A: Answer to: What happened with hypersonic test number 2?") {
+		t.Errorf("Expected the early turns in the summary, got %q", stored.Summary)
+	}
+	if strings.Contains(stored.Summary, "test number 3") {
+		t.Errorf("Expected the recent turns kept out of the summary, got %q", stored.Summary)
+	}
+	if len(stored.History().Messages) != RecentMessages {
+		t.Errorf("Expected the history to hold the recent messages, got %d", len(stored.History().Messages))
+	}
+	if stored.Title != "Hypersonics" {
+		t.Errorf("Expected the given title kept, got %q", stored.Title)
+	}
+}
+
+func TestServiceAskConcurrentTurns(t *testing.T) {
+	service, repo, answerer := newTestService()
+	ctx := context.Background()
+
+	created, _ := service.Create(ctx, &CreateRequest{})
+	id := created.ID.Hex()
+	for i := 1; i <= 3; i++ {
+		service.Ask(ctx, id, fmt.Sprintf("What happened with hypersonic test number %d?", i))
+	}
+
+	// Another turn is saved while this one is being answered
+	answerer.answering = func() {
+		if _, err := service.Ask(ctx, id, "What happened with hypersonic test number 4?"); err != nil {
+			t.Errorf("Ask() error = %v", err)
+		}
+	}
+	if _, err := service.Ask(ctx, id, "What happened with hypersonic test number 5?"); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	stored := repo.conversations[id]
+	if len(stored.Messages) != 10 || stored.MessageCount != 10 {
+		t.Fatalf("Expected both turns saved, got %d messages", len(stored.Messages))
+	}
+	if !strings.Contains(stored.Messages[6].Content, "number 4") || !strings.Contains(stored.Messages[8].Content, "number 5") {
+		t.Errorf("Expected the later turn saved after the concurrent one, got %q and %q", stored.Messages[6].Content, stored.Messages[8].Content)
+	}
+	if stored.SummarizedMessages != len(stored.Messages)-RecentMessages || !strings.Contains(stored.Summary, "number 2?") {
+		t.Errorf("Expected the summary to cover both turns, got %d messages summarised: %q", stored.SummarizedMessages, stored.Summary)
+	}
+}
+
+func TestServiceAskErrors(t *testing.T) {
+	service, _, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := service.Ask(ctx, primitive.NewObjectID().Hex(), "Any news?"); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("Expected ErrConversationNotFound, got %v", err)
+	}
+
+	created, _ := service.Create(ctx, &CreateRequest{})
+	if _, err := service.Ask(ctx, created.ID.Hex(), "  "); !errors.Is(err, ErrInvalidQuestion) {
+		t.Errorf("Expected ErrInvalidQuestion, got %v", err)
+	}
+
+	if _, err := service.List(ctx, &Filter{Limit: maxListLimit + 1}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter, got %v", err)
+	}
+}
+
+func TestRuleRewriterRewrite(t *testing.T) {
+	history := func(questions ...string) History {
+		var messages []Message
+		for _, question := range questions {
+			messages = append(messages, Message{Role: RoleUser, Content: question}, Message{Role: RoleAssistant, Content: "An answer."})
+		}
+		return History{Messages: messages}
+	}
+
+	tests := []struct {
+		name     string
+		previous []string
+		question string
+		want     string
+	}{
+		{"new question", []string{"Which contracts did RTX win this quarter?"}, "What is the status of the B-21 program?", "What is the status of the B-21 program?"},
+		{"company", []string{"Which contracts did RTX win this quarter?"}, "And what about Lockheed?", "Which contracts did Lockheed win this quarter?"},
+		{"period", []string{"Which contracts did RTX win this quarter?"}, "What about last year?", "Which contracts did RTX win last year?"},
+		{"chained", []string{"Which contracts did RTX win this quarter?", "And what about Lockheed?"}, "What about last year?", "Which contracts did Lockheed win last year?"},
+		{"added company", []string{"Which contracts were awarded this quarter?"}, "What about Lockheed Martin?", "Which contracts were awarded this quarter for Lockheed Martin?"},
+		{"also", []string{"Which contracts did RTX win this quarter?"}, "Also Lockheed?", "Which contracts did RTX and Lockheed win this quarter?"},
+		{"reference", []string{"Which contracts did RTX win this quarter?"}, "Why did they lose it?", "Why did they lose it? RTX this quarter"},
+	}
+
+	rewriter := NewRuleRewriter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rewriter.Rewrite(context.Background(), history(tt.previous...), tt.question)
+			if err != nil {
+				t.Fatalf("Rewrite() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Rewrite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceAskBoundsRewrites(t *testing.T) {
+	service, _, _ := newTestService()
+	ctx := context.Background()
+
+	created, _ := service.Create(ctx, &CreateRequest{})
+	id := created.ID.Hex()
+	service.Ask(ctx, id, "Which contracts did RTX win this quarter?")
+
+	// Follow-ups are rewritten from the questions as asked, so they do not grow
+	var lengths []int
+	for _, question := range []string{"Why did they lose it?", "And Lockheed?", "Why is that?", "What about Boeing?"} {
+		turn, err := service.Ask(ctx, id, question)
+		if err != nil {
+			t.Fatalf("Ask() error = %v", err)
+		}
+		lengths = append(lengths, len(turn.StandaloneQuestion))
+		if strings.Count(turn.StandaloneQuestion, "RTX") > 1 || strings.Contains(turn.StandaloneQuestion, "Why did they lose it? Why") {
+			t.Errorf("Expected the rewrite not to repeat earlier rewrites, got %q", turn.StandaloneQuestion)
+		}
+	}
+	if lengths[3] > len("Which contracts did RTX win this quarter?")+10 {
+		t.Errorf("Expected bounded rewrites, got lengths %v", lengths)
+	}
+
+	long := strings.Repeat("word ", 300)
+	if got := truncateQuestion(long); len(got) > maxQuestionLength || strings.HasSuffix(got, "wor") {
+		t.Errorf("Expected the rewrite cut at a word within %d characters, got %d", maxQuestionLength, len(got))
+	}
+}
+
+func TestIsFollowUp(t *testing.T) {
+	tests := map[string]bool{
+		"And what about Lockheed?":                     true,
+		"What about last year?":                        true,
+		"Why did they lose it?":                        true,
+		"Northrop?":                                    true,
+		"Which contracts did RTX win this quarter?":    false,
+		"What is the status of the B-21 program?":      false,
+		"Compare Boeing and Airbus commercial backlog": false,
+	}
+
+	for question, want := range tests {
+		if got := IsFollowUp(question); got != want {
+			t.Errorf("IsFollowUp(%q) = %v, want %v", question, got, want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package embedding
+
+import (
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// ArticleChunks splits an article's body text into the sentence-aware
+// passages to embed. An article without body text has one empty passage,
+// so that it is still found by its title.
+func ArticleChunks(article *news.Article) []news.ContentChunk {
+	chunks := news.ArticleContentChunks(article)
+	if len(chunks) == 0 {
+		return []news.ContentChunk{{}}
+	}
+	return chunks
+}
+
+// embeddingText is the text embedded for a passage. It starts with the
+// title, which gives the passage context for the embedder.
+func embeddingText(article *news.Article, chunk news.ContentChunk) string {
+	if chunk.Text == "" {
+		return article.Title
+	}
+	return article.Title + "\n\n" + chunk.Text
+}
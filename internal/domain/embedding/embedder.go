@@ -0,0 +1,129 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// Embedder turns texts into vectors whose cosine similarity reflects how
+// close their meanings are
+type Embedder interface {
+	// Model names the embedding model; it is stored with each chunk
+	Model() string
+
+	// Dimensions is the length of the returned vectors
+	Dimensions() int
+
+	// Embed returns one vector per text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// DefaultHashingDimensions is the vector length of the default hashing embedder
+const DefaultHashingDimensions = 512
+
+// HashingEmbedder embeds texts offline by hashing their stemmed words and
+// word pairs into a fixed number of dimensions. It is deterministic, so it
+// suits tests and deployments without an embedding API, but it only captures
+// shared vocabulary, not synonyms.
+type HashingEmbedder struct {
+	dimensions int
+}
+
+// NewHashingEmbedder creates a hashing embedder with the given vector length
+func NewHashingEmbedder(dimensions int) *HashingEmbedder {
+	if dimensions <= 0 {
+		dimensions = DefaultHashingDimensions
+	}
+	return &HashingEmbedder{dimensions: dimensions}
+}
+
+// Model implements Embedder
+func (e *HashingEmbedder) Model() string {
+	return fmt.Sprintf("hashing-%d", e.dimensions)
+}
+
+// Dimensions implements Embedder
+func (e *HashingEmbedder) Dimensions() int {
+	return e.dimensions
+}
+
+// Embed implements Embedder
+func (e *HashingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+// embed hashes each term and adjacent term pair into a signed dimension;
+// pairs weigh half as much as single terms
+func (e *HashingEmbedder) embed(text string) []float32 {
+	vector := make([]float32, e.dimensions)
+	terms := news.SearchTerms(text)
+	for i, term := range terms {
+		e.add(vector, term, 1)
+		if i > 0 {
+			e.add(vector, terms[i-1]+" "+term, 0.5)
+		}
+	}
+	Normalize(vector)
+	return vector
+}
+
+// add adds weight to the dimension a feature hashes to; one bit of the hash
+// chooses the sign so that collisions cancel out on average
+func (e *HashingEmbedder) add(vector []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	if sum&(1<<63) != 0 {
+		weight = -weight
+	}
+	vector[sum%uint64(e.dimensions)] += weight
+}
+
+// Normalize scales a vector to unit length in place; zero vectors are left unchanged
+func Normalize(vector []float32) {
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return
+	}
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+}
+
+// Cosine returns the cosine similarity of two vectors of equal length, or 0
+// when either is a zero vector
+func Cosine(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// dot returns the dot product of two vectors, which is their cosine
+// similarity when both are normalised
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
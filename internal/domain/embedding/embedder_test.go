@@ -0,0 +1,76 @@
+package embedding
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+func TestHashingEmbedderSimilarity(t *testing.T) {
+	embedder := NewHashingEmbedder(DefaultHashingDimensions)
+	vectors, err := embedder.Embed(context.Background(), []string{
+		"Army awards hypersonic missile contract",
+		"The Army awarded a contract for hypersonic missiles",
+		"Quarterly earnings beat analyst expectations",
+	})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+
+	for _, vector := range vectors {
+		if len(vector) != embedder.Dimensions() {
+			t.Fatalf("Expected %d dimensions, got %d", embedder.Dimensions(), len(vector))
+		}
+	}
+
+	related, unrelated := Cosine(vectors[0], vectors[1]), Cosine(vectors[0], vectors[2])
+	if related <= unrelated || related < 0.5 {
+		t.Errorf("Expected related texts to be more similar: related %v, unrelated %v", related, unrelated)
+	}
+}
+
+func TestHashingEmbedderDeterministic(t *testing.T) {
+	text := "Lockheed Martin delivers F-35 jets"
+	first, _ := NewHashingEmbedder(64).Embed(context.Background(), []string{text})
+	second, _ := NewHashingEmbedder(64).Embed(context.Background(), []string{text})
+	if !slices.Equal(first[0], second[0]) {
+		t.Error("Expected identical vectors for identical text")
+	}
+
+	var norm float64
+	for _, v := range first[0] {
+		norm += float64(v) * float64(v)
+	}
+	if math.Abs(norm-1) > 1e-5 {
+		t.Errorf("Expected a unit vector, got norm %v", norm)
+	}
+}
+
+func TestArticleChunks(t *testing.T) {
+	article := &news.Article{
+		Title:   "Army awards hypersonic contract",
+		Content: strings.Repeat("The Army awarded a contract for hypersonic glide bodies. ", 60),
+	}
+
+	chunks := ArticleChunks(article)
+	if len(chunks) < 2 {
+		t.Fatalf("Expected several chunks, got %d", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if chunk.Tokens > news.DefaultChunkTokens {
+			t.Errorf("Chunk %d has %d tokens, over the %d limit", chunk.Index, chunk.Tokens, news.DefaultChunkTokens)
+		}
+		if text := embeddingText(article, chunk); !strings.HasPrefix(text, article.Title+"\n\n") {
+			t.Errorf("Expected the embedded text to start with the title, got %q", text)
+		}
+	}
+
+	empty := ArticleChunks(&news.Article{Title: "Title only"})
+	if len(empty) != 1 || embeddingText(&news.Article{Title: "Title only"}, empty[0]) != "Title only" {
+		t.Errorf("Expected the title alone for an article without body, got %+v", empty)
+	}
+}
@@ -0,0 +1,9 @@
+package embedding
+
+import "errors"
+
+// Domain errors for embeddings
+var (
+	ErrDimensionMismatch = errors.New("embedding dimensions do not match the index")
+	ErrEmptyQuery        = errors.New("query text cannot be empty")
+)
@@ -0,0 +1,297 @@
+package embedding
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HNSWConfig tunes a hierarchical navigable small world graph
+type HNSWConfig struct {
+	// M is the number of neighbours linked per node above the base layer;
+	// the base layer links twice as many
+	M int
+	// EfConstruction is the candidate list size when inserting
+	EfConstruction int
+	// EfSearch is the minimum candidate list size when searching; larger
+	// values trade speed for recall
+	EfSearch int
+	// MaxDeletedRatio is the share of deleted nodes above which the graph is
+	// rebuilt from its live nodes
+	MaxDeletedRatio float64
+}
+
+// DefaultHNSWConfig returns settings with recall close to exact search for
+// up to a few hundred thousand chunks
+func DefaultHNSWConfig() HNSWConfig {
+	return HNSWConfig{M: 16, EfConstruction: 128, EfSearch: 64, MaxDeletedRatio: 0.25}
+}
+
+// hnswNode is a chunk in the graph with its neighbours on each layer
+type hnswNode struct {
+	indexedChunk
+	neighbours [][]int
+	deleted    bool
+}
+
+// HNSWIndex is an approximate nearest neighbour index (Malkov and Yashunin,
+// 2016). Search cost grows logarithmically with the number of chunks.
+// Replaced chunks stay in the graph as deleted nodes that are traversed but
+// never returned, until they pass MaxDeletedRatio of the nodes and the graph
+// is rebuilt.
+type HNSWIndex struct {
+	mu         sync.RWMutex
+	config     HNSWConfig
+	levelScale float64
+	dimensions int
+	nodes      []*hnswNode
+	entry      int // -1 while the index is empty
+	maxLevel   int
+	articles   map[primitive.ObjectID][]int
+	size       int
+	deleted    int
+	random     *rand.Rand
+}
+
+// NewHNSWIndex creates an empty HNSW index; zero config fields take the defaults
+func NewHNSWIndex(config HNSWConfig) *HNSWIndex {
+	defaults := DefaultHNSWConfig()
+	if config.M <= 0 {
+		config.M = defaults.M
+	}
+	if config.EfConstruction <= 0 {
+		config.EfConstruction = defaults.EfConstruction
+	}
+	if config.EfSearch <= 0 {
+		config.EfSearch = defaults.EfSearch
+	}
+	if config.MaxDeletedRatio <= 0 {
+		config.MaxDeletedRatio = defaults.MaxDeletedRatio
+	}
+
+	return &HNSWIndex{
+		config:     config,
+		levelScale: 1 / math.Log(float64(config.M)),
+		entry:      -1,
+		articles:   make(map[primitive.ObjectID][]int),
+		// A fixed seed keeps the graph reproducible for the same insertions
+		random: rand.New(rand.NewSource(1)),
+	}
+}
+
+// Add implements Index
+func (x *HNSWIndex) Add(chunks []*Chunk) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if err := checkDimensions(&x.dimensions, chunks); err != nil {
+		return err
+	}
+
+	for _, id := range articleIDs(chunks) {
+		for _, node := range x.articles[id] {
+			x.nodes[node].deleted = true
+			x.size--
+			x.deleted++
+		}
+		delete(x.articles, id)
+	}
+	if float64(x.deleted) > x.config.MaxDeletedRatio*float64(len(x.nodes)) {
+		x.rebuild()
+	}
+	for _, chunk := range chunks {
+		x.insert(chunk)
+	}
+	return nil
+}
+
+// rebuild replaces the graph with one of its live nodes, in insertion order
+func (x *HNSWIndex) rebuild() {
+	nodes := x.nodes
+	x.nodes = make([]*hnswNode, 0, x.size)
+	x.entry, x.maxLevel = -1, 0
+	x.articles = make(map[primitive.ObjectID][]int)
+	x.size, x.deleted = 0, 0
+
+	for _, node := range nodes {
+		if !node.deleted {
+			x.insert(node.chunk)
+		}
+	}
+}
+
+// insert links a new node into every layer up to its random level
+func (x *HNSWIndex) insert(chunk *Chunk) {
+	level := int(math.Floor(-math.Log(1-x.random.Float64()) * x.levelScale))
+	node := &hnswNode{indexedChunk: newIndexedChunk(chunk), neighbours: make([][]int, level+1)}
+	id := len(x.nodes)
+	x.nodes = append(x.nodes, node)
+	x.articles[chunk.ArticleID] = append(x.articles[chunk.ArticleID], id)
+	x.size++
+
+	if x.entry < 0 {
+		x.entry, x.maxLevel = id, level
+		return
+	}
+
+	// Descend greedily through the layers above the node's level
+	entry := x.entry
+	for layer := x.maxLevel; layer > level; layer-- {
+		entry = x.searchLayer(node.vector, entry, 1, layer)[0].id
+	}
+
+	for layer := min(level, x.maxLevel); layer >= 0; layer-- {
+		candidates := x.searchLayer(node.vector, entry, x.config.EfConstruction, layer)
+		for _, neighbour := range candidates[:min(len(candidates), x.config.M)] {
+			node.neighbours[layer] = append(node.neighbours[layer], neighbour.id)
+			x.link(neighbour.id, id, layer)
+		}
+		entry = candidates[0].id
+	}
+
+	if level > x.maxLevel {
+		x.entry, x.maxLevel = id, level
+	}
+}
+
+// link adds a neighbour to a node's layer, keeping only the closest
+// neighbours once the layer is full
+func (x *HNSWIndex) link(from, to, layer int) {
+	node := x.nodes[from]
+	node.neighbours[layer] = append(node.neighbours[layer], to)
+
+	limit := x.config.M
+	if layer == 0 {
+		limit *= 2
+	}
+	if len(node.neighbours[layer]) <= limit {
+		return
+	}
+
+	neighbours := node.neighbours[layer]
+	sort.Slice(neighbours, func(a, b int) bool {
+		return x.distance(node.vector, neighbours[a]) < x.distance(node.vector, neighbours[b])
+	})
+	node.neighbours[layer] = neighbours[:limit]
+}
+
+// distance is the cosine distance between a normalised vector and a node
+func (x *HNSWIndex) distance(vector []float32, node int) float64 {
+	return 1 - dot(vector, x.nodes[node].vector)
+}
+
+// Search implements Index. The candidate list grows until enough chunks
+// pass the filter or the whole graph has been considered.
+func (x *HNSWIndex) Search(vector []float32, filter *Filter, limit int) []Match {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if len(vector) != x.dimensions || limit <= 0 || x.entry < 0 {
+		return nil
+	}
+	query := make([]float32, len(vector))
+	copy(query, vector)
+	Normalize(query)
+
+	entry := x.entry
+	for layer := x.maxLevel; layer > 0; layer-- {
+		entry = x.searchLayer(query, entry, 1, layer)[0].id
+	}
+
+	for ef := max(x.config.EfSearch, limit); ; ef *= 2 {
+		var matches []Match
+		for _, candidate := range x.searchLayer(query, entry, ef, 0) {
+			node := x.nodes[candidate.id]
+			if !node.deleted && filter.Matches(node.chunk) {
+				matches = append(matches, Match{Chunk: node.chunk, Score: 1 - candidate.distance})
+			}
+		}
+		if len(matches) >= limit || ef >= len(x.nodes) {
+			sortMatches(matches)
+			return matches[:min(len(matches), limit)]
+		}
+	}
+}
+
+// Len implements Index
+func (x *HNSWIndex) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.size
+}
+
+// hnswCandidate is a node and its distance to the query
+type hnswCandidate struct {
+	id       int
+	distance float64
+}
+
+// searchLayer returns the ef nodes of a layer closest to the vector, closest
+// first, by best-first traversal from the entry node
+func (x *HNSWIndex) searchLayer(vector []float32, entry, ef, layer int) []hnswCandidate {
+	visited := map[int]bool{entry: true}
+	start := hnswCandidate{id: entry, distance: x.distance(vector, entry)}
+	candidates := &candidateHeap{items: []hnswCandidate{start}}
+	results := &candidateHeap{items: []hnswCandidate{start}, furthestFirst: true}
+
+	for candidates.Len() > 0 {
+		current := heap.Pop(candidates).(hnswCandidate)
+		if current.distance > results.items[0].distance && results.Len() >= ef {
+			break
+		}
+
+		node := x.nodes[current.id]
+		if layer >= len(node.neighbours) {
+			continue
+		}
+		for _, neighbour := range node.neighbours[layer] {
+			if visited[neighbour] {
+				continue
+			}
+			visited[neighbour] = true
+
+			distance := x.distance(vector, neighbour)
+			if results.Len() < ef || distance < results.items[0].distance {
+				heap.Push(candidates, hnswCandidate{id: neighbour, distance: distance})
+				heap.Push(results, hnswCandidate{id: neighbour, distance: distance})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sort.Slice(results.items, func(a, b int) bool {
+		return results.items[a].distance < results.items[b].distance
+	})
+	return results.items
+}
+
+// candidateHeap orders candidates closest first, or furthest first
+type candidateHeap struct {
+	items         []hnswCandidate
+	furthestFirst bool
+}
+
+func (h *candidateHeap) Len() int { return len(h.items) }
+
+func (h *candidateHeap) Less(a, b int) bool {
+	if h.furthestFirst {
+		return h.items[a].distance > h.items[b].distance
+	}
+	return h.items[a].distance < h.items[b].distance
+}
+
+func (h *candidateHeap) Swap(a, b int) { h.items[a], h.items[b] = h.items[b], h.items[a] }
+
+func (h *candidateHeap) Push(item any) { h.items = append(h.items, item.(hnswCandidate)) }
+
+func (h *candidateHeap) Pop() any {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
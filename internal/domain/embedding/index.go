@@ -0,0 +1,144 @@
+package embedding
+
+import (
+	"slices"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Index stores chunk vectors for similarity search. Implementations are
+// safe for concurrent use.
+type Index interface {
+	// Add indexes chunks, replacing the chunks indexed earlier for the same articles
+	Add(chunks []*Chunk) error
+
+	// Search returns up to limit chunks matching the filter, most similar to
+	// the vector first
+	Search(vector []float32, filter *Filter, limit int) []Match
+
+	// Len returns the number of indexed chunks
+	Len() int
+}
+
+// indexedChunk is a chunk with its normalised vector
+type indexedChunk struct {
+	chunk  *Chunk
+	vector []float32
+}
+
+// newIndexedChunk copies and normalises the chunk's vector so that the dot
+// product gives the cosine similarity
+func newIndexedChunk(chunk *Chunk) indexedChunk {
+	vector := slices.Clone(chunk.Vector)
+	Normalize(vector)
+	return indexedChunk{chunk: chunk, vector: vector}
+}
+
+// checkDimensions verifies every chunk has the index's vector length,
+// setting it from the first chunk of an empty index
+func checkDimensions(dimensions *int, chunks []*Chunk) error {
+	for _, chunk := range chunks {
+		if *dimensions == 0 {
+			*dimensions = len(chunk.Vector)
+		}
+		if len(chunk.Vector) != *dimensions {
+			return ErrDimensionMismatch
+		}
+	}
+	return nil
+}
+
+// articleIDs returns the distinct articles of the chunks
+func articleIDs(chunks []*Chunk) []primitive.ObjectID {
+	var ids []primitive.ObjectID
+	for _, chunk := range chunks {
+		if !slices.Contains(ids, chunk.ArticleID) {
+			ids = append(ids, chunk.ArticleID)
+		}
+	}
+	return ids
+}
+
+// FlatIndex compares the query with every indexed chunk. Search is exact and
+// linear in the number of chunks, which is fast enough for tens of thousands.
+type FlatIndex struct {
+	mu         sync.RWMutex
+	dimensions int
+	articles   map[primitive.ObjectID][]indexedChunk
+	size       int
+}
+
+// NewFlatIndex creates an empty flat index
+func NewFlatIndex() *FlatIndex {
+	return &FlatIndex{articles: make(map[primitive.ObjectID][]indexedChunk)}
+}
+
+// Add implements Index
+func (x *FlatIndex) Add(chunks []*Chunk) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if err := checkDimensions(&x.dimensions, chunks); err != nil {
+		return err
+	}
+
+	for _, id := range articleIDs(chunks) {
+		x.size -= len(x.articles[id])
+		delete(x.articles, id)
+	}
+	for _, chunk := range chunks {
+		x.articles[chunk.ArticleID] = append(x.articles[chunk.ArticleID], newIndexedChunk(chunk))
+		x.size++
+	}
+	return nil
+}
+
+// Search implements Index
+func (x *FlatIndex) Search(vector []float32, filter *Filter, limit int) []Match {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if len(vector) != x.dimensions || limit <= 0 {
+		return nil
+	}
+	query := slices.Clone(vector)
+	Normalize(query)
+
+	var matches []Match
+	for _, chunks := range x.articles {
+		for _, indexed := range chunks {
+			if filter.Matches(indexed.chunk) {
+				matches = append(matches, Match{Chunk: indexed.chunk, Score: dot(query, indexed.vector)})
+			}
+		}
+	}
+
+	sortMatches(matches)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Len implements Index
+func (x *FlatIndex) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.size
+}
+
+// sortMatches orders matches by descending score, breaking ties by article
+// and chunk position so results are deterministic
+func sortMatches(matches []Match) {
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return matches[a].Score > matches[b].Score
+		}
+		if matches[a].Chunk.ArticleID != matches[b].Chunk.ArticleID {
+			return matches[a].Chunk.ArticleID.Hex() < matches[b].Chunk.ArticleID.Hex()
+		}
+		return matches[a].Chunk.Index < matches[b].Chunk.Index
+	})
+}
@@ -0,0 +1,123 @@
+package embedding
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// randomChunks returns chunks of distinct articles with random vectors
+func randomChunks(count, dimensions int, random *rand.Rand) []*Chunk {
+	chunks := make([]*Chunk, count)
+	for i := range chunks {
+		vector := make([]float32, dimensions)
+		for d := range vector {
+			vector[d] = float32(random.NormFloat64())
+		}
+		chunks[i] = &Chunk{ArticleID: primitive.NewObjectID(), Vector: vector}
+	}
+	return chunks
+}
+
+func TestHNSWIndexRecall(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	chunks := randomChunks(2000, 32, random)
+
+	flat, hnsw := NewFlatIndex(), NewHNSWIndex(HNSWConfig{})
+	for _, chunk := range chunks {
+		if err := flat.Add([]*Chunk{chunk}); err != nil {
+			t.Fatalf("FlatIndex.Add() error = %v", err)
+		}
+		if err := hnsw.Add([]*Chunk{chunk}); err != nil {
+			t.Fatalf("HNSWIndex.Add() error = %v", err)
+		}
+	}
+
+	const queries, k = 50, 10
+	found := 0
+	for _, query := range randomChunks(queries, 32, random) {
+		exact := make(map[*Chunk]bool)
+		for _, match := range flat.Search(query.Vector, nil, k) {
+			exact[match.Chunk] = true
+		}
+		for _, match := range hnsw.Search(query.Vector, nil, k) {
+			if exact[match.Chunk] {
+				found++
+			}
+		}
+	}
+
+	if recall := float64(found) / (queries * k); recall < 0.9 {
+		t.Errorf("Expected recall of at least 0.9, got %v", recall)
+	}
+}
+
+func TestHNSWIndexRebuild(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	chunks := randomChunks(200, 16, random)
+
+	index := NewHNSWIndex(HNSWConfig{})
+	for _, chunk := range chunks {
+		index.Add([]*Chunk{chunk})
+	}
+
+	// Re-indexing every article leaves no more than a quarter of the nodes deleted
+	for _, chunk := range chunks {
+		index.Add([]*Chunk{{ArticleID: chunk.ArticleID, Vector: chunk.Vector}})
+	}
+	if index.Len() != len(chunks) {
+		t.Fatalf("Expected %d chunks, got %d", len(chunks), index.Len())
+	}
+	if len(index.nodes) > len(chunks)*4/3+1 {
+		t.Errorf("Expected the graph rebuilt without its deleted nodes, got %d nodes", len(index.nodes))
+	}
+
+	for _, chunk := range chunks[:10] {
+		matches := index.Search(chunk.Vector, nil, 1)
+		if len(matches) != 1 || matches[0].Chunk.ArticleID != chunk.ArticleID {
+			t.Errorf("Expected the re-indexed chunk found, got %+v", matches)
+		}
+	}
+}
+
+func TestIndexReplaceAndFilter(t *testing.T) {
+	now := time.Now()
+	older := now.AddDate(0, 0, -30)
+	article := primitive.NewObjectID()
+
+	for name, index := range map[string]Index{"flat": NewFlatIndex(), "hnsw": NewHNSWIndex(HNSWConfig{})} {
+		t.Run(name, func(t *testing.T) {
+			index.Add([]*Chunk{
+				{ArticleID: article, Index: 0, Vector: []float32{1, 0}, Companies: []string{"Boeing"}, PublishedDate: now},
+				{ArticleID: article, Index: 1, Vector: []float32{0, 1}, Companies: []string{"Boeing"}, PublishedDate: now},
+			})
+			index.Add([]*Chunk{
+				{ArticleID: primitive.NewObjectID(), Vector: []float32{1, 0.1}, Companies: []string{"Airbus"}, PublishedDate: older},
+			})
+
+			// Re-indexing the article replaces both of its chunks
+			index.Add([]*Chunk{
+				{ArticleID: article, Index: 0, Vector: []float32{0.9, 0.1}, Companies: []string{"Boeing"}, PublishedDate: now},
+			})
+			if index.Len() != 2 {
+				t.Fatalf("Expected 2 chunks, got %d", index.Len())
+			}
+
+			matches := index.Search([]float32{1, 0}, &Filter{Companies: []string{"Boeing"}}, 5)
+			if len(matches) != 1 || matches[0].Chunk.ArticleID != article {
+				t.Fatalf("Expected the Boeing chunk only, got %+v", matches)
+			}
+
+			start := now.AddDate(0, 0, -7)
+			if matches := index.Search([]float32{1, 0}, &Filter{StartDate: &start}, 5); len(matches) != 1 {
+				t.Errorf("Expected the recent chunk only, got %+v", matches)
+			}
+
+			if err := index.Add([]*Chunk{{ArticleID: article, Vector: []float32{1, 0, 0}}}); err != ErrDimensionMismatch {
+				t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package embedding
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Chunk is a passage of an article with its embedding. The article's
+// companies and publish date are copied so that retrieval can filter
+// without loading the article.
+type Chunk struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ArticleID     primitive.ObjectID `json:"article_id" bson:"article_id"`
+	Index         int                `json:"index" bson:"index"` // Position of the chunk in the article
+	Text          string             `json:"text" bson:"text"`
+	Start         int                `json:"start" bson:"start"` // Character offsets of the passage in the article's body text
+	End           int                `json:"end" bson:"end"`
+	Tokens        int                `json:"tokens" bson:"tokens"` // Estimated tokens of the passage
+	Companies     []string           `json:"companies" bson:"companies"`
+	PublishedDate time.Time          `json:"published_date" bson:"published_date"`
+	// Model names the embedder; vectors of different models are not comparable
+	Model     string    `json:"model" bson:"model"`
+	Vector    []float32 `json:"-" bson:"vector"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// Filter restricts retrieval to chunks of articles mentioning any of the
+// companies and published within the date range
+type Filter struct {
+	Companies []string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// Matches reports whether the chunk satisfies the filter; a nil filter matches every chunk
+func (f *Filter) Matches(chunk *Chunk) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Companies) > 0 && !slices.ContainsFunc(f.Companies, func(company string) bool {
+		return slices.Contains(chunk.Companies, company)
+	}) {
+		return false
+	}
+	if f.StartDate != nil && chunk.PublishedDate.Before(*f.StartDate) {
+		return false
+	}
+	if f.EndDate != nil && chunk.PublishedDate.After(*f.EndDate) {
+		return false
+	}
+	return true
+}
+
+// Match is an indexed chunk and its cosine similarity to a query
+type Match struct {
+	Chunk *Chunk
+	Score float64
+}
+
+// ArticleMatch is an article retrieved by similarity, scored by its most
+// similar chunk
+type ArticleMatch struct {
+	ArticleID primitive.ObjectID
+	Score     float64
+	Chunk     *Chunk
+}
@@ -0,0 +1,19 @@
+package embedding
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository defines the interface for chunk embedding storage
+type Repository interface {
+	// ReplaceForArticle replaces the chunks stored for an article
+	ReplaceForArticle(ctx context.Context, articleID primitive.ObjectID, chunks []*Chunk) error
+
+	// ForEach calls fn with every chunk embedded by the model and stored at
+	// or after since (every chunk when since is zero), grouped by article.
+	// It stops at the first error.
+	ForEach(ctx context.Context, model string, since time.Time, fn func(*Chunk) error) error
+}
@@ -0,0 +1,242 @@
+package embedding
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// chunksPerArticle is how many chunks are retrieved per requested article,
+	// since the best chunks often come from the same articles
+	chunksPerArticle = 4
+
+	// loadOverlap is how far before the previous load a reload looks for
+	// stored chunks, covering chunks stored while that load was running
+	loadOverlap = time.Minute
+
+	// loadTimeout bounds a single periodic reload
+	loadTimeout = 5 * time.Minute
+)
+
+// Service embeds articles at ingest time and retrieves them by similarity
+type Service struct {
+	repo     Repository
+	embedder Embedder
+	index    Index
+	logger   *slog.Logger
+
+	// loading serializes loads
+	loading sync.Mutex
+	// mu guards the fields below, and keeps indexing an article and
+	// recording it together
+	mu sync.Mutex
+	// indexed holds when the indexed chunks of each article were stored
+	indexed map[primitive.ObjectID]time.Time
+	// loadedAt is when the last successful load started
+	loadedAt time.Time
+}
+
+// NewService creates a new embedding service.
+// embedder and index are optional; when nil, the hashing embedder and a
+// flat index are used.
+func NewService(repo Repository, embedder Embedder, index Index, logger *slog.Logger) *Service {
+	if embedder == nil {
+		embedder = NewHashingEmbedder(DefaultHashingDimensions)
+	}
+	if index == nil {
+		index = NewFlatIndex()
+	}
+
+	return &Service{
+		repo:     repo,
+		embedder: embedder,
+		index:    index,
+		logger:   logger,
+		indexed:  make(map[primitive.ObjectID]time.Time),
+	}
+}
+
+// IndexArticle splits the article into chunks, embeds them, and stores and
+// indexes them in place of its earlier chunks. It returns the number of chunks.
+func (s *Service) IndexArticle(ctx context.Context, article *news.Article) (int, error) {
+	passages := ArticleChunks(article)
+	texts := make([]string, len(passages))
+	for i, passage := range passages {
+		texts[i] = embeddingText(article, passage)
+	}
+
+	vectors, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		s.logger.Warn("Failed to embed article", "error", err, "article_id", article.ID.Hex())
+		return 0, err
+	}
+
+	now := time.Now()
+	chunks := make([]*Chunk, len(passages))
+	for i, passage := range passages {
+		chunks[i] = &Chunk{
+			ArticleID:     article.ID,
+			Index:         i,
+			Text:          passage.Text,
+			Start:         passage.Start,
+			End:           passage.End,
+			Tokens:        passage.Tokens,
+			Companies:     article.Companies,
+			PublishedDate: article.PublishedDate,
+			Model:         s.embedder.Model(),
+			Vector:        vectors[i],
+			CreatedAt:     now,
+		}
+	}
+
+	if err := s.repo.ReplaceForArticle(ctx, article.ID, chunks); err != nil {
+		s.logger.Error("Failed to store article chunks", "error", err, "article_id", article.ID.Hex())
+		return 0, err
+	}
+	s.mu.Lock()
+	err = s.index.Add(chunks)
+	if err == nil {
+		s.indexed[article.ID] = storedTime(now)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		s.logger.Error("Failed to index article chunks", "error", err, "article_id", article.ID.Hex())
+		return 0, err
+	}
+
+	return len(chunks), nil
+}
+
+// storedTime truncates a time to the millisecond precision it is stored with
+func storedTime(t time.Time) time.Time {
+	return t.Truncate(time.Millisecond)
+}
+
+// Load adds the stored chunks embedded by the current model to the index.
+// The first load reads every chunk; later loads read only the chunks stored
+// since the previous one, skipping articles whose indexed chunks are as
+// recent. It returns the number of chunks loaded.
+func (s *Service) Load(ctx context.Context) (int, error) {
+	s.loading.Lock()
+	defer s.loading.Unlock()
+
+	s.mu.Lock()
+	since := s.loadedAt
+	s.mu.Unlock()
+	if !since.IsZero() {
+		since = since.Add(-loadOverlap)
+	}
+	started := time.Now()
+
+	// Chunks arrive grouped by article and are added one article at a time,
+	// since adding replaces an article's chunks
+	var article []*Chunk
+	loaded := 0
+	flush := func() error {
+		if len(article) == 0 {
+			return nil
+		}
+		chunks := article
+		article = nil
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		storedAt := storedTime(chunks[0].CreatedAt)
+		if indexedAt, ok := s.indexed[chunks[0].ArticleID]; ok && !storedAt.After(indexedAt) {
+			return nil
+		}
+		if err := s.index.Add(chunks); err != nil {
+			return err
+		}
+		s.indexed[chunks[0].ArticleID] = storedAt
+		loaded += len(chunks)
+		return nil
+	}
+
+	err := s.repo.ForEach(ctx, s.embedder.Model(), since, func(chunk *Chunk) error {
+		if len(article) > 0 && article[0].ArticleID != chunk.ArticleID {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		article = append(article, chunk)
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		s.logger.Error("Failed to load article chunks", "error", err, "loaded", loaded)
+		return loaded, err
+	}
+
+	s.mu.Lock()
+	s.loadedAt = started
+	s.mu.Unlock()
+
+	s.logger.Info("Loaded article chunks into the vector index", "chunks", loaded, "model", s.embedder.Model())
+	return loaded, nil
+}
+
+// Watch loads newly stored chunks every interval until ctx is cancelled, so
+// articles embedded by other processes, such as the feed-processor command,
+// become searchable without a restart
+func (s *Service) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			loadCtx, cancel := context.WithTimeout(ctx, loadTimeout)
+			s.Load(loadCtx)
+			cancel()
+		}
+	}
+}
+
+// Search returns up to limit articles matching the filter whose chunks are
+// most similar to the text, best first
+func (s *Service) Search(ctx context.Context, text string, filter *Filter, limit int) ([]*ArticleMatch, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, ErrEmptyQuery
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	vectors, err := s.embedder.Embed(ctx, []string{text})
+	if err != nil {
+		s.logger.Warn("Failed to embed query", "error", err)
+		return nil, err
+	}
+
+	// Keep the best chunk of each article
+	var results []*ArticleMatch
+	seen := make(map[primitive.ObjectID]bool)
+	for _, match := range s.index.Search(vectors[0], filter, limit*chunksPerArticle) {
+		if seen[match.Chunk.ArticleID] {
+			continue
+		}
+		seen[match.Chunk.ArticleID] = true
+		results = append(results, &ArticleMatch{ArticleID: match.Chunk.ArticleID, Score: match.Score, Chunk: match.Chunk})
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// Len returns the number of indexed chunks
+func (s *Service) Len() int {
+	return s.index.Len()
+}
@@ -0,0 +1,107 @@
+package embedding
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryRepository stores chunks in memory
+type memoryRepository struct {
+	chunks map[primitive.ObjectID][]*Chunk
+}
+
+func (r *memoryRepository) ReplaceForArticle(ctx context.Context, articleID primitive.ObjectID, chunks []*Chunk) error {
+	r.chunks[articleID] = chunks
+	return nil
+}
+
+func (r *memoryRepository) ForEach(ctx context.Context, model string, since time.Time, fn func(*Chunk) error) error {
+	for _, chunks := range r.chunks {
+		for _, chunk := range chunks {
+			if chunk.Model != model || chunk.CreatedAt.Before(since) {
+				continue
+			}
+			if err := fn(chunk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestServiceIndexAndSearch(t *testing.T) {
+	repo := &memoryRepository{chunks: make(map[primitive.ObjectID][]*Chunk)}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	service := NewService(repo, nil, nil, logger)
+
+	articles := []*news.Article{
+		{
+			ID:            primitive.NewObjectID(),
+			Title:         "Army awards hypersonic missile contract",
+			Summary:       "Lockheed Martin will build hypersonic glide bodies.",
+			Companies:     []string{"Lockheed Martin"},
+			PublishedDate: time.Now(),
+		},
+		{
+			ID:            primitive.NewObjectID(),
+			Title:         "RTX reports quarterly earnings",
+			Summary:       "Revenue rose on strong commercial aerospace demand.",
+			Companies:     []string{"Raytheon Technologies"},
+			PublishedDate: time.Now(),
+		},
+	}
+	for _, article := range articles {
+		if _, err := service.IndexArticle(context.Background(), article); err != nil {
+			t.Fatalf("IndexArticle() error = %v", err)
+		}
+	}
+
+	matches, err := service.Search(context.Background(), "hypersonic missiles", nil, 2)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(matches) == 0 || matches[0].ArticleID != articles[0].ID {
+		t.Fatalf("Expected the hypersonic article first, got %+v", matches)
+	}
+
+	filtered, _ := service.Search(context.Background(), "hypersonic missiles", &Filter{Companies: []string{"Raytheon Technologies"}}, 2)
+	for _, match := range filtered {
+		if match.ArticleID != articles[1].ID {
+			t.Errorf("Expected only the RTX article, got %+v", match)
+		}
+	}
+
+	// A new process rebuilds its index from the stored chunks
+	reloaded := NewService(repo, nil, NewHNSWIndex(HNSWConfig{}), logger)
+	if loaded, err := reloaded.Load(context.Background()); err != nil || loaded != service.Len() {
+		t.Errorf("Expected %d chunks loaded, got %d (error %v)", service.Len(), loaded, err)
+	}
+
+	// A reload reads the chunks stored since, and indexes only newer ones
+	stored := &news.Article{
+		ID:            primitive.NewObjectID(),
+		Title:         "Navy orders more submarines",
+		Summary:       "General Dynamics will build two more boats.",
+		Companies:     []string{"General Dynamics"},
+		PublishedDate: time.Now(),
+	}
+	if _, err := service.IndexArticle(context.Background(), stored); err != nil {
+		t.Fatalf("IndexArticle() error = %v", err)
+	}
+	added := service.Len() - reloaded.Len()
+	if loaded, err := reloaded.Load(context.Background()); err != nil || loaded != added {
+		t.Errorf("Expected only the new article's chunks loaded, got %d (error %v)", loaded, err)
+	}
+	if loaded, _ := reloaded.Load(context.Background()); loaded != 0 {
+		t.Errorf("Expected nothing reloaded, got %d chunks", loaded)
+	}
+	if reloaded.Len() != service.Len() {
+		t.Errorf("Expected %d chunks indexed, got %d", service.Len(), reloaded.Len())
+	}
+}
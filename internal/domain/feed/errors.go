@@ -0,0 +1,10 @@
+package feed
+
+import "errors"
+
+// Domain errors for feed ingestion
+var (
+	ErrFetchStateNotFound = errors.New("feed fetch state not found")
+	ErrInvalidFeedURL     = errors.New("feed URL cannot be empty")
+	ErrInvalidRunFilter   = errors.New("invalid feed run filter")
+)
@@ -0,0 +1,218 @@
+package feed
+
+import (
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FetchState records the outcome of the most recent fetch of a single feed.
+// The stored validators (ETag / Last-Modified) drive conditional GET requests
+// so unchanged feeds can be skipped without downloading or parsing them.
+type FetchState struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	FeedURL       string             `json:"feed_url" bson:"feed_url"`
+	CompanyName   string             `json:"company_name" bson:"company_name"`
+	FeedLabel     string             `json:"feed_label" bson:"feed_label"`
+	ETag          string             `json:"etag,omitempty" bson:"etag,omitempty"`
+	LastModified  string             `json:"last_modified,omitempty" bson:"last_modified,omitempty"`
+	LastStatus    int                `json:"last_status" bson:"last_status"`
+	LastItemCount int                `json:"last_item_count" bson:"last_item_count"`
+	LastError     string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	LastFetchedAt time.Time          `json:"last_fetched_at" bson:"last_fetched_at"`
+	LastSuccessAt time.Time          `json:"last_success_at,omitempty" bson:"last_success_at,omitempty"`
+	// ConsecutiveFailures counts failed fetches since the last success
+	ConsecutiveFailures int `json:"consecutive_failures" bson:"consecutive_failures"`
+	// PublishInterval is the smoothed gap between item publication times
+	PublishInterval time.Duration `json:"publish_interval" bson:"publish_interval"`
+	// PollInterval is the interval chosen by the scheduler after the last fetch
+	PollInterval time.Duration `json:"poll_interval" bson:"poll_interval"`
+	// NextDueAt is when the scheduler fetches the feed again
+	NextDueAt time.Time `json:"next_due_at" bson:"next_due_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// Validate validates the FetchState fields
+func (s *FetchState) Validate() error {
+	if s.FeedURL == "" {
+		return ErrInvalidFeedURL
+	}
+	return nil
+}
+
+// RecordSuccess stores the validators and item count of a successful fetch.
+// A 304 response keeps the previous validators and item count.
+func (s *FetchState) RecordSuccess(status int, etag, lastModified string, itemCount int, at time.Time) {
+	s.LastStatus = status
+	s.LastError = ""
+	s.LastFetchedAt = at
+	s.LastSuccessAt = at
+	s.ConsecutiveFailures = 0
+
+	if etag != "" {
+		s.ETag = etag
+	}
+	if lastModified != "" {
+		s.LastModified = lastModified
+	}
+	if status != http.StatusNotModified {
+		s.LastItemCount = itemCount
+	}
+}
+
+// RecordFailure stores the status and error of a failed fetch
+func (s *FetchState) RecordFailure(status int, err error, at time.Time) {
+	s.LastStatus = status
+	s.LastFetchedAt = at
+	s.ConsecutiveFailures++
+	if err != nil {
+		s.LastError = err.Error()
+	}
+}
+
+// FeedResult summarises the processing of a single feed within a run
+type FeedResult struct {
+	CompanyName string        `json:"company_name"`
+	FeedLabel   string        `json:"feed_label"`
+	FeedURL     string        `json:"feed_url"`
+	StartedAt   time.Time     `json:"started_at"`
+	Duration    time.Duration `json:"duration"`
+	StatusCode  int           `json:"status_code"`
+	NotModified bool          `json:"not_modified"`
+	Items       int           `json:"items"`
+	New         int           `json:"new"`
+	Duplicates  int           `json:"duplicates"`
+	Merged      int           `json:"merged"` // Near-duplicates merged into existing articles
+	Errors      int           `json:"errors"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// Failed reports whether the feed itself could not be fetched or parsed
+func (r *FeedResult) Failed() bool {
+	return r.Error != ""
+}
+
+// RunReport summarises a feed processing run across one or more feeds
+type RunReport struct {
+	StartedAt       time.Time     `json:"started_at"`
+	FinishedAt      time.Time     `json:"finished_at"`
+	Duration        time.Duration `json:"duration"`
+	Feeds           []FeedResult  `json:"feeds"`
+	TotalItems      int           `json:"total_items"`
+	TotalNew        int           `json:"total_new"`
+	TotalDuplicates int           `json:"total_duplicates"`
+	TotalMerged     int           `json:"total_merged"`
+	TotalErrors     int           `json:"total_errors"`
+	FailedFeeds     int           `json:"failed_feeds"`
+}
+
+// NewRunReport builds a report from per-feed results and computes the totals
+func NewRunReport(startedAt, finishedAt time.Time, results []FeedResult) *RunReport {
+	report := &RunReport{
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		Duration:   finishedAt.Sub(startedAt),
+		Feeds:      results,
+	}
+
+	for _, result := range results {
+		report.TotalItems += result.Items
+		report.TotalNew += result.New
+		report.TotalDuplicates += result.Duplicates
+		report.TotalMerged += result.Merged
+		report.TotalErrors += result.Errors
+		if result.Failed() {
+			report.FailedFeeds++
+		}
+	}
+
+	return report
+}
+
+// Run is the persisted record of a single feed fetch
+type Run struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	StartedAt   time.Time          `json:"started_at" bson:"started_at"`
+	FinishedAt  time.Time          `json:"finished_at" bson:"finished_at"`
+	CompanyName string             `json:"company_name" bson:"company_name"`
+	FeedLabel   string             `json:"feed_label" bson:"feed_label"`
+	FeedURL     string             `json:"feed_url" bson:"feed_url"`
+	StatusCode  int                `json:"status_code" bson:"status_code"`
+	NotModified bool               `json:"not_modified" bson:"not_modified"`
+	ItemsParsed int                `json:"items_parsed" bson:"items_parsed"`
+	Inserted    int                `json:"inserted" bson:"inserted"`
+	Skipped     int                `json:"skipped" bson:"skipped"`
+	Merged      int                `json:"merged" bson:"merged"`
+	Errors      int                `json:"errors" bson:"errors"`
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// NewRun converts a feed result into a persisted run record
+func NewRun(result FeedResult) *Run {
+	return &Run{
+		StartedAt:   result.StartedAt,
+		FinishedAt:  result.StartedAt.Add(result.Duration),
+		CompanyName: result.CompanyName,
+		FeedLabel:   result.FeedLabel,
+		FeedURL:     result.FeedURL,
+		StatusCode:  result.StatusCode,
+		NotModified: result.NotModified,
+		ItemsParsed: result.Items,
+		Inserted:    result.New,
+		Skipped:     result.Duplicates,
+		Merged:      result.Merged,
+		Errors:      result.Errors,
+		Error:       result.Error,
+	}
+}
+
+// Succeeded reports whether the feed was fetched and parsed
+func (r *Run) Succeeded() bool {
+	return r.Error == ""
+}
+
+// RunFilter represents filters for feed run queries
+type RunFilter struct {
+	CompanyName string `json:"company_name,omitempty"`
+	FeedURL     string `json:"feed_url,omitempty"`
+	FailedOnly  bool   `json:"failed_only,omitempty"`
+	Limit       int    `json:"limit,omitempty"`
+}
+
+// Health summarises the health of a single feed
+type Health struct {
+	CompanyName         string    `json:"company_name"`
+	FeedLabel           string    `json:"feed_label"`
+	FeedURL             string    `json:"feed_url"`
+	Healthy             bool      `json:"healthy"`
+	LastStatus          int       `json:"last_status"`
+	LastFetchedAt       time.Time `json:"last_fetched_at"`
+	LastSuccessAt       time.Time `json:"last_success_at,omitempty"`
+	LastItemCount       int       `json:"last_item_count"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	PollInterval        string    `json:"poll_interval,omitempty"`
+	NextDueAt           time.Time `json:"next_due_at,omitempty"`
+}
+
+// NewHealth derives the health of a feed from its fetch state
+func NewHealth(state *FetchState) Health {
+	health := Health{
+		CompanyName:         state.CompanyName,
+		FeedLabel:           state.FeedLabel,
+		FeedURL:             state.FeedURL,
+		Healthy:             state.ConsecutiveFailures == 0,
+		LastStatus:          state.LastStatus,
+		LastFetchedAt:       state.LastFetchedAt,
+		LastSuccessAt:       state.LastSuccessAt,
+		LastItemCount:       state.LastItemCount,
+		ConsecutiveFailures: state.ConsecutiveFailures,
+		LastError:           state.LastError,
+		NextDueAt:           state.NextDueAt,
+	}
+	if state.PollInterval > 0 {
+		health.PollInterval = state.PollInterval.String()
+	}
+	return health
+}
@@ -0,0 +1,26 @@
+package feed
+
+import (
+	"context"
+)
+
+// StateRepository defines the interface for persisting per-feed fetch state
+type StateRepository interface {
+	// GetByURL retrieves the fetch state for a feed URL
+	GetByURL(ctx context.Context, feedURL string) (*FetchState, error)
+
+	// Upsert creates or replaces the fetch state for a feed URL
+	Upsert(ctx context.Context, state *FetchState) error
+
+	// List retrieves the fetch state of every known feed
+	List(ctx context.Context) ([]*FetchState, error)
+}
+
+// RunRepository defines the interface for persisting feed run history
+type RunRepository interface {
+	// Create saves a new feed run
+	Create(ctx context.Context, run *Run) error
+
+	// List retrieves the most recent feed runs matching the filter
+	List(ctx context.Context, filter *RunFilter) ([]*Run, error)
+}
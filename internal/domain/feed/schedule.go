@@ -0,0 +1,169 @@
+package feed
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultMinInterval is the shortest interval between two fetches of a feed
+	DefaultMinInterval = 15 * time.Minute
+	// DefaultMaxInterval is the longest interval between two fetches of a feed
+	DefaultMaxInterval = 12 * time.Hour
+	// DefaultInterval is used for feeds without enough history to adapt
+	DefaultInterval = 2 * time.Hour
+
+	// maxBackoffExponent caps the exponential backoff at 2^6 times the base interval
+	maxBackoffExponent = 6
+	// quietGrowthFactor lengthens the interval of feeds that published nothing new
+	quietGrowthFactor = 1.5
+	// quietCeilingFactor bounds quiet growth relative to the adaptive interval
+	quietCeilingFactor = 4
+	// publishRateSmoothing is the weight given to the latest publish gap estimate
+	publishRateSmoothing = 0.3
+)
+
+// SchedulePolicy decides when a feed is due to be fetched again
+type SchedulePolicy struct {
+	MinInterval     time.Duration
+	MaxInterval     time.Duration
+	DefaultInterval time.Duration
+}
+
+// DefaultSchedulePolicy returns the policy used when no configuration is given
+func DefaultSchedulePolicy() SchedulePolicy {
+	return SchedulePolicy{
+		MinInterval:     DefaultMinInterval,
+		MaxInterval:     DefaultMaxInterval,
+		DefaultInterval: DefaultInterval,
+	}
+}
+
+// WithDefaults replaces unset or inconsistent values with defaults
+func (p SchedulePolicy) WithDefaults() SchedulePolicy {
+	if p.MinInterval <= 0 {
+		p.MinInterval = DefaultMinInterval
+	}
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = DefaultMaxInterval
+	}
+	if p.MaxInterval < p.MinInterval {
+		p.MaxInterval = p.MinInterval
+	}
+	if p.DefaultInterval <= 0 {
+		p.DefaultInterval = DefaultInterval
+	}
+	p.DefaultInterval = p.clamp(p.DefaultInterval)
+	return p
+}
+
+// FetchOutcome describes a completed fetch for scheduling purposes
+type FetchOutcome struct {
+	// Failed is true when the feed could not be fetched or parsed
+	Failed bool
+	// NewItems is the number of articles not seen before
+	NewItems int
+	// PublishTimes are the publication times of the items in the feed
+	PublishTimes []time.Time
+	// FixedInterval is a per-feed poll interval that overrides adaptation
+	FixedInterval time.Duration
+}
+
+// Schedule computes the next fetch time of a feed after a fetch completed at
+// the given time, and stores it in the fetch state.
+//
+// Successful fetches use the feed's fixed interval when one is configured,
+// otherwise half the observed publish gap, lengthened while the feed stays
+// quiet. Failed fetches back off exponentially from that interval using
+// ConsecutiveFailures. The result is always clamped to the policy bounds.
+func (p SchedulePolicy) Schedule(state *FetchState, outcome FetchOutcome, at time.Time) {
+	if gap, ok := publishGap(outcome.PublishTimes); ok {
+		if state.PublishInterval <= 0 {
+			state.PublishInterval = gap
+		} else {
+			smoothed := publishRateSmoothing*float64(gap) + (1-publishRateSmoothing)*float64(state.PublishInterval)
+			state.PublishInterval = time.Duration(smoothed)
+		}
+	}
+
+	base := p.baseInterval(state, outcome)
+	interval := base
+	if outcome.Failed {
+		exponent := state.ConsecutiveFailures
+		if exponent > maxBackoffExponent {
+			exponent = maxBackoffExponent
+		}
+		interval = base * time.Duration(1<<exponent)
+	}
+
+	state.PollInterval = p.clamp(interval)
+	state.NextDueAt = at.Add(state.PollInterval)
+}
+
+// baseInterval returns the interval to use before any failure backoff
+func (p SchedulePolicy) baseInterval(state *FetchState, outcome FetchOutcome) time.Duration {
+	if outcome.FixedInterval > 0 {
+		return p.clamp(outcome.FixedInterval)
+	}
+
+	adaptive := p.adaptiveInterval(state)
+	if outcome.Failed || outcome.NewItems > 0 || state.PollInterval <= 0 {
+		return adaptive
+	}
+
+	// Quiet feeds are polled less often, up to a multiple of the adaptive interval
+	quiet := time.Duration(float64(state.PollInterval) * quietGrowthFactor)
+	if ceiling := adaptive * quietCeilingFactor; quiet > ceiling {
+		quiet = ceiling
+	}
+	return p.clamp(quiet)
+}
+
+// adaptiveInterval derives the interval from the feed's publish rate.
+// Fetching twice per publish gap keeps latency low without polling idle feeds.
+func (p SchedulePolicy) adaptiveInterval(state *FetchState) time.Duration {
+	if state.PublishInterval <= 0 {
+		return p.DefaultInterval
+	}
+	return p.clamp(state.PublishInterval / 2)
+}
+
+// clamp bounds an interval to the policy limits
+func (p SchedulePolicy) clamp(interval time.Duration) time.Duration {
+	if interval < p.MinInterval {
+		return p.MinInterval
+	}
+	if interval > p.MaxInterval {
+		return p.MaxInterval
+	}
+	return interval
+}
+
+// IsDue reports whether a feed should be fetched at the given time.
+// Feeds never scheduled before are always due.
+func (s *FetchState) IsDue(now time.Time) bool {
+	return s.NextDueAt.IsZero() || !now.Before(s.NextDueAt)
+}
+
+// publishGap returns the mean gap between consecutive publish times.
+// Zero times are ignored; at least two distinct times are required.
+func publishGap(times []time.Time) (time.Duration, bool) {
+	valid := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		if !t.IsZero() {
+			valid = append(valid, t)
+		}
+	}
+	if len(valid) < 2 {
+		return 0, false
+	}
+
+	sort.Slice(valid, func(i, j int) bool { return valid[i].Before(valid[j]) })
+
+	span := valid[len(valid)-1].Sub(valid[0])
+	if span <= 0 {
+		return 0, false
+	}
+
+	return span / time.Duration(len(valid)-1), true
+}
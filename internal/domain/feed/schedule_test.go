@@ -0,0 +1,131 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedulePolicyWithDefaults(t *testing.T) {
+	policy := SchedulePolicy{MinInterval: time.Hour, MaxInterval: time.Minute}.WithDefaults()
+
+	if policy.MaxInterval != time.Hour {
+		t.Errorf("Expected max interval raised to min, got %s", policy.MaxInterval)
+	}
+	if policy.DefaultInterval != time.Hour {
+		t.Errorf("Expected default interval clamped to 1h, got %s", policy.DefaultInterval)
+	}
+}
+
+func TestScheduleAdaptsToPublishRate(t *testing.T) {
+	policy := DefaultSchedulePolicy()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		gap      time.Duration
+		expected time.Duration
+	}{
+		{"frequent publisher clamped to min", 10 * time.Minute, DefaultMinInterval},
+		{"hourly publisher polled twice per gap", 2 * time.Hour, time.Hour},
+		{"rare publisher clamped to max", 7 * 24 * time.Hour, DefaultMaxInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &FetchState{FeedURL: "https://example.com/rss"}
+			outcome := FetchOutcome{
+				NewItems:     3,
+				PublishTimes: []time.Time{now, now.Add(-tt.gap), now.Add(-2 * tt.gap)},
+			}
+
+			policy.Schedule(state, outcome, now)
+
+			if state.PollInterval != tt.expected {
+				t.Errorf("Expected interval %s, got %s", tt.expected, state.PollInterval)
+			}
+			if !state.NextDueAt.Equal(now.Add(tt.expected)) {
+				t.Errorf("Expected next due %s, got %s", now.Add(tt.expected), state.NextDueAt)
+			}
+		})
+	}
+}
+
+func TestScheduleWithoutHistoryUsesDefault(t *testing.T) {
+	state := &FetchState{FeedURL: "https://example.com/rss"}
+	DefaultSchedulePolicy().Schedule(state, FetchOutcome{NewItems: 1}, time.Now())
+
+	if state.PollInterval != DefaultInterval {
+		t.Errorf("Expected default interval %s, got %s", DefaultInterval, state.PollInterval)
+	}
+}
+
+func TestScheduleQuietFeedGrows(t *testing.T) {
+	policy := DefaultSchedulePolicy()
+	state := &FetchState{
+		FeedURL:         "https://example.com/rss",
+		PublishInterval: 2 * time.Hour,
+		PollInterval:    time.Hour,
+	}
+
+	policy.Schedule(state, FetchOutcome{}, time.Now())
+	if state.PollInterval != 90*time.Minute {
+		t.Errorf("Expected quiet interval 1h30m, got %s", state.PollInterval)
+	}
+
+	for i := 0; i < 10; i++ {
+		policy.Schedule(state, FetchOutcome{}, time.Now())
+	}
+	if state.PollInterval != 4*time.Hour {
+		t.Errorf("Expected quiet growth capped at 4h, got %s", state.PollInterval)
+	}
+
+	policy.Schedule(state, FetchOutcome{NewItems: 1}, time.Now())
+	if state.PollInterval != time.Hour {
+		t.Errorf("Expected new items to restore adaptive interval 1h, got %s", state.PollInterval)
+	}
+}
+
+func TestScheduleBacksOffOnFailures(t *testing.T) {
+	policy := DefaultSchedulePolicy()
+	state := &FetchState{FeedURL: "https://example.com/rss", PublishInterval: 2 * time.Hour}
+	now := time.Now()
+
+	expected := []time.Duration{2 * time.Hour, 4 * time.Hour, 8 * time.Hour, DefaultMaxInterval, DefaultMaxInterval}
+	for i, want := range expected {
+		state.RecordFailure(503, nil, now)
+		policy.Schedule(state, FetchOutcome{Failed: true}, now)
+		if state.PollInterval != want {
+			t.Errorf("Failure %d: expected interval %s, got %s", i+1, want, state.PollInterval)
+		}
+	}
+
+	state.RecordSuccess(200, "", "", 1, now)
+	policy.Schedule(state, FetchOutcome{NewItems: 1}, now)
+	if state.PollInterval != time.Hour {
+		t.Errorf("Expected success to reset interval to 1h, got %s", state.PollInterval)
+	}
+}
+
+func TestScheduleFixedInterval(t *testing.T) {
+	policy := DefaultSchedulePolicy()
+	state := &FetchState{FeedURL: "https://example.com/rss", PublishInterval: 10 * time.Minute}
+
+	policy.Schedule(state, FetchOutcome{NewItems: 1, FixedInterval: 3 * time.Hour}, time.Now())
+	if state.PollInterval != 3*time.Hour {
+		t.Errorf("Expected fixed interval 3h, got %s", state.PollInterval)
+	}
+}
+
+func TestFetchStateIsDue(t *testing.T) {
+	now := time.Now()
+
+	if !(&FetchState{}).IsDue(now) {
+		t.Error("Expected unscheduled feed to be due")
+	}
+	if (&FetchState{NextDueAt: now.Add(time.Minute)}).IsDue(now) {
+		t.Error("Expected future feed not to be due")
+	}
+	if !(&FetchState{NextDueAt: now}).IsDue(now) {
+		t.Error("Expected feed due exactly now to be due")
+	}
+}
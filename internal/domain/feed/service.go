@@ -0,0 +1,73 @@
+package feed
+
+import (
+	"context"
+	"log/slog"
+)
+
+const (
+	defaultRunLimit = 50
+	maxRunLimit     = 500
+)
+
+// Service handles read access to feed ingestion history and health
+type Service struct {
+	runRepo   RunRepository
+	stateRepo StateRepository
+	logger    *slog.Logger
+}
+
+// NewService creates a new feed service
+func NewService(runRepo RunRepository, stateRepo StateRepository, logger *slog.Logger) *Service {
+	return &Service{
+		runRepo:   runRepo,
+		stateRepo: stateRepo,
+		logger:    logger,
+	}
+}
+
+// RecordRun persists the outcome of a single feed fetch
+func (s *Service) RecordRun(ctx context.Context, result FeedResult) error {
+	run := NewRun(result)
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		s.logger.Error("Failed to record feed run", "error", err, "url", result.FeedURL)
+		return err
+	}
+	return nil
+}
+
+// ListRecentRuns retrieves recent feed runs, newest first
+func (s *Service) ListRecentRuns(ctx context.Context, filter *RunFilter) ([]*Run, error) {
+	if filter == nil {
+		filter = &RunFilter{}
+	}
+
+	if filter.Limit < 0 || filter.Limit > maxRunLimit {
+		return nil, ErrInvalidRunFilter
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultRunLimit
+	}
+
+	runs, err := s.runRepo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list feed runs", "error", err)
+		return nil, err
+	}
+	return runs, nil
+}
+
+// GetHealth returns the health of every feed that has been fetched at least once
+func (s *Service) GetHealth(ctx context.Context) ([]Health, error) {
+	states, err := s.stateRepo.List(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list feed states", "error", err)
+		return nil, err
+	}
+
+	health := make([]Health, 0, len(states))
+	for _, state := range states {
+		health = append(health, NewHealth(state))
+	}
+	return health, nil
+}
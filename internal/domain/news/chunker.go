@@ -0,0 +1,242 @@
+package news
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultChunkTokens is the token size of an article chunk
+	DefaultChunkTokens = 256
+	// DefaultChunkOverlapTokens is the number of tokens of trailing sentences
+	// repeated from the previous chunk, so that passages cut at a boundary
+	// are found in full
+	DefaultChunkOverlapTokens = 48
+)
+
+// ContentChunk is a passage of an article's body text made of whole
+// sentences. Start and End are character offsets into the body text.
+type ContentChunk struct {
+	Index  int    `json:"index"`
+	Text   string `json:"text"`
+	Start  int    `json:"start"`
+	End    int    `json:"end"`
+	Tokens int    `json:"tokens"`
+}
+
+// EstimateTokens approximates the number of model tokens in text. English
+// text averages about four characters per token with OpenAI tokenizers.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// spanTokens estimates the tokens of the runes between start and end, like EstimateTokens
+func spanTokens(start, end int) int {
+	return (end - start + 3) / 4
+}
+
+// sentenceAbbreviations end with a period without ending a sentence
+var sentenceAbbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true, "st": true,
+	"gen": true, "lt": true, "col": true, "maj": true, "capt": true, "cmdr": true, "adm": true, "sgt": true,
+	"rep": true, "sen": true, "gov": true, "sec": true, "pres": true,
+	"inc": true, "corp": true, "co": true, "ltd": true, "llc": true, "no": true, "vs": true, "etc": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true,
+	"sep": true, "sept": true, "oct": true, "nov": true, "dec": true,
+}
+
+// span is a range of rune offsets
+type span struct {
+	start, end int
+}
+
+// sentenceSpans returns the spans of the sentences in text, without
+// surrounding whitespace. Sentences end at terminal punctuation followed by
+// whitespace and a capital, digit or quote, and at line breaks.
+func sentenceSpans(text []rune) []span {
+	var sentences []span
+	start := -1
+	emit := func(end int) {
+		if start < 0 {
+			return
+		}
+		for end > start && unicode.IsSpace(text[end-1]) {
+			end--
+		}
+		if end > start {
+			sentences = append(sentences, span{start, end})
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if start < 0 {
+			if !unicode.IsSpace(r) {
+				start = i
+			}
+			continue
+		}
+		if r == '\n' {
+			emit(i)
+			continue
+		}
+		if (r == '.' || r == '!' || r == '?') && sentenceEnds(text, start, i) {
+			end := i + 1
+			// Keep closing quotes and brackets with the sentence
+			for end < len(text) && strings.ContainsRune(`"')]”’`, text[end]) {
+				end++
+			}
+			emit(end)
+		}
+	}
+	emit(len(text))
+	return sentences
+}
+
+// sentenceEnds reports whether the punctuation at i ends the sentence started at start
+func sentenceEnds(text []rune, start, i int) bool {
+	next := i + 1
+	for next < len(text) && strings.ContainsRune(`"')]”’`, text[next]) {
+		next++
+	}
+	if next == len(text) {
+		return true
+	}
+	if !unicode.IsSpace(text[next]) {
+		return false
+	}
+	for next < len(text) && unicode.IsSpace(text[next]) {
+		if text[next] == '\n' {
+			return true
+		}
+		next++
+	}
+	if next == len(text) {
+		return true
+	}
+	if r := text[next]; !unicode.IsUpper(r) && !unicode.IsDigit(r) && !strings.ContainsRune(`"'(“‘`, r) {
+		return false
+	}
+
+	if text[i] != '.' {
+		return true
+	}
+	// A period after an abbreviation or an initial, as in "U.S." or "Gen.",
+	// does not end the sentence
+	wordStart := i
+	for wordStart > start && !unicode.IsSpace(text[wordStart-1]) {
+		wordStart--
+	}
+	word := strings.ToLower(strings.Trim(string(text[wordStart:i]), `"'(“‘`))
+	if sentenceAbbreviations[word] || utf8.RuneCountInString(word) == 1 {
+		return false
+	}
+	if strings.Contains(word, ".") && !strings.ContainsFunc(word, unicode.IsDigit) {
+		return false
+	}
+	return true
+}
+
+// splitLongSpan splits a span of more than maxTokens into windows of whole words
+func splitLongSpan(text []rune, s span, maxTokens int) []span {
+	if spanTokens(s.start, s.end) <= maxTokens {
+		return []span{s}
+	}
+
+	var parts []span
+	partStart := -1
+	lastEnd := s.start
+	for i := s.start; i <= s.end; i++ {
+		if i < s.end && !unicode.IsSpace(text[i]) {
+			if partStart < 0 {
+				partStart = i
+			}
+			continue
+		}
+		if partStart < 0 {
+			continue
+		}
+		// i ends a word; close the part before it when the word does not fit
+		if spanTokens(partStart, i) > maxTokens && lastEnd > partStart {
+			parts = append(parts, span{partStart, lastEnd})
+			partStart = lastEnd
+			for unicode.IsSpace(text[partStart]) {
+				partStart++
+			}
+		}
+		lastEnd = i
+	}
+	if partStart >= 0 && lastEnd > partStart {
+		parts = append(parts, span{partStart, lastEnd})
+	}
+	return parts
+}
+
+// ChunkText splits text into chunks of whole sentences of at most maxTokens
+// each. Every chunk but the first starts with the trailing sentences of the
+// previous chunk that fit in overlapTokens. Sentences longer than maxTokens
+// are split between words.
+func ChunkText(text string, maxTokens, overlapTokens int) []ContentChunk {
+	if maxTokens <= 0 {
+		return nil
+	}
+	if overlapTokens < 0 || overlapTokens >= maxTokens {
+		overlapTokens = 0
+	}
+
+	runes := []rune(text)
+	var sentences []span
+	for _, sentence := range sentenceSpans(runes) {
+		sentences = append(sentences, splitLongSpan(runes, sentence, maxTokens)...)
+	}
+
+	var chunks []ContentChunk
+	for first := 0; first < len(sentences); {
+		last := first
+		for last+1 < len(sentences) && spanTokens(sentences[first].start, sentences[last+1].end) <= maxTokens {
+			last++
+		}
+
+		start, end := sentences[first].start, sentences[last].end
+		chunkText := string(runes[start:end])
+		chunks = append(chunks, ContentChunk{
+			Index:  len(chunks),
+			Text:   chunkText,
+			Start:  start,
+			End:    end,
+			Tokens: spanTokens(start, end),
+		})
+		if last == len(sentences)-1 {
+			break
+		}
+
+		// Step back over the sentences that fit in the overlap, always moving forward
+		next := last + 1
+		for next-1 > first && spanTokens(sentences[next-1].start, end) <= overlapTokens {
+			next--
+		}
+		first = next
+	}
+	return chunks
+}
+
+// TruncateTokens returns the leading whole sentences of text that fit in
+// maxTokens, or text itself when it fits
+func TruncateTokens(text string, maxTokens int) string {
+	if EstimateTokens(text) <= maxTokens {
+		return text
+	}
+
+	chunks := ChunkText(text, maxTokens, 0)
+	if len(chunks) == 0 {
+		return ""
+	}
+	return chunks[0].Text
+}
+
+// ArticleContentChunks splits the article's body text into chunks of
+// DefaultChunkTokens with DefaultChunkOverlapTokens of overlap
+func ArticleContentChunks(article *Article) []ContentChunk {
+	return ChunkText(article.BodyText(), DefaultChunkTokens, DefaultChunkOverlapTokens)
+}
@@ -0,0 +1,100 @@
+package news
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitSentences(t *testing.T) {
+	text := `Gen. Smith said the U.S. Army awarded Lockheed Martin Corp. a $1.5 billion contract. "It is a milestone," he said! Deliveries start in 2026.
+Next paragraph without a period`
+
+	want := []string{
+		"Gen. Smith said the U.S. Army awarded Lockheed Martin Corp. a $1.5 billion contract.",
+		`"It is a milestone," he said!`,
+		"Deliveries start in 2026.",
+		"Next paragraph without a period",
+	}
+
+	got := splitSentences(text)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d sentences, got %q", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Sentence %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChunkText(t *testing.T) {
+	var sentences []string
+	for i := 0; i < 40; i++ {
+		sentences = append(sentences, "The Navy ordered more radar systems for its destroyers this year.")
+	}
+	text := strings.Join(sentences, " ")
+
+	chunks := ChunkText(text, 64, 20)
+	if len(chunks) < 3 {
+		t.Fatalf("Expected several chunks, got %d", len(chunks))
+	}
+
+	runes := []rune(text)
+	for i, chunk := range chunks {
+		if chunk.Index != i {
+			t.Errorf("Chunk %d has index %d", i, chunk.Index)
+		}
+		if chunk.Tokens > 64 {
+			t.Errorf("Chunk %d has %d tokens, over the limit", i, chunk.Tokens)
+		}
+		if string(runes[chunk.Start:chunk.End]) != chunk.Text {
+			t.Errorf("Chunk %d offsets do not match its text", i)
+		}
+		if !strings.HasPrefix(chunk.Text, "The Navy") || !strings.HasSuffix(chunk.Text, "year.") {
+			t.Errorf("Chunk %d does not hold whole sentences: %q", i, chunk.Text)
+		}
+		if i > 0 && chunk.Start >= chunks[i-1].End {
+			t.Errorf("Chunk %d does not overlap the previous chunk", i)
+		}
+	}
+	if chunks[len(chunks)-1].End != len(runes) {
+		t.Error("Expected the last chunk to reach the end of the text")
+	}
+}
+
+func TestChunkTextSplitsLongSentences(t *testing.T) {
+	text := strings.Repeat("hypersonic ", 100)
+
+	chunks := ChunkText(text, 20, 0)
+	if len(chunks) < 5 {
+		t.Fatalf("Expected the sentence split between words, got %d chunks", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if chunk.Tokens > 20 || strings.HasPrefix(chunk.Text, " ") || strings.HasSuffix(chunk.Text, " ") {
+			t.Errorf("Unexpected chunk %q with %d tokens", chunk.Text, chunk.Tokens)
+		}
+	}
+
+	if ChunkText("", 20, 0) != nil {
+		t.Error("Expected no chunks for empty text")
+	}
+}
+
+func TestTruncateTokens(t *testing.T) {
+	text := "First sentence is here. Second sentence is here. Third sentence is here."
+
+	if got := TruncateTokens(text, 100); got != text {
+		t.Errorf("Expected text that fits unchanged, got %q", got)
+	}
+	got := TruncateTokens(text, 12)
+	if got != "First sentence is here. Second sentence is here." {
+		t.Errorf("Expected the leading whole sentences, got %q", got)
+	}
+	if EstimateTokens(got) > 12 {
+		t.Errorf("Truncated text has %d tokens", EstimateTokens(got))
+	}
+	if utf8.RuneCountInString(got) >= utf8.RuneCountInString(text) {
+		t.Error("Expected the text truncated")
+	}
+}
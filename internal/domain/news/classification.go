@@ -0,0 +1,204 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Category is a topic of the defense and aerospace taxonomy
+type Category string
+
+const (
+	CategoryContracts  Category = "contracts"
+	CategoryEarnings   Category = "earnings"
+	CategoryMergers    Category = "mergers_acquisitions"
+	CategoryPrograms   Category = "programs"
+	CategoryLeadership Category = "leadership"
+	CategoryRegulation Category = "export_regulation"
+	CategoryIncidents  Category = "incidents"
+)
+
+// Categories lists every category of the taxonomy
+var Categories = []Category{
+	CategoryContracts,
+	CategoryEarnings,
+	CategoryMergers,
+	CategoryPrograms,
+	CategoryLeadership,
+	CategoryRegulation,
+	CategoryIncidents,
+}
+
+// IsValid reports whether the category is part of the taxonomy
+func (c Category) IsValid() bool {
+	for _, category := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCategory converts a string into a category of the taxonomy
+func ParseCategory(value string) (Category, error) {
+	category := Category(strings.ToLower(strings.TrimSpace(value)))
+	if !category.IsValid() {
+		return "", fmt.Errorf("%w: unknown category %q", ErrInvalidFilter, value)
+	}
+	return category, nil
+}
+
+// Classifier assigns taxonomy categories to an article
+type Classifier interface {
+	Classify(ctx context.Context, article *Article) ([]Category, error)
+}
+
+const (
+	// titleRuleWeight is the weight of a keyword found in the title
+	titleRuleWeight = 2
+	// bodyRuleWeight is the weight of each distinct keyword found in the body
+	bodyRuleWeight = 1
+	// ruleThreshold is the weight a category needs to be assigned
+	ruleThreshold = 2
+)
+
+// CategoryKeywords are the keywords and phrases of each category. Phrases
+// match whole words case-insensitively, ignoring punctuation ("F-35" matches "f 35").
+var CategoryKeywords = map[Category][]string{
+	CategoryContracts: {
+		"contract", "contracts", "contract award", "task order", "delivery order",
+		"idiq", "contract modification", "solicitation", "firm fixed price",
+		"cost plus", "sole source", "low rate initial production", "lrip",
+	},
+	CategoryEarnings: {
+		"earnings", "quarterly results", "first quarter", "second quarter", "third quarter",
+		"fourth quarter", "q1", "q2", "q3", "q4", "eps", "per share", "free cash flow",
+		"net income", "operating margin", "financial results", "raises guidance",
+		"revenue guidance", "quarterly dividend", "record backlog",
+	},
+	CategoryMergers: {
+		"acquisition of", "merger", "divest", "divests", "divestiture", "takeover", "buyout",
+		"joint venture", "spin off", "spinoff", "definitive agreement", "to acquire",
+		"minority stake", "majority stake", "equity stake",
+	},
+	CategoryPrograms: {
+		"f 35", "f 47", "f 22", "f 16", "b 21", "kc 46", "c 130", "p 8", "e 7",
+		"patriot missile", "patriot air and missile defense", "pac 3", "sm 6", "sm 3",
+		"standard missile", "javelin", "stinger missile", "tomahawk", "amraam", "aim 9x",
+		"ltamds", "thaad", "sentinel icbm", "lgm 35a", "ngad", "collaborative combat aircraft",
+		"golden dome", "himars", "gmlrs", "m1 abrams", "abrams tank", "stryker vehicle", "black hawk", "chinook",
+		"columbia class", "virginia class", "gtf", "f135", "program of record",
+		"milestone b", "milestone c",
+	},
+	CategoryLeadership: {
+		"ceo", "chief executive", "chief executive officer", "cfo", "chief financial officer",
+		"chief operating officer", "appoints", "appointed", "steps down", "step down",
+		"resigns", "resigned", "retires", "board of directors", "sworn in", "leadership change",
+	},
+	CategoryRegulation: {
+		"export control", "export controls", "export license", "export licence", "itar",
+		"foreign military sale", "foreign military sales", "fms", "dsca", "faa", "tariff",
+		"tariffs", "cfius", "ndaa", "congressional notification", "rulemaking",
+		"economic sanctions", "sanctions on",
+	},
+	CategoryIncidents: {
+		"crashed", "mishap", "explosion", "grounded", "grounding", "malfunction", "fatal",
+		"emergency landing", "cyberattack", "data breach", "failed test", "engine fire",
+		"caught fire", "plane crash", "helicopter crash", "killed in",
+	},
+}
+
+// CategoryCueWords are generic words that only support a category, such as
+// "sales" or "fire". Each counts as a body keyword even in the title, so a
+// cue word alone never assigns a category.
+var CategoryCueWords = map[Category][]string{
+	CategoryContracts:  {"award", "awards", "awarded", "procurement", "multiyear"},
+	CategoryEarnings:   {"revenue", "revenues", "sales", "guidance", "outlook", "fiscal year", "backlog", "dividend"},
+	CategoryMergers:    {"acquire", "acquires", "acquired", "merge", "merges", "stake"},
+	CategoryPrograms:   {"patriot", "sentinel", "stinger", "stryker", "abrams"},
+	CategoryLeadership: {"appointment", "successor", "succeeds", "retirement", "nominated", "nominee"},
+	CategoryRegulation: {
+		"export", "exports", "sanction", "sanctions", "regulation", "regulations", "regulatory",
+		"regulator", "compliance", "license", "licence",
+	},
+	CategoryIncidents: {"crash", "fire", "accident", "incident", "investigation", "recall", "injured", "killed", "outage"},
+}
+
+// RuleClassifier classifies articles offline with keyword rules. A category
+// is assigned when a keyword appears in the title, or when two distinct
+// keywords or cue words appear in the title and body.
+type RuleClassifier struct {
+	keywords map[Category][]string
+	cues     map[Category][]string
+}
+
+// NewRuleClassifier creates a rule-based classifier using CategoryKeywords
+// and CategoryCueWords
+func NewRuleClassifier() *RuleClassifier {
+	return &RuleClassifier{
+		keywords: normalizePhrases(CategoryKeywords),
+		cues:     normalizePhrases(CategoryCueWords),
+	}
+}
+
+// normalizePhrases normalises the phrases of each category for matching
+func normalizePhrases(phrases map[Category][]string) map[Category][]string {
+	normalized := make(map[Category][]string, len(phrases))
+	for category, list := range phrases {
+		for _, phrase := range list {
+			normalized[category] = append(normalized[category], normalizePhrase(phrase))
+		}
+	}
+	return normalized
+}
+
+// Classify implements Classifier; it never fails
+func (c *RuleClassifier) Classify(ctx context.Context, article *Article) ([]Category, error) {
+	return c.ClassifyText(article.Title, article.BodyText()), nil
+}
+
+// ClassifyText returns the categories of a title and body in taxonomy order
+func (c *RuleClassifier) ClassifyText(title, body string) []Category {
+	title = normalizePhrase(title)
+	body = normalizePhrase(body)
+
+	var categories []Category
+	for _, category := range Categories {
+		weight := 0
+		for _, phrase := range c.keywords[category] {
+			switch {
+			case strings.Contains(title, phrase):
+				weight += titleRuleWeight
+			case strings.Contains(body, phrase):
+				weight += bodyRuleWeight
+			}
+		}
+		for _, phrase := range c.cues[category] {
+			if strings.Contains(title, phrase) || strings.Contains(body, phrase) {
+				weight += bodyRuleWeight
+			}
+		}
+		if weight >= ruleThreshold {
+			categories = append(categories, category)
+		}
+	}
+
+	return categories
+}
+
+// normalizePhrase lower-cases text and joins its words with single spaces,
+// padded so that phrases can be matched on word boundaries
+func normalizePhrase(text string) string {
+	return " " + strings.Join(tokenize(strings.ToLower(text)), " ") + " "
+}
+
+// HasCategory reports whether the article is classified in the category
+func (a *Article) HasCategory(category Category) bool {
+	for _, c := range a.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package news
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRuleClassifierClassifyText(t *testing.T) {
+	classifier := NewRuleClassifier()
+
+	tests := []struct {
+		name     string
+		title    string
+		body     string
+		expected []Category
+	}{
+		{
+			name:     "contract award for a program",
+			title:    "Lockheed Martin awarded $1.2B contract for F-35 sustainment",
+			expected: []Category{CategoryContracts, CategoryPrograms},
+		},
+		{
+			name:     "quarterly earnings",
+			title:    "RTX reports second quarter results",
+			body:     "Sales rose 9% and the company raised its full-year guidance.",
+			expected: []Category{CategoryEarnings},
+		},
+		{
+			name:     "leadership change",
+			title:    "Boeing CEO to step down at year end",
+			expected: []Category{CategoryLeadership},
+		},
+		{
+			name:     "export regulation in body only",
+			title:    "State Department approves sale to Poland",
+			body:     "The DSCA delivered the required congressional notification for the Foreign Military Sale.",
+			expected: []Category{CategoryRegulation},
+		},
+		{
+			name:     "cue words together assign a category",
+			title:    "Lockheed sales rise as guidance climbs",
+			expected: []Category{CategoryEarnings},
+		},
+		{
+			name:     "single body keyword is not enough",
+			title:    "Industry roundup",
+			body:     "A fire drill was held at the plant.",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifier.ClassifyText(tt.title, tt.body)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRuleClassifierMatchesWholeWords(t *testing.T) {
+	classifier := NewRuleClassifier()
+
+	// "firestone" and "feeds" must not match "fire" and "eps"
+	if got := classifier.ClassifyText("Firestone feeds update", ""); len(got) != 0 {
+		t.Errorf("Expected no categories, got %v", got)
+	}
+}
+
+func TestRuleClassifierIgnoresLoneCueWords(t *testing.T) {
+	classifier := NewRuleClassifier()
+
+	titles := []string{
+		"Boeing engineer wins industry award",
+		"Fire drill held at the Fort Worth plant",
+		"Northrop opens a new sales office in Denver",
+		"Pension fund takes a stake in a startup",
+		"Driver license renewals move online",
+		"Stacey Abrams speaks in Atlanta",
+	}
+	for _, title := range titles {
+		if got := classifier.ClassifyText(title, ""); len(got) != 0 {
+			t.Errorf("Expected no categories for %q, got %v", title, got)
+		}
+	}
+}
+
+func TestRuleClassifierClassifyArticle(t *testing.T) {
+	article := &Article{
+		Title:   "Northrop Grumman to acquire satellite maker",
+		Summary: "The definitive agreement is expected to close next year.",
+	}
+
+	categories, err := NewRuleClassifier().Classify(context.Background(), article)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	article.Categories = categories
+
+	if !article.HasCategory(CategoryMergers) {
+		t.Errorf("Expected mergers category, got %v", categories)
+	}
+	if article.HasCategory(CategoryIncidents) {
+		t.Errorf("Did not expect incidents category, got %v", categories)
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	category, err := ParseCategory(" Contracts ")
+	if err != nil || category != CategoryContracts {
+		t.Errorf("Expected contracts, got %q (%v)", category, err)
+	}
+
+	if _, err := ParseCategory("sports"); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter, got %v", err)
+	}
+}
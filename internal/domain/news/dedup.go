@@ -0,0 +1,283 @@
+package news
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// NearDuplicateDistance is the largest SimHash Hamming distance between
+	// two articles considered the same story. Feed items are short, so the
+	// threshold is looser than the 3 bits commonly used for whole web pages.
+	NearDuplicateDistance = 6
+	// FingerprintBandCount splits a fingerprint into bands for candidate lookup.
+	// With at most NearDuplicateDistance differing bits, at least one of
+	// NearDuplicateDistance+1 bands is identical.
+	FingerprintBandCount = NearDuplicateDistance + 1
+	// DuplicateWindow bounds how far apart in publish time duplicates can be
+	DuplicateWindow = 14 * 24 * time.Hour
+
+	// minFingerprintTokens is the minimum number of words for a meaningful fingerprint
+	minFingerprintTokens = 8
+	// maxDuplicateCandidates bounds the stored articles compared with a new one
+	maxDuplicateCandidates = 100
+)
+
+// trackingParams are query parameters that identify campaigns, not content
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true,
+	"mc_cid": true, "mc_eid": true, "_ga": true, "_gl": true, "_hsenc": true, "_hsmi": true,
+	"igshid": true, "ref": true, "ref_src": true, "cmpid": true, "ncid": true,
+	"sr_share": true, "spm": true, "mkt_tok": true,
+}
+
+// trackingParamPrefixes are prefixes of campaign tracking parameters
+var trackingParamPrefixes = []string{"utm_", "at_", "pk_", "hmb_"}
+
+// contractNumberPattern matches DoD contract numbers such as FA8625-21-C-6001
+var contractNumberPattern = regexp.MustCompile(`\b[A-Z0-9]{6}-?\d{2}-?[A-Z]-?\d{4}\b`)
+
+// dollarAmountPattern matches dollar amounts such as $1.2 billion or $45,000,000
+var dollarAmountPattern = regexp.MustCompile(`(?i)\$\s?\d[\d,]*(?:\.\d+)?(?:\s?(?:thousand|million|billion|trillion)\b)?`)
+
+// ArticleSource is one feed item that reported an article
+type ArticleSource struct {
+	CompanyName   string    `json:"company_name" bson:"company_name"`
+	FeedSource    string    `json:"feed_source" bson:"feed_source"`
+	SourceURL     string    `json:"source_url" bson:"source_url"`
+	GUID          string    `json:"guid" bson:"guid"`
+	PublishedDate time.Time `json:"published_date" bson:"published_date"`
+}
+
+// DuplicateQuery describes the stored articles that may duplicate a new one
+type DuplicateQuery struct {
+	CanonicalURL     string
+	FingerprintBands []string
+	From             time.Time
+	To               time.Time
+	Limit            int
+}
+
+// CanonicalizeURL normalises a URL so that links to the same page compare
+// equal: the scheme becomes https, the host is lower-cased without "www."
+// or default ports, tracking parameters and fragments are removed,
+// remaining parameters are sorted and trailing slashes dropped.
+func CanonicalizeURL(rawURL string) string {
+	trimmed := strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return trimmed
+	}
+
+	if parsed.Scheme == "http" || parsed.Scheme == "https" {
+		parsed.Scheme = "https"
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+	parsed.Host = host
+	parsed.User = nil
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+
+	query := parsed.Query()
+	for key := range query {
+		if isTrackingParam(key) {
+			query.Del(key)
+		}
+	}
+	// Encode sorts parameters by key
+	parsed.RawQuery = query.Encode()
+
+	if len(parsed.Path) > 1 {
+		parsed.Path = strings.TrimRight(parsed.Path, "/")
+		parsed.RawPath = ""
+	}
+	if parsed.Path == "/" {
+		parsed.Path = ""
+	}
+
+	return parsed.String()
+}
+
+// isTrackingParam reports whether a query parameter only tracks campaigns
+func isTrackingParam(key string) bool {
+	lower := strings.ToLower(key)
+	if trackingParams[lower] {
+		return true
+	}
+	for _, prefix := range trackingParamPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fingerprint computes a 64-bit SimHash over the words of the text.
+// Near-identical texts produce fingerprints with a small Hamming distance.
+// It returns false when the text is too short to fingerprint reliably.
+func Fingerprint(text string) (uint64, bool) {
+	tokens := tokenize(strings.ToLower(text))
+	if len(tokens) < minFingerprintTokens {
+		return 0, false
+	}
+
+	var weights [64]int
+	for _, token := range tokens {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(token))
+		hash := hasher.Sum64()
+
+		for bit := 0; bit < 64; bit++ {
+			if hash&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			fingerprint |= 1 << uint(bit)
+		}
+	}
+
+	return fingerprint, true
+}
+
+// HammingDistance counts the differing bits of two fingerprints
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FingerprintBands splits a fingerprint into labelled bands used to find
+// candidate near-duplicates with an exact index lookup
+func FingerprintBands(fingerprint uint64) []string {
+	bandBits := 64 / FingerprintBandCount
+	mask := uint64(1)<<uint(bandBits) - 1
+
+	bands := make([]string, 0, FingerprintBandCount)
+	for i := 0; i < FingerprintBandCount; i++ {
+		band := (fingerprint >> uint(i*bandBits)) & mask
+		bands = append(bands, fmt.Sprintf("%d:%x", i, band))
+	}
+	return bands
+}
+
+// PrepareDeduplication sets the canonical URL, fingerprint and source list
+// of a new article
+func (a *Article) PrepareDeduplication() {
+	a.CanonicalURL = CanonicalizeURL(a.SourceURL)
+
+	if fingerprint, ok := Fingerprint(a.Title + " " + a.BodyText()); ok {
+		a.Fingerprint = int64(fingerprint)
+		a.FingerprintBands = FingerprintBands(fingerprint)
+	}
+
+	if len(a.Sources) == 0 {
+		for _, company := range a.Companies {
+			a.Sources = append(a.Sources, a.source(company))
+		}
+	}
+}
+
+// DuplicateQuery returns the query for stored articles that may duplicate this one
+func (a *Article) DuplicateQuery() *DuplicateQuery {
+	return &DuplicateQuery{
+		CanonicalURL:     a.CanonicalURL,
+		FingerprintBands: a.FingerprintBands,
+		From:             a.PublishedDate.Add(-DuplicateWindow),
+		To:               a.PublishedDate.Add(DuplicateWindow),
+		Limit:            maxDuplicateCandidates,
+	}
+}
+
+// IsDuplicateOf reports whether other is the same story: the same canonical
+// URL, or a fingerprint within NearDuplicateDistance and matching
+// identifiers. Templated announcements such as contract awards differ in
+// only a few words, so a near fingerprint alone does not make a duplicate.
+func (a *Article) IsDuplicateOf(other *Article) bool {
+	if a.CanonicalURL != "" && a.CanonicalURL == other.CanonicalURL {
+		return true
+	}
+	if len(a.FingerprintBands) == 0 || len(other.FingerprintBands) == 0 {
+		return false
+	}
+	if HammingDistance(uint64(a.Fingerprint), uint64(other.Fingerprint)) > NearDuplicateDistance {
+		return false
+	}
+	return sameIdentifiers(a, other)
+}
+
+// sameIdentifiers reports whether two articles identify the same story.
+// When both cite contract numbers they must share one; otherwise, when both
+// cite dollar amounts they must share one; otherwise their titles must match.
+// Stored candidates are loaded without their full text, so only the title
+// and summary are compared.
+func sameIdentifiers(a, b *Article) bool {
+	aText, bText := a.Title+" "+a.Summary, b.Title+" "+b.Summary
+
+	aNumbers, bNumbers := contractNumbers(aText), contractNumbers(bText)
+	if len(aNumbers) > 0 && len(bNumbers) > 0 {
+		return sharesAny(aNumbers, bNumbers)
+	}
+
+	aAmounts, bAmounts := dollarAmounts(aText), dollarAmounts(bText)
+	if len(aAmounts) > 0 && len(bAmounts) > 0 {
+		return sharesAny(aAmounts, bAmounts)
+	}
+
+	return slices.Equal(tokenize(strings.ToLower(a.Title)), tokenize(strings.ToLower(b.Title)))
+}
+
+// contractNumbers returns the contract numbers cited in the text, without dashes
+func contractNumbers(text string) []string {
+	matches := contractNumberPattern.FindAllString(text, -1)
+	for i, match := range matches {
+		matches[i] = strings.ReplaceAll(match, "-", "")
+	}
+	return matches
+}
+
+// dollarAmounts returns the dollar amounts cited in the text, lower-cased
+// without spaces or thousands separators
+func dollarAmounts(text string) []string {
+	matches := dollarAmountPattern.FindAllString(text, -1)
+	for i, match := range matches {
+		matches[i] = strings.NewReplacer(" ", "", ",", "").Replace(strings.ToLower(match))
+	}
+	return matches
+}
+
+// sharesAny reports whether the lists have a value in common
+func sharesAny(a, b []string) bool {
+	for _, value := range a {
+		if slices.Contains(b, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// source describes this article's feed item for one company
+func (a *Article) source(company string) ArticleSource {
+	return ArticleSource{
+		CompanyName:   company,
+		FeedSource:    a.FeedSource,
+		SourceURL:     a.SourceURL,
+		GUID:          a.GUID,
+		PublishedDate: a.PublishedDate,
+	}
+}
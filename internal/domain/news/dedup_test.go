@@ -0,0 +1,159 @@
+package news
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://www.example.com/news/story/", "https://example.com/news/story"},
+		{"http://Example.com:80/news/story?utm_source=rss&utm_medium=feed", "https://example.com/news/story"},
+		{"https://example.com/news?b=2&a=1&fbclid=abc#comments", "https://example.com/news?a=1&b=2"},
+		{"https://example.com:8443/", "https://example.com:8443"},
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalizeURL(tt.input); got != tt.expected {
+			t.Errorf("CanonicalizeURL(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestFingerprintNearDuplicates(t *testing.T) {
+	original := "Raytheon has been awarded a contract by the U.S. Army to deliver additional Patriot air and missile defense " +
+		"systems, including radars, launchers and interceptors, to strengthen integrated air defense for allied nations " +
+		"across Europe and the Indo-Pacific region over the next five years."
+	syndicated := "Raytheon has been awarded a contract by the U.S. Army to deliver additional Patriot air and missile defense " +
+		"systems, including radars, launchers and interceptors, to strengthen integrated air defense for allied nations " +
+		"across Europe and the Indo-Pacific region over the next five years, the company said."
+	unrelated := "Boeing reported third quarter revenue below analyst expectations as commercial airplane deliveries slowed " +
+		"and the company recorded additional charges on fixed-price defense development programs including the tanker."
+
+	a, ok := Fingerprint(original)
+	if !ok {
+		t.Fatal("Expected fingerprint for long text")
+	}
+	b, _ := Fingerprint(syndicated)
+	c, _ := Fingerprint(unrelated)
+
+	if d := HammingDistance(a, b); d > NearDuplicateDistance {
+		t.Errorf("Expected near-duplicate distance <= %d, got %d", NearDuplicateDistance, d)
+	}
+	if d := HammingDistance(a, c); d <= NearDuplicateDistance {
+		t.Errorf("Expected unrelated texts to differ by more than %d bits, got %d", NearDuplicateDistance, d)
+	}
+
+	if _, ok := Fingerprint("too short to fingerprint"); ok {
+		t.Error("Expected no fingerprint for short text")
+	}
+}
+
+func TestFingerprintBandsShareBandWithinDistance(t *testing.T) {
+	fingerprint := uint64(0x0123456789abcdef)
+	// Flip one bit in each of three bands
+	near := fingerprint ^ (1 << 2) ^ (1 << 20) ^ (1 << 40)
+
+	bands := FingerprintBands(fingerprint)
+	if len(bands) != FingerprintBandCount {
+		t.Fatalf("Expected %d bands, got %d", FingerprintBandCount, len(bands))
+	}
+
+	shared := 0
+	for i, band := range FingerprintBands(near) {
+		if band == bands[i] {
+			shared++
+		}
+	}
+	if shared == 0 {
+		t.Error("Expected fingerprints within the near-duplicate distance to share a band")
+	}
+}
+
+func TestIsDuplicateOf(t *testing.T) {
+	published := time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC)
+	text := "Lockheed Martin and the U.S. Air Force completed the first flight test of an upgraded F-35 sensor suite at Edwards."
+
+	article := &Article{
+		Title:         "F-35 upgrade flies",
+		SourceURL:     "https://www.example.com/f35?utm_campaign=x",
+		GUID:          "guid-1",
+		Summary:       text,
+		Companies:     []string{"Lockheed Martin"},
+		FeedSource:    "Example",
+		PublishedDate: published,
+	}
+	article.PrepareDeduplication()
+
+	if len(article.Sources) != 1 || article.Sources[0].GUID != "guid-1" {
+		t.Fatalf("Expected one source for the article, got %+v", article.Sources)
+	}
+
+	sameURL := &Article{CanonicalURL: "https://example.com/f35"}
+	if !article.IsDuplicateOf(sameURL) {
+		t.Error("Expected articles with the same canonical URL to be duplicates")
+	}
+
+	copied := &Article{Title: "F-35 upgrade flies", SourceURL: "https://other.example.org/story", Summary: text}
+	copied.PrepareDeduplication()
+	if !article.IsDuplicateOf(copied) {
+		t.Error("Expected articles with identical text to be duplicates")
+	}
+
+	different := &Article{
+		Title:     "Earnings",
+		SourceURL: "https://other.example.org/earnings",
+		Summary:   "Northrop Grumman raised its full year sales guidance on strong demand for space and mission systems.",
+	}
+	different.PrepareDeduplication()
+	if article.IsDuplicateOf(different) {
+		t.Error("Expected unrelated articles not to be duplicates")
+	}
+}
+
+func TestIsDuplicateOfTemplatedAwards(t *testing.T) {
+	template := "%s, Fort Worth, Texas, has been awarded a %s firm-fixed-price modification (%s) to a previously awarded contract for " +
+		"sustainment, logistics and engineering support of the F-35 Lightning II aircraft for the Air Force, Marine Corps, Navy and " +
+		"international partners. Work will be performed in Fort Worth, Texas, and is expected to be completed in December 2026. " +
+		"Fiscal 2025 aircraft procurement funds are obligated at the time of award. The Naval Air Systems Command, Patuxent River, Maryland, is the contracting activity."
+
+	first := &Article{
+		Title:     "Contracts for June 16, 2025",
+		SourceURL: "https://www.defense.gov/News/Contracts/Contract/Article/1",
+		Summary:   fmt.Sprintf(template, "Lockheed Martin Corp.", "$412,500,000", "N00019-24-C-0010"),
+	}
+	second := &Article{
+		Title:     "Contracts for June 16, 2025",
+		SourceURL: "https://www.defense.gov/News/Contracts/Contract/Article/2",
+		Summary:   fmt.Sprintf(template, "Lockheed Martin Aeronautics Co.", "$98,250,000", "N00019-25-C-0042"),
+	}
+	first.PrepareDeduplication()
+	second.PrepareDeduplication()
+
+	if distance := HammingDistance(uint64(first.Fingerprint), uint64(second.Fingerprint)); distance > NearDuplicateDistance {
+		t.Fatalf("Expected the notices within the near-duplicate distance, got %d", distance)
+	}
+	if first.IsDuplicateOf(second) {
+		t.Error("Expected awards with different contract numbers not to be duplicates")
+	}
+
+	amountOnly := &Article{Title: first.Title, Summary: strings.ReplaceAll(first.Summary, " (N00019-24-C-0010)", "")}
+	otherAmount := &Article{Title: first.Title, Summary: strings.ReplaceAll(amountOnly.Summary, "$412,500,000", "$98,250,000")}
+	amountOnly.PrepareDeduplication()
+	otherAmount.PrepareDeduplication()
+	if amountOnly.IsDuplicateOf(otherAmount) {
+		t.Error("Expected awards with different amounts not to be duplicates")
+	}
+
+	reposted := &Article{Title: "Lockheed wins F-35 sustainment award", SourceURL: "https://other.example.org/award", Summary: first.Summary}
+	reposted.PrepareDeduplication()
+	if !first.IsDuplicateOf(reposted) {
+		t.Error("Expected a repost of the same award to be a duplicate")
+	}
+}
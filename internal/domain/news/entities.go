@@ -0,0 +1,200 @@
+package news
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// minTickerLength is the shortest ticker matched on its own. Shorter tickers
+// ("BA", "GD") are common words or initials and only match after an exchange
+// prefix such as "NYSE: BA".
+const minTickerLength = 3
+
+// tickerPrefixes are tokens that introduce a stock ticker
+var tickerPrefixes = map[string]bool{
+	"nyse": true, "nasdaq": true, "amex": true, "lse": true, "tsx": true, "otc": true,
+}
+
+// CompanyEntity lists the terms that identify a company in article text
+type CompanyEntity struct {
+	Name    string
+	Ticker  string
+	Aliases []string
+	People  []string // Full names of key people
+}
+
+// CompanyMention records how often and where a company is mentioned in an article
+type CompanyMention struct {
+	CompanyName string `json:"company_name" bson:"company_name"`
+	Count       int    `json:"count" bson:"count"`
+	// FirstPosition is the word offset of the first mention, counting the
+	// title first and then the body
+	FirstPosition int  `json:"first_position" bson:"first_position"`
+	InTitle       bool `json:"in_title" bson:"in_title"`
+}
+
+// entityTerm is one tokenised term of a company
+type entityTerm struct {
+	company     int
+	tokens      []string
+	ticker      bool // Tickers match upper-case text only
+	needsPrefix bool
+}
+
+// EntityMatcher finds mentions of known companies in article text
+type EntityMatcher struct {
+	companies []string
+	terms     map[string][]entityTerm // Keyed by the first token of the term
+}
+
+// NewEntityMatcher builds a matcher for the companies' names, tickers,
+// aliases and key people
+func NewEntityMatcher(entities []CompanyEntity) *EntityMatcher {
+	m := &EntityMatcher{terms: make(map[string][]entityTerm)}
+
+	for i, entity := range entities {
+		m.companies = append(m.companies, entity.Name)
+
+		names := append([]string{entity.Name}, entity.Aliases...)
+		names = append(names, entity.People...)
+		for _, name := range names {
+			m.add(entityTerm{company: i, tokens: tokenize(strings.ToLower(name))})
+		}
+
+		if ticker := strings.TrimSpace(entity.Ticker); ticker != "" {
+			m.add(entityTerm{
+				company:     i,
+				tokens:      []string{strings.ToUpper(ticker)},
+				ticker:      true,
+				needsPrefix: len(ticker) < minTickerLength,
+			})
+		}
+	}
+
+	// Try longer terms first so "Raytheon Technologies" wins over "Raytheon"
+	for key := range m.terms {
+		terms := m.terms[key]
+		sort.SliceStable(terms, func(a, b int) bool {
+			return len(terms[a].tokens) > len(terms[b].tokens)
+		})
+	}
+
+	return m
+}
+
+// add registers a term under its first token
+func (m *EntityMatcher) add(term entityTerm) {
+	if len(term.tokens) == 0 {
+		return
+	}
+	key := term.tokens[0]
+	m.terms[key] = append(m.terms[key], term)
+}
+
+// Match returns the companies mentioned in the title and body, ordered by
+// first mention
+func (m *EntityMatcher) Match(title, body string) []CompanyMention {
+	titleTokens := tokenize(title)
+	tokens := append(titleTokens, tokenize(body)...)
+
+	lower := make([]string, len(tokens))
+	for i, token := range tokens {
+		lower[i] = strings.ToLower(token)
+	}
+
+	mentions := make(map[int]*CompanyMention)
+	for i := 0; i < len(tokens); {
+		term, ok := m.matchAt(tokens, lower, i)
+		if !ok {
+			i++
+			continue
+		}
+
+		mention, seen := mentions[term.company]
+		if !seen {
+			mention = &CompanyMention{
+				CompanyName:   m.companies[term.company],
+				FirstPosition: i,
+				InTitle:       i < len(titleTokens),
+			}
+			mentions[term.company] = mention
+		}
+		mention.Count++
+		i += len(term.tokens)
+	}
+
+	result := make([]CompanyMention, 0, len(mentions))
+	for _, mention := range mentions {
+		result = append(result, *mention)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].FirstPosition < result[b].FirstPosition
+	})
+
+	return result
+}
+
+// matchAt returns the longest term starting at token i
+func (m *EntityMatcher) matchAt(tokens, lower []string, i int) (entityTerm, bool) {
+	candidates := m.terms[lower[i]]
+	if tickers, ok := m.terms[tokens[i]]; ok && tokens[i] != lower[i] {
+		candidates = append(append([]entityTerm{}, candidates...), tickers...)
+	}
+
+	for _, term := range candidates {
+		if term.ticker {
+			if tokens[i] != term.tokens[0] {
+				continue
+			}
+			if term.needsPrefix && (i == 0 || !tickerPrefixes[lower[i-1]]) {
+				continue
+			}
+			return term, true
+		}
+
+		if i+len(term.tokens) > len(lower) {
+			continue
+		}
+		matched := true
+		for j, token := range term.tokens {
+			if lower[i+j] != token {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return term, true
+		}
+	}
+
+	return entityTerm{}, false
+}
+
+// tokenize splits text into words of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// TagCompanies records the companies mentioned in the article and adds them
+// to Companies. The feed's own companies are kept even when not mentioned.
+func (a *Article) TagCompanies(matcher *EntityMatcher) {
+	if matcher == nil {
+		return
+	}
+
+	a.Mentions = matcher.Match(a.Title, a.BodyText())
+
+	known := make(map[string]bool, len(a.Companies))
+	for _, name := range a.Companies {
+		known[name] = true
+	}
+	for _, mention := range a.Mentions {
+		if !known[mention.CompanyName] {
+			known[mention.CompanyName] = true
+			a.Companies = append(a.Companies, mention.CompanyName)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package news
+
+import "testing"
+
+func testEntityMatcher() *EntityMatcher {
+	return NewEntityMatcher([]CompanyEntity{
+		{
+			Name:    "Lockheed Martin",
+			Ticker:  "LMT",
+			Aliases: []string{"Lockheed"},
+			People:  []string{"James Taiclet"},
+		},
+		{
+			Name:    "Raytheon Technologies",
+			Ticker:  "RTX",
+			Aliases: []string{"Raytheon", "RTX"},
+		},
+		{
+			Name:   "Boeing",
+			Ticker: "BA",
+		},
+	})
+}
+
+func TestEntityMatcherMatch(t *testing.T) {
+	matcher := testEntityMatcher()
+
+	mentions := matcher.Match(
+		"Army awards Lockheed Martin missile contract",
+		"The contract follows a Raytheon Technologies award. Lockheed CEO James Taiclet said LMT shares rose. Raytheon declined to comment.",
+	)
+
+	if len(mentions) != 2 {
+		t.Fatalf("Expected 2 mentioned companies, got %+v", mentions)
+	}
+
+	lockheed := mentions[0]
+	if lockheed.CompanyName != "Lockheed Martin" || lockheed.Count != 4 || !lockheed.InTitle || lockheed.FirstPosition != 2 {
+		t.Errorf("Unexpected Lockheed mention: %+v", lockheed)
+	}
+
+	raytheon := mentions[1]
+	if raytheon.CompanyName != "Raytheon Technologies" || raytheon.Count != 2 || raytheon.InTitle {
+		t.Errorf("Unexpected Raytheon mention: %+v", raytheon)
+	}
+}
+
+func TestEntityMatcherTickers(t *testing.T) {
+	matcher := testEntityMatcher()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{"short ticker without exchange", "Crews will BA the runway", 0},
+		{"short ticker with exchange", "Shares (NYSE: BA) fell", 1},
+		{"lower-case ticker", "the lmt team", 0},
+		{"upper-case ticker", "LMT rose", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(matcher.Match("", tt.text)); got != tt.expected {
+				t.Errorf("Expected %d mentions, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTagCompaniesKeepsFeedCompany(t *testing.T) {
+	article := &Article{
+		Title:     "Contracts for June 16",
+		Summary:   "Lockheed Martin Corp., Grand Prairie, Texas, was awarded a contract.",
+		Companies: []string{"US War Department"},
+	}
+
+	article.TagCompanies(testEntityMatcher())
+
+	if len(article.Companies) != 2 || article.Companies[0] != "US War Department" || article.Companies[1] != "Lockheed Martin" {
+		t.Errorf("Expected feed company and Lockheed Martin, got %v", article.Companies)
+	}
+	if len(article.Mentions) != 1 {
+		t.Errorf("Expected 1 mention, got %+v", article.Mentions)
+	}
+}
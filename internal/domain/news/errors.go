@@ -10,5 +10,11 @@ var (
 	ErrInvalidRelevanceScore = errors.New("relevance score must be between 0 and 1")
 	ErrArticleNotFound       = errors.New("article not found")
 	ErrDuplicateArticle      = errors.New("article already exists")
+	ErrArticleMerged         = errors.New("article merged into an existing duplicate")
 	ErrInvalidFilter         = errors.New("invalid filter parameters")
+	ErrRobotsDisallowed      = errors.New("fetching the page is disallowed by robots.txt")
+	ErrUnsupportedContent    = errors.New("unsupported content type")
+	ErrNoReadableContent     = errors.New("no readable content found")
+	ErrInvalidEntityID       = errors.New("invalid entity ID")
+	ErrEmptySearchQuery      = errors.New("search query must contain at least one word")
 )
\ No newline at end of file
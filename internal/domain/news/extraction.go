@@ -0,0 +1,77 @@
+package news
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ExtractionStatus describes the outcome of full-text extraction for an article
+type ExtractionStatus string
+
+const (
+	// ExtractionSucceeded means the readable text of the source page was stored
+	ExtractionSucceeded ExtractionStatus = "succeeded"
+	// ExtractionFailed means the page could not be fetched or parsed
+	ExtractionFailed ExtractionStatus = "failed"
+	// ExtractionBlocked means robots.txt disallows fetching the page
+	ExtractionBlocked ExtractionStatus = "blocked"
+	// ExtractionSkipped means the page is not HTML or has no readable text
+	ExtractionSkipped ExtractionStatus = "skipped"
+)
+
+// Extraction records the outcome of full-text extraction for an article
+type Extraction struct {
+	Status      ExtractionStatus `json:"status" bson:"status"`
+	Error       string           `json:"error,omitempty" bson:"error,omitempty"`
+	WordCount   int              `json:"word_count" bson:"word_count"`
+	ExtractedAt time.Time        `json:"extracted_at" bson:"extracted_at"`
+}
+
+// ExtractedContent is the readable content of a source page
+type ExtractedContent struct {
+	Title     string
+	Text      string
+	WordCount int
+}
+
+// Extractor fetches a source page and extracts its main readable text
+type Extractor interface {
+	Extract(ctx context.Context, sourceURL string) (*ExtractedContent, error)
+}
+
+// ApplyExtraction stores the outcome of full-text extraction on the article
+func (a *Article) ApplyExtraction(content *ExtractedContent, err error, at time.Time) {
+	extraction := &Extraction{ExtractedAt: at}
+
+	switch {
+	case err == nil && content != nil:
+		extraction.Status = ExtractionSucceeded
+		extraction.WordCount = content.WordCount
+		a.FullText = content.Text
+	case errors.Is(err, ErrRobotsDisallowed):
+		extraction.Status = ExtractionBlocked
+	case errors.Is(err, ErrUnsupportedContent), errors.Is(err, ErrNoReadableContent):
+		extraction.Status = ExtractionSkipped
+	default:
+		extraction.Status = ExtractionFailed
+	}
+
+	if err != nil {
+		extraction.Error = err.Error()
+	}
+
+	a.Extraction = extraction
+}
+
+// BodyText returns the most complete text available for the article:
+// the extracted full text, then the feed content, then the summary
+func (a *Article) BodyText() string {
+	if a.FullText != "" {
+		return a.FullText
+	}
+	if a.Content != "" {
+		return a.Content
+	}
+	return a.Summary
+}
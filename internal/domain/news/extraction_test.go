@@ -0,0 +1,38 @@
+package news
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApplyExtractionStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  *ExtractedContent
+		err      error
+		expected ExtractionStatus
+	}{
+		{"success", &ExtractedContent{Text: "body", WordCount: 1}, nil, ExtractionSucceeded},
+		{"robots", nil, ErrRobotsDisallowed, ExtractionBlocked},
+		{"unsupported", nil, ErrUnsupportedContent, ExtractionSkipped},
+		{"failure", nil, errors.New("connection refused"), ExtractionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := &Article{Summary: "teaser"}
+			article.ApplyExtraction(tt.content, tt.err, time.Now())
+
+			if article.Extraction.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s", tt.expected, article.Extraction.Status)
+			}
+			if tt.err == nil && article.BodyText() != "body" {
+				t.Errorf("Expected body text from extraction, got %q", article.BodyText())
+			}
+			if tt.err != nil && article.BodyText() != "teaser" {
+				t.Errorf("Expected body text to fall back to summary, got %q", article.BodyText())
+			}
+		})
+	}
+}
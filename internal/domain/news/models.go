@@ -19,6 +19,28 @@ type Article struct {
 	FeedSource     string             `json:"feed_source" bson:"feed_source"`
 	Content        string             `json:"content,omitempty" bson:"content,omitempty"`
 	GUID           string             `json:"guid" bson:"guid"`
+	// ContentHTML is the sanitised HTML of the feed content; Content holds its plain text
+	ContentHTML string  `json:"content_html,omitempty" bson:"content_html,omitempty"`
+	Media       []Media `json:"media,omitempty" bson:"media,omitempty"`
+	// FullText is the readable text extracted from the source page
+	FullText   string      `json:"full_text,omitempty" bson:"full_text,omitempty"`
+	Extraction *Extraction `json:"extraction,omitempty" bson:"extraction,omitempty"`
+	// CanonicalURL and Fingerprint detect the same story published under
+	// different URLs or GUIDs; Sources lists every feed item merged into the article
+	CanonicalURL     string          `json:"canonical_url,omitempty" bson:"canonical_url,omitempty"`
+	Fingerprint      int64           `json:"fingerprint,omitempty" bson:"fingerprint,omitempty"`
+	FingerprintBands []string        `json:"-" bson:"fingerprint_bands,omitempty"`
+	Sources          []ArticleSource `json:"sources,omitempty" bson:"sources,omitempty"`
+	// Mentions lists the known companies found in the article text
+	Mentions []CompanyMention `json:"mentions,omitempty" bson:"mentions,omitempty"`
+	// Relevance explains RelevanceScore factor by factor
+	Relevance *Relevance `json:"relevance,omitempty" bson:"relevance,omitempty"`
+	// Categories are the taxonomy topics the article covers
+	Categories []Category `json:"categories,omitempty" bson:"categories,omitempty"`
+	// Entities lists the people, programs, agencies and countries mentioned
+	Entities []EntityMention `json:"entities,omitempty" bson:"entities,omitempty"`
+	// Sentiment is the tone of the article overall and towards each mentioned company
+	Sentiment *Sentiment `json:"sentiment,omitempty" bson:"sentiment,omitempty"`
 }
 
 // Validate validates the Article fields
@@ -38,17 +60,61 @@ func (a *Article) Validate() error {
 	return nil
 }
 
-// NewsFilter represents filters for news queries
+// NewsFilter represents filters for news queries. All conditions must hold.
 type NewsFilter struct {
-	Company      string     `json:"company,omitempty"`
-	StartDate    *time.Time `json:"start_date,omitempty"`
-	EndDate      *time.Time `json:"end_date,omitempty"`
-	MinRelevance *float64   `json:"min_relevance,omitempty"`
-	Limit        int        `json:"limit,omitempty"`
-	Offset       int        `json:"offset,omitempty"`
+	Company string `json:"company,omitempty"` // Required company
+	// Companies matches articles tagged with any or all of the companies
+	Companies        []string     `json:"companies,omitempty"`
+	CompanyMatch     CompanyMatch `json:"company_match,omitempty"`
+	ExcludeCompanies []string     `json:"exclude_companies,omitempty"`
+	FeedSources      []string     `json:"feed_sources,omitempty"` // Any of the feed labels
+	StartDate        *time.Time   `json:"start_date,omitempty"`
+	EndDate          *time.Time   `json:"end_date,omitempty"`
+	MinRelevance     *float64     `json:"min_relevance,omitempty"`
+	// Categories matches articles in any of the categories
+	Categories        []Category `json:"categories,omitempty"`
+	ExcludeCategories []Category `json:"exclude_categories,omitempty"`
+	Entity            string     `json:"entity,omitempty"` // Normalised entity ID
+	// Query is free text; every word or quoted phrase must appear in the
+	// title or summary
+	Query string `json:"query,omitempty"`
+	// Sentiment filters apply to the tone towards the filter's only company
+	// (see SentimentCompany), and to the overall tone otherwise
+	MinSentiment *float64       `json:"min_sentiment,omitempty"`
+	MaxSentiment *float64       `json:"max_sentiment,omitempty"`
+	Sentiment    SentimentLabel `json:"sentiment,omitempty"`
+	Sort         SortField      `json:"sort,omitempty"`
+	Order        SortOrder      `json:"order,omitempty"`
+	// After continues from a cursor returned with the previous page; it
+	// replaces Offset
+	After  *Cursor `json:"-"`
+	Limit  int     `json:"limit,omitempty"`
+	Offset int     `json:"offset,omitempty"`
 }
 
-// RSSFeedItem represents a parsed RSS feed item
+// MediaType classifies a media attachment
+type MediaType string
+
+const (
+	MediaImage MediaType = "image"
+	MediaVideo MediaType = "video"
+	MediaAudio MediaType = "audio"
+	MediaFile  MediaType = "file"
+)
+
+// Media is an image or enclosure attached to an article
+type Media struct {
+	URL      string    `json:"url" bson:"url"`
+	Type     MediaType `json:"type" bson:"type"`
+	MIMEType string    `json:"mime_type,omitempty" bson:"mime_type,omitempty"`
+	Title    string    `json:"title,omitempty" bson:"title,omitempty"`
+	Width    int       `json:"width,omitempty" bson:"width,omitempty"`
+	Height   int       `json:"height,omitempty" bson:"height,omitempty"`
+	Length   int64     `json:"length,omitempty" bson:"length,omitempty"`
+}
+
+// RSSFeedItem represents a parsed RSS feed item.
+// Title, Summary and Content are plain text; ContentHTML is sanitised HTML.
 type RSSFeedItem struct {
 	Title       string
 	Summary     string
@@ -56,4 +122,6 @@ type RSSFeedItem struct {
 	PublishDate time.Time
 	GUID        string
 	Content     string
+	ContentHTML string
+	Media       []Media
 }
\ No newline at end of file
@@ -0,0 +1,330 @@
+package news
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// EntityType is the kind of a named entity
+type EntityType string
+
+const (
+	EntityPerson  EntityType = "person"
+	EntityProgram EntityType = "program" // Weapons programs and platforms
+	EntityAgency  EntityType = "agency"  // Government agencies and military services
+	EntityCountry EntityType = "country"
+)
+
+// EntityTypes lists every entity type
+var EntityTypes = []EntityType{EntityPerson, EntityProgram, EntityAgency, EntityCountry}
+
+// IsValid reports whether the entity type is known
+func (t EntityType) IsValid() bool {
+	for _, entityType := range EntityTypes {
+		if t == entityType {
+			return true
+		}
+	}
+	return false
+}
+
+// NamedEntity is a gazetteer entry: a person, program, agency or country and
+// the names it is mentioned by
+type NamedEntity struct {
+	Type    EntityType
+	Name    string
+	Aliases []string
+	// AliasesOnly marks a name that is ambiguous on its own, such as
+	// "Patriot"; only the qualified aliases ("Patriot missile") match
+	AliasesOnly bool
+}
+
+// ID returns the entity's normalised ID
+func (e NamedEntity) ID() string {
+	return EntityID(e.Type, e.Name)
+}
+
+// EntityMention records how often a named entity is mentioned in an article
+type EntityMention struct {
+	ID      string     `json:"id" bson:"id"`
+	Type    EntityType `json:"type" bson:"type"`
+	Name    string     `json:"name" bson:"name"`
+	Count   int        `json:"count" bson:"count"`
+	InTitle bool       `json:"in_title" bson:"in_title"`
+}
+
+// EntityID builds the normalised ID of an entity from its type and canonical
+// name, e.g. "program:f-35" or "person:james-taiclet"
+func EntityID(entityType EntityType, name string) string {
+	return string(entityType) + ":" + strings.Join(tokenize(strings.ToLower(name)), "-")
+}
+
+// ParseEntityID validates a normalised entity ID and returns its type
+func ParseEntityID(id string) (EntityType, error) {
+	prefix, slug, found := strings.Cut(id, ":")
+	entityType := EntityType(prefix)
+	if !found || !entityType.IsValid() || slug == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEntityID, id)
+	}
+
+	for _, r := range slug {
+		if r != '-' && !unicode.IsLower(r) && !unicode.IsDigit(r) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidEntityID, id)
+		}
+	}
+	return entityType, nil
+}
+
+// namedTerm is one tokenised name of a gazetteer entity
+type namedTerm struct {
+	entity int
+	tokens []string
+	// exact terms are acronyms ("US", "NASA") that match upper-case text only
+	exact bool
+}
+
+// NamedEntityMatcher finds mentions of gazetteer entities in article text
+type NamedEntityMatcher struct {
+	entities []NamedEntity
+	terms    map[string][]namedTerm // Keyed by the lower-case first token of the term
+}
+
+// NewNamedEntityMatcher builds a matcher for the entities' names and aliases.
+// Names match whole words case-insensitively, ignoring punctuation, except
+// single-word acronyms which must be written in upper case.
+func NewNamedEntityMatcher(entities []NamedEntity) *NamedEntityMatcher {
+	m := &NamedEntityMatcher{entities: entities, terms: make(map[string][]namedTerm)}
+
+	for i, entity := range entities {
+		names := entity.Aliases
+		if !entity.AliasesOnly {
+			names = append([]string{entity.Name}, entity.Aliases...)
+		}
+		for _, name := range names {
+			tokens := tokenize(name)
+			if len(tokens) == 0 {
+				continue
+			}
+
+			term := namedTerm{entity: i, exact: len(tokens) == 1 && isAcronym(tokens[0])}
+			if !term.exact {
+				for j := range tokens {
+					tokens[j] = strings.ToLower(tokens[j])
+				}
+			}
+			term.tokens = tokens
+
+			key := strings.ToLower(tokens[0])
+			m.terms[key] = append(m.terms[key], term)
+		}
+	}
+
+	// Try longer terms first so "Space Launch System" wins over shorter names
+	for key := range m.terms {
+		terms := m.terms[key]
+		sort.SliceStable(terms, func(a, b int) bool {
+			return len(terms[a].tokens) > len(terms[b].tokens)
+		})
+	}
+
+	return m
+}
+
+// isAcronym reports whether a token is written in upper-case letters only
+func isAcronym(token string) bool {
+	for _, r := range token {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match returns the entities mentioned in the title and body, ordered by
+// first mention. Overlapping names count once, for the longest match.
+func (m *NamedEntityMatcher) Match(title, body string) []EntityMention {
+	titleTokens := tokenize(title)
+	tokens := append(titleTokens, tokenize(body)...)
+
+	lower := make([]string, len(tokens))
+	for i, token := range tokens {
+		lower[i] = strings.ToLower(token)
+	}
+
+	var order []int
+	mentions := make(map[int]*EntityMention)
+	for i := 0; i < len(tokens); {
+		term, ok := m.matchAt(tokens, lower, i)
+		if !ok {
+			i++
+			continue
+		}
+
+		mention, seen := mentions[term.entity]
+		if !seen {
+			entity := m.entities[term.entity]
+			mention = &EntityMention{
+				ID:      entity.ID(),
+				Type:    entity.Type,
+				Name:    entity.Name,
+				InTitle: i < len(titleTokens),
+			}
+			mentions[term.entity] = mention
+			order = append(order, term.entity)
+		}
+		mention.Count++
+		i += len(term.tokens)
+	}
+
+	result := make([]EntityMention, 0, len(order))
+	for _, entity := range order {
+		result = append(result, *mentions[entity])
+	}
+	return result
+}
+
+// matchAt returns the longest term starting at token i
+func (m *NamedEntityMatcher) matchAt(tokens, lower []string, i int) (namedTerm, bool) {
+	for _, term := range m.terms[lower[i]] {
+		if i+len(term.tokens) > len(tokens) {
+			continue
+		}
+
+		text := lower
+		if term.exact {
+			text = tokens
+		}
+		matched := true
+		for j, token := range term.tokens {
+			if text[i+j] != token {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return term, true
+		}
+	}
+
+	return namedTerm{}, false
+}
+
+// ExtractEntities records the people, programs, agencies and countries
+// mentioned in the article
+func (a *Article) ExtractEntities(matcher *NamedEntityMatcher) {
+	if matcher == nil {
+		return
+	}
+	a.Entities = matcher.Match(a.Title, a.BodyText())
+}
+
+// DefaultGazetteer returns the built-in programs, agencies and countries.
+// People are added from the companies' key people at ingestion time.
+func DefaultGazetteer() []NamedEntity {
+	gazetteer := make([]NamedEntity, 0, len(defaultPrograms)+len(defaultAgencies)+len(defaultCountries))
+	gazetteer = append(gazetteer, defaultPrograms...)
+	gazetteer = append(gazetteer, defaultAgencies...)
+	gazetteer = append(gazetteer, defaultCountries...)
+	return gazetteer
+}
+
+// defaultPrograms are weapons programs and platforms. Names that are also
+// common words or other organisations match only in qualified forms.
+var defaultPrograms = []NamedEntity{
+	{Type: EntityProgram, Name: "F-35", Aliases: []string{"F-35A", "F-35B", "F-35C", "Joint Strike Fighter", "F-35 Lightning II"}},
+	{Type: EntityProgram, Name: "F-22", Aliases: []string{"F-22 Raptor"}},
+	{Type: EntityProgram, Name: "F-47", Aliases: []string{"Next Generation Air Dominance", "NGAD"}},
+	{Type: EntityProgram, Name: "F-16", Aliases: []string{"F-16 Fighting Falcon"}},
+	{Type: EntityProgram, Name: "B-21", Aliases: []string{"B-21 Raider"}},
+	{Type: EntityProgram, Name: "B-52", Aliases: []string{"B-52 Stratofortress"}},
+	{Type: EntityProgram, Name: "KC-46", Aliases: []string{"KC-46A", "KC-46 Pegasus"}},
+	{Type: EntityProgram, Name: "C-130", Aliases: []string{"C-130J", "C-130 Hercules", "C-130J Super Hercules"}},
+	{Type: EntityProgram, Name: "E-7", Aliases: []string{"E-7 Wedgetail"}},
+	{Type: EntityProgram, Name: "CH-53K", Aliases: []string{"CH-53K King Stallion"}},
+	{Type: EntityProgram, Name: "UH-60", Aliases: []string{"UH-60 Black Hawk", "Black Hawk"}},
+	{Type: EntityProgram, Name: "AH-64", Aliases: []string{"AH-64 Apache", "Apache helicopter"}},
+	{Type: EntityProgram, Name: "V-22", Aliases: []string{"V-22 Osprey", "MV-22", "CV-22"}},
+	{Type: EntityProgram, Name: "MQ-9", Aliases: []string{"MQ-9 Reaper", "MQ-9B"}},
+	{Type: EntityProgram, Name: "MQ-25", Aliases: []string{"MQ-25 Stingray"}},
+	{Type: EntityProgram, Name: "Collaborative Combat Aircraft", Aliases: []string{"CCA"}},
+	{Type: EntityProgram, Name: "Patriot", AliasesOnly: true, Aliases: []string{
+		"Patriot missile", "Patriot missiles", "Patriot system", "Patriot systems", "Patriot battery",
+		"Patriot batteries", "Patriot interceptor", "Patriot interceptors", "Patriot air and missile defense",
+		"Patriot Advanced Capability", "MIM-104", "PAC-2", "PAC-3", "PAC-3 MSE",
+	}},
+	{Type: EntityProgram, Name: "THAAD", Aliases: []string{"Terminal High Altitude Area Defense"}},
+	{Type: EntityProgram, Name: "LTAMDS", Aliases: []string{"Lower Tier Air and Missile Defense Sensor"}},
+	{Type: EntityProgram, Name: "Aegis", Aliases: []string{"Aegis Combat System", "Aegis Ballistic Missile Defense"}},
+	{Type: EntityProgram, Name: "Standard Missile", Aliases: []string{"SM-3", "SM-6"}},
+	{Type: EntityProgram, Name: "Tomahawk", Aliases: []string{"Tomahawk cruise missile"}},
+	{Type: EntityProgram, Name: "AMRAAM", Aliases: []string{"AIM-120"}},
+	{Type: EntityProgram, Name: "Javelin", Aliases: []string{"Javelin missile"}},
+	{Type: EntityProgram, Name: "HIMARS", Aliases: []string{"High Mobility Artillery Rocket System"}},
+	{Type: EntityProgram, Name: "GMLRS", Aliases: []string{"Guided Multiple Launch Rocket System"}},
+	{Type: EntityProgram, Name: "Sentinel ICBM", Aliases: []string{"LGM-35A", "LGM-35A Sentinel"}},
+	{Type: EntityProgram, Name: "Next Generation Interceptor", Aliases: []string{"NGI"}},
+	{Type: EntityProgram, Name: "Golden Dome"},
+	{Type: EntityProgram, Name: "Columbia-class submarine", Aliases: []string{"Columbia-class", "Columbia class"}},
+	{Type: EntityProgram, Name: "Virginia-class submarine", Aliases: []string{"Virginia-class", "Virginia class"}},
+	{Type: EntityProgram, Name: "Artemis", Aliases: []string{"Artemis program", "Artemis II", "Artemis III"}},
+	{Type: EntityProgram, Name: "Space Launch System", Aliases: []string{"SLS"}},
+	{Type: EntityProgram, Name: "Orion", AliasesOnly: true, Aliases: []string{
+		"Orion spacecraft", "Orion capsule", "Orion crew capsule", "Orion Multi-Purpose Crew Vehicle", "Orion MPCV",
+	}},
+	{Type: EntityProgram, Name: "Starliner", Aliases: []string{"CST-100 Starliner"}},
+	{Type: EntityProgram, Name: "GPS III", Aliases: []string{"GPS IIIF"}},
+}
+
+// defaultAgencies are government agencies, military services and alliances
+var defaultAgencies = []NamedEntity{
+	{Type: EntityAgency, Name: "Department of Defense", Aliases: []string{"DoD", "Defense Department", "Pentagon", "Department of War", "War Department"}},
+	{Type: EntityAgency, Name: "US Army", Aliases: []string{"U.S. Army", "United States Army", "Army Contracting Command"}},
+	{Type: EntityAgency, Name: "US Navy", Aliases: []string{"U.S. Navy", "United States Navy", "Naval Sea Systems Command", "NAVSEA", "Naval Air Systems Command", "NAVAIR"}},
+	{Type: EntityAgency, Name: "US Air Force", Aliases: []string{"U.S. Air Force", "United States Air Force", "USAF", "Air Force Life Cycle Management Center"}},
+	{Type: EntityAgency, Name: "US Marine Corps", Aliases: []string{"U.S. Marine Corps", "United States Marine Corps", "USMC"}},
+	{Type: EntityAgency, Name: "US Space Force", Aliases: []string{"U.S. Space Force", "United States Space Force", "Space Systems Command"}},
+	{Type: EntityAgency, Name: "DARPA", Aliases: []string{"Defense Advanced Research Projects Agency"}},
+	{Type: EntityAgency, Name: "Missile Defense Agency", Aliases: []string{"MDA"}},
+	{Type: EntityAgency, Name: "Defense Logistics Agency", Aliases: []string{"DLA"}},
+	{Type: EntityAgency, Name: "Defense Security Cooperation Agency", Aliases: []string{"DSCA"}},
+	{Type: EntityAgency, Name: "NASA", Aliases: []string{"National Aeronautics and Space Administration"}},
+	{Type: EntityAgency, Name: "Federal Aviation Administration", Aliases: []string{"FAA"}},
+	{Type: EntityAgency, Name: "State Department", Aliases: []string{"Department of State", "U.S. State Department"}},
+	{Type: EntityAgency, Name: "Department of Homeland Security", Aliases: []string{"DHS", "Homeland Security"}},
+	{Type: EntityAgency, Name: "NATO", Aliases: []string{"North Atlantic Treaty Organization"}},
+	{Type: EntityAgency, Name: "UK Ministry of Defence", Aliases: []string{"Ministry of Defence"}},
+	{Type: EntityAgency, Name: "European Space Agency", Aliases: []string{"ESA"}},
+}
+
+// defaultCountries are countries commonly covered by defense and aerospace news
+var defaultCountries = []NamedEntity{
+	{Type: EntityCountry, Name: "United States", Aliases: []string{"US", "USA", "U.S.", "U.S.A.", "United States of America"}},
+	{Type: EntityCountry, Name: "United Kingdom", Aliases: []string{"UK", "U.K.", "Britain", "Great Britain"}},
+	{Type: EntityCountry, Name: "Canada"},
+	{Type: EntityCountry, Name: "Australia"},
+	{Type: EntityCountry, Name: "Germany"},
+	{Type: EntityCountry, Name: "France"},
+	{Type: EntityCountry, Name: "Italy"},
+	{Type: EntityCountry, Name: "Netherlands"},
+	{Type: EntityCountry, Name: "Norway"},
+	{Type: EntityCountry, Name: "Sweden"},
+	{Type: EntityCountry, Name: "Finland"},
+	{Type: EntityCountry, Name: "Poland"},
+	{Type: EntityCountry, Name: "Romania"},
+	{Type: EntityCountry, Name: "Turkey", Aliases: []string{"Türkiye"}},
+	{Type: EntityCountry, Name: "Ukraine"},
+	{Type: EntityCountry, Name: "Russia", Aliases: []string{"Russian Federation"}},
+	{Type: EntityCountry, Name: "China", Aliases: []string{"People's Republic of China", "PRC"}},
+	{Type: EntityCountry, Name: "Taiwan"},
+	{Type: EntityCountry, Name: "Japan"},
+	{Type: EntityCountry, Name: "South Korea", Aliases: []string{"Republic of Korea"}},
+	{Type: EntityCountry, Name: "North Korea", Aliases: []string{"DPRK"}},
+	{Type: EntityCountry, Name: "India"},
+	{Type: EntityCountry, Name: "Israel"},
+	{Type: EntityCountry, Name: "Saudi Arabia"},
+	{Type: EntityCountry, Name: "United Arab Emirates", Aliases: []string{"UAE"}},
+	{Type: EntityCountry, Name: "Qatar"},
+	{Type: EntityCountry, Name: "Iran"},
+}
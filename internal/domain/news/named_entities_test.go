@@ -0,0 +1,132 @@
+package news
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNamedEntityMatcherMatch(t *testing.T) {
+	gazetteer := append(DefaultGazetteer(), NamedEntity{Type: EntityPerson, Name: "James Taiclet"})
+	matcher := NewNamedEntityMatcher(gazetteer)
+
+	mentions := matcher.Match(
+		"Lockheed Martin wins F-35 Lot 18 contract",
+		"The U.S. Air Force and NASA said the F-35A jets will go to Poland. James Taiclet welcomed the award for the Joint Strike Fighter.",
+	)
+
+	expected := []EntityMention{
+		{ID: "program:f-35", Type: EntityProgram, Name: "F-35", Count: 3, InTitle: true},
+		{ID: "agency:us-air-force", Type: EntityAgency, Name: "US Air Force", Count: 1},
+		{ID: "agency:nasa", Type: EntityAgency, Name: "NASA", Count: 1},
+		{ID: "country:poland", Type: EntityCountry, Name: "Poland", Count: 1},
+		{ID: "person:james-taiclet", Type: EntityPerson, Name: "James Taiclet", Count: 1},
+	}
+
+	if len(mentions) != len(expected) {
+		t.Fatalf("Expected %d entities, got %+v", len(expected), mentions)
+	}
+	for i, want := range expected {
+		if mentions[i] != want {
+			t.Errorf("Entity %d: expected %+v, got %+v", i, want, mentions[i])
+		}
+	}
+}
+
+func TestNamedEntityMatcherAcronyms(t *testing.T) {
+	matcher := NewNamedEntityMatcher(DefaultGazetteer())
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"upper-case acronym", "The US approved the sale", "country:united-states"},
+		{"lower-case word", "Officials told us about the sale", ""},
+		{"dotted abbreviation", "The U.S. approved the sale", "country:united-states"},
+		{"longest name wins", "The U.S. Army ordered rounds", "agency:us-army"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mentions := matcher.Match("", tt.text)
+			if tt.expected == "" {
+				if len(mentions) != 0 {
+					t.Errorf("Expected no entities, got %+v", mentions)
+				}
+				return
+			}
+			if len(mentions) != 1 || mentions[0].ID != tt.expected {
+				t.Errorf("Expected %s, got %+v", tt.expected, mentions)
+			}
+		})
+	}
+}
+
+func TestNamedEntityMatcherAmbiguousNames(t *testing.T) {
+	matcher := NewNamedEntityMatcher(DefaultGazetteer())
+
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"The Patriot League season opens this weekend", nil},
+		{"Orion Health raised new funding", nil},
+		{"Bank of America cut its outlook for Boeing", nil},
+		{"Raytheon will deliver Patriot batteries to Poland", []string{"program:patriot", "country:poland"}},
+		{"Engineers stacked the Orion spacecraft for Artemis II", []string{"program:orion", "program:artemis"}},
+		{"The United States of America signed the agreement", []string{"country:united-states"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			mentions := matcher.Match("", tt.text)
+			ids := make([]string, 0, len(mentions))
+			for _, mention := range mentions {
+				ids = append(ids, mention.ID)
+			}
+			if !slices.Equal(ids, tt.expected) && (len(ids) != 0 || len(tt.expected) != 0) {
+				t.Errorf("Expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestParseEntityID(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected EntityType
+		wantErr  bool
+	}{
+		{"program:f-35", EntityProgram, false},
+		{"person:james-taiclet", EntityPerson, false},
+		{"country:united-states", EntityCountry, false},
+		{"weapon:f-35", "", true},
+		{"program:", "", true},
+		{"program:F-35", "", true},
+		{"f-35", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := ParseEntityID(tt.id)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEntityID) {
+					t.Errorf("Expected ErrInvalidEntityID, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("Expected %s, got %s (error %v)", tt.expected, got, err)
+			}
+		})
+	}
+}
+
+func TestEntityIDMatchesParse(t *testing.T) {
+	for _, entity := range DefaultGazetteer() {
+		if _, err := ParseEntityID(entity.ID()); err != nil {
+			t.Errorf("Gazetteer entity %q has an invalid ID: %v", entity.Name, err)
+		}
+	}
+}
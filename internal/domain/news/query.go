@@ -0,0 +1,247 @@
+package news
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SortField is the article field results are ordered by
+type SortField string
+
+const (
+	SortByDate      SortField = "date"
+	SortByRelevance SortField = "relevance"
+	// SortByMatch ranks articles by how well they match the query text
+	// (ranked full-text search); it requires query text
+	SortByMatch SortField = "match"
+)
+
+// ParseSortField converts a string into a sort field; empty sorts by date
+func ParseSortField(value string) (SortField, error) {
+	switch field := SortField(strings.ToLower(strings.TrimSpace(value))); field {
+	case "":
+		return SortByDate, nil
+	case SortByDate, SortByRelevance, SortByMatch:
+		return field, nil
+	default:
+		return "", fmt.Errorf("%w: unknown sort %q", ErrInvalidFilter, value)
+	}
+}
+
+// SortOrder is the direction results are ordered in
+type SortOrder string
+
+const (
+	SortDescending SortOrder = "desc"
+	SortAscending  SortOrder = "asc"
+)
+
+// ParseSortOrder converts a string into a sort order; empty is descending
+func ParseSortOrder(value string) (SortOrder, error) {
+	switch order := SortOrder(strings.ToLower(strings.TrimSpace(value))); order {
+	case "":
+		return SortDescending, nil
+	case SortDescending, SortAscending:
+		return order, nil
+	default:
+		return "", fmt.Errorf("%w: unknown sort order %q", ErrInvalidFilter, value)
+	}
+}
+
+// CompanyMatch controls whether articles must mention any or all of the
+// filter's companies
+type CompanyMatch string
+
+const (
+	MatchAnyCompany CompanyMatch = "any"
+	MatchAllCompany CompanyMatch = "all"
+)
+
+// ParseCompanyMatch converts a string into a company match mode; empty matches any
+func ParseCompanyMatch(value string) (CompanyMatch, error) {
+	switch match := CompanyMatch(strings.ToLower(strings.TrimSpace(value))); match {
+	case "":
+		return MatchAnyCompany, nil
+	case MatchAnyCompany, MatchAllCompany:
+		return match, nil
+	default:
+		return "", fmt.Errorf("%w: unknown company match %q", ErrInvalidFilter, value)
+	}
+}
+
+// Cursor marks the last article of a page; the next page starts after it in
+// the sort order it was issued for. Match scores depend on the query, so
+// ranked search cursors carry the offset of the next page instead.
+type Cursor struct {
+	Sort          SortField          `json:"s"`
+	Order         SortOrder          `json:"o"`
+	PublishedDate time.Time          `json:"p,omitempty"`
+	Relevance     float64            `json:"r,omitempty"`
+	Offset        int                `json:"n,omitempty"`
+	ID            primitive.ObjectID `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token returned by Cursor.Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID.IsZero() {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	return &cursor, nil
+}
+
+// NextCursor returns the cursor of the page after articles, or nil when the
+// page is not full and so is the last one
+func (f *NewsFilter) NextCursor(articles []*Article) *Cursor {
+	if len(articles) == 0 || len(articles) < f.Limit {
+		return nil
+	}
+
+	last := articles[len(articles)-1]
+	return &Cursor{
+		Sort:          f.EffectiveSort(),
+		Order:         f.EffectiveOrder(),
+		PublishedDate: last.PublishedDate,
+		Relevance:     last.RelevanceScore,
+		ID:            last.ID,
+	}
+}
+
+// SentimentCompany returns the company sentiment filters apply to: the only
+// company the filter requires, or empty for the overall tone
+func (f *NewsFilter) SentimentCompany() string {
+	switch {
+	case f.Company != "" && len(f.Companies) == 0:
+		return f.Company
+	case f.Company == "" && len(f.Companies) == 1:
+		return f.Companies[0]
+	default:
+		return ""
+	}
+}
+
+// Matches reports whether an article satisfies the filter's conditions, as
+// the repository would evaluate them. Sort, order, cursor and paging are ignored.
+func (f *NewsFilter) Matches(article *Article) bool {
+	if f.Company != "" && !slices.Contains(article.Companies, f.Company) {
+		return false
+	}
+	if len(f.Companies) > 0 {
+		matched := 0
+		for _, company := range f.Companies {
+			if slices.Contains(article.Companies, company) {
+				matched++
+			}
+		}
+		if matched == 0 || (f.CompanyMatch == MatchAllCompany && matched < len(f.Companies)) {
+			return false
+		}
+	}
+	for _, company := range f.ExcludeCompanies {
+		if slices.Contains(article.Companies, company) {
+			return false
+		}
+	}
+	if len(f.FeedSources) > 0 && !slices.Contains(f.FeedSources, article.FeedSource) {
+		return false
+	}
+	if f.StartDate != nil && article.PublishedDate.Before(*f.StartDate) {
+		return false
+	}
+	if f.EndDate != nil && article.PublishedDate.After(*f.EndDate) {
+		return false
+	}
+	if f.MinRelevance != nil && article.RelevanceScore < *f.MinRelevance {
+		return false
+	}
+	if len(f.Categories) > 0 && !slices.ContainsFunc(f.Categories, article.HasCategory) {
+		return false
+	}
+	if slices.ContainsFunc(f.ExcludeCategories, article.HasCategory) {
+		return false
+	}
+	if f.Entity != "" && !slices.ContainsFunc(article.Entities, func(m EntityMention) bool { return m.ID == f.Entity }) {
+		return false
+	}
+	title, summary := strings.ToLower(article.Title), strings.ToLower(article.Summary)
+	for _, term := range QueryTerms(f.Query) {
+		term = strings.ToLower(term)
+		if !strings.Contains(title, term) && !strings.Contains(summary, term) {
+			return false
+		}
+	}
+	return f.matchesSentiment(article)
+}
+
+// matchesSentiment applies the sentiment conditions to the tone towards
+// SentimentCompany, or to the overall tone
+func (f *NewsFilter) matchesSentiment(article *Article) bool {
+	if f.MinSentiment == nil && f.MaxSentiment == nil && f.Sentiment == "" {
+		return true
+	}
+	if article.Sentiment == nil {
+		return false
+	}
+
+	score, label := article.Sentiment.Score, article.Sentiment.Label
+	if company := f.SentimentCompany(); company != "" {
+		i := slices.IndexFunc(article.Sentiment.Companies, func(c CompanySentiment) bool { return c.CompanyName == company })
+		if i < 0 {
+			return false
+		}
+		score, label = article.Sentiment.Companies[i].Score, article.Sentiment.Companies[i].Label
+	}
+
+	return (f.MinSentiment == nil || score >= *f.MinSentiment) &&
+		(f.MaxSentiment == nil || score <= *f.MaxSentiment) &&
+		(f.Sentiment == "" || label == f.Sentiment)
+}
+
+// EffectiveSort returns the sort field, defaulting to date
+func (f *NewsFilter) EffectiveSort() SortField {
+	if f.Sort == "" {
+		return SortByDate
+	}
+	return f.Sort
+}
+
+// EffectiveOrder returns the sort order, defaulting to descending
+func (f *NewsFilter) EffectiveOrder() SortOrder {
+	if f.Order == "" {
+		return SortDescending
+	}
+	return f.Order
+}
+
+// QueryTerms splits a free-text query into words and quoted phrases
+func QueryTerms(query string) []string {
+	var terms []string
+	for i, part := range strings.Split(query, `"`) {
+		// Odd parts were inside quotes
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(part), " "); phrase != "" {
+				terms = append(terms, phrase)
+			}
+			continue
+		}
+		terms = append(terms, strings.Fields(part)...)
+	}
+	return terms
+}
@@ -0,0 +1,103 @@
+package news
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := &Cursor{
+		Sort:          SortByRelevance,
+		Order:         SortDescending,
+		PublishedDate: time.Date(2024, 10, 23, 10, 30, 0, 0, time.UTC),
+		Relevance:     0.85,
+		ID:            primitive.NewObjectID(),
+	}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if *decoded != *cursor {
+		t.Errorf("Expected %+v, got %+v", cursor, decoded)
+	}
+
+	for _, token := range []string{"not base64!", "e30"} {
+		if _, err := DecodeCursor(token); err == nil {
+			t.Errorf("Expected an error for token %q", token)
+		}
+	}
+}
+
+func TestNextCursor(t *testing.T) {
+	articles := []*Article{
+		{ID: primitive.NewObjectID(), RelevanceScore: 0.9},
+		{ID: primitive.NewObjectID(), RelevanceScore: 0.7},
+	}
+
+	filter := &NewsFilter{Sort: SortByRelevance, Limit: 2}
+	cursor := filter.NextCursor(articles)
+	if cursor == nil || cursor.ID != articles[1].ID || cursor.Relevance != 0.7 || cursor.Order != SortDescending {
+		t.Errorf("Unexpected cursor %+v", cursor)
+	}
+
+	filter.Limit = 3
+	if cursor := filter.NextCursor(articles); cursor != nil {
+		t.Errorf("Expected no cursor for the last page, got %+v", cursor)
+	}
+}
+
+func TestValidateFilterCursor(t *testing.T) {
+	service := &Service{}
+	cursor := &Cursor{Sort: SortByDate, Order: SortDescending, ID: primitive.NewObjectID()}
+
+	tests := []struct {
+		name    string
+		filter  NewsFilter
+		wantErr bool
+	}{
+		{"cursor in the default order", NewsFilter{After: cursor, Limit: 10}, false},
+		{"cursor for another sort", NewsFilter{After: cursor, Sort: SortByRelevance, Limit: 10}, true},
+		{"cursor for another order", NewsFilter{After: cursor, Order: SortAscending, Limit: 10}, true},
+		{"cursor with offset", NewsFilter{After: cursor, Offset: 20, Limit: 10}, true},
+		{"unknown category", NewsFilter{ExcludeCategories: []Category{"weather"}, Limit: 10}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.validateFilter(&tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	got := QueryTerms(`hypersonic "glide  body" test`)
+	expected := []string{"hypersonic", "glide body", "test"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestSentimentCompany(t *testing.T) {
+	tests := []struct {
+		filter   NewsFilter
+		expected string
+	}{
+		{NewsFilter{Company: "Boeing"}, "Boeing"},
+		{NewsFilter{Companies: []string{"Boeing"}}, "Boeing"},
+		{NewsFilter{Companies: []string{"Boeing", "Airbus"}}, ""},
+		{NewsFilter{}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.SentimentCompany(); got != tt.expected {
+			t.Errorf("SentimentCompany() of %+v = %q, expected %q", tt.filter, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package news
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// RecencyHalfLife is the article age at which the recency factor halves
+const RecencyHalfLife = 7 * 24 * time.Hour
+
+const (
+	// positionDecayWords is the word offset at which the position factor halves
+	positionDecayWords = 50
+	// keywordSaturation is the number of distinct industry keywords that gives
+	// the full keyword factor
+	keywordSaturation = 5
+)
+
+// RelevanceScorer scores how relevant an article is to the tracked companies
+type RelevanceScorer interface {
+	Score(article *Article, input RelevanceInput) *Relevance
+}
+
+// RelevanceInput is the context an article is scored in
+type RelevanceInput struct {
+	Industry    string    // Industry of the feed's company, selects the keyword dictionary
+	SourceTrust float64   // Trust weight of the feed between 0 and 1
+	Now         time.Time // Reference time for recency
+}
+
+// Relevance is the explainable result of scoring an article: the score and
+// the factors it was combined from, each between 0 and 1
+type Relevance struct {
+	Score       float64   `json:"score" bson:"score"`
+	Company     string    `json:"company,omitempty" bson:"company,omitempty"` // Company the mention factors refer to
+	Mentions    float64   `json:"mentions" bson:"mentions"`
+	Position    float64   `json:"position" bson:"position"`
+	Keywords    float64   `json:"keywords" bson:"keywords"`
+	SourceTrust float64   `json:"source_trust" bson:"source_trust"`
+	Recency     float64   `json:"recency" bson:"recency"`
+	ScoredAt    time.Time `json:"scored_at" bson:"scored_at"`
+}
+
+// RelevanceWeights weights each factor of the default scorer
+type RelevanceWeights struct {
+	Mentions    float64
+	Position    float64
+	Keywords    float64
+	SourceTrust float64
+	Recency     float64
+}
+
+// DefaultRelevanceWeights returns the weights used when none are configured
+func DefaultRelevanceWeights() RelevanceWeights {
+	return RelevanceWeights{
+		Mentions:    0.3,
+		Position:    0.2,
+		Keywords:    0.2,
+		SourceTrust: 0.15,
+		Recency:     0.15,
+	}
+}
+
+// IndustryKeywords are the keyword dictionaries of each industry
+var IndustryKeywords = map[string][]string{
+	"Defense": {
+		"contract", "award", "missile", "radar", "munition", "weapon", "army", "navy",
+		"air force", "marine", "pentagon", "procurement", "interceptor", "hypersonic",
+		"fighter", "submarine", "armored", "ammunition", "readiness", "deterrence",
+	},
+	"Aerospace": {
+		"aircraft", "engine", "aviation", "airline", "satellite", "space", "launch",
+		"avionics", "airframe", "propulsion", "certification", "delivery", "deliveries",
+		"backlog", "orders", "faa", "fleet", "rocket", "spacecraft", "aerostructure",
+	},
+	"Government": {
+		"secretary", "department", "policy", "budget", "appropriation", "congress",
+		"regulation", "directive", "acquisition", "program", "strategy", "treaty",
+		"sanction", "export control", "executive order", "legislation", "deployment",
+		"exercise", "alliance", "nato",
+	},
+}
+
+// DefaultRelevanceScorer combines entity mention frequency and position,
+// industry keywords, source trust and recency into a weighted score
+type DefaultRelevanceScorer struct {
+	weights  RelevanceWeights
+	keywords map[string][]string
+}
+
+// NewDefaultRelevanceScorer creates the default scorer with the given weights
+// and the built-in industry keyword dictionaries
+func NewDefaultRelevanceScorer(weights RelevanceWeights) *DefaultRelevanceScorer {
+	return &DefaultRelevanceScorer{
+		weights:  weights,
+		keywords: IndustryKeywords,
+	}
+}
+
+// Score scores the article against each of its companies and keeps the best.
+// Articles without company mentions fall back to a case-insensitive search
+// for the company names in the title and body.
+func (s *DefaultRelevanceScorer) Score(article *Article, input RelevanceInput) *Relevance {
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	trust := input.SourceTrust
+	if trust <= 0 || trust > 1 {
+		trust = 1
+	}
+
+	body := article.BodyText()
+	relevance := &Relevance{
+		Keywords:    s.keywordFactor(input.Industry, strings.ToLower(article.Title+" "+body)),
+		SourceTrust: trust,
+		Recency:     recencyFactor(article.PublishedDate, now),
+		ScoredAt:    now,
+	}
+
+	for _, name := range article.Companies {
+		mentions, position := mentionFactors(article, name, body)
+		if relevance.Company == "" || mentions+position > relevance.Mentions+relevance.Position {
+			relevance.Company = name
+			relevance.Mentions = mentions
+			relevance.Position = position
+		}
+	}
+
+	relevance.Score = s.combine(relevance)
+	return relevance
+}
+
+// combine computes the weighted average of the factors
+func (s *DefaultRelevanceScorer) combine(r *Relevance) float64 {
+	w := s.weights
+	total := w.Mentions + w.Position + w.Keywords + w.SourceTrust + w.Recency
+	if total <= 0 {
+		return 0
+	}
+
+	score := (w.Mentions*r.Mentions + w.Position*r.Position + w.Keywords*r.Keywords +
+		w.SourceTrust*r.SourceTrust + w.Recency*r.Recency) / total
+
+	return math.Round(math.Min(math.Max(score, 0), 1)*1000) / 1000
+}
+
+// keywordFactor counts the distinct industry keywords present in the lower-case text
+func (s *DefaultRelevanceScorer) keywordFactor(industry, lowerText string) float64 {
+	keywords := s.keywords[industry]
+	if len(keywords) == 0 {
+		return 0
+	}
+
+	found := 0
+	for _, keyword := range keywords {
+		if strings.Contains(lowerText, keyword) {
+			found++
+		}
+	}
+
+	return math.Min(float64(found)/keywordSaturation, 1)
+}
+
+// mentionFactors returns the frequency and position factors of a company.
+// Frequency saturates as count/(count+1); position is 1 for title mentions
+// and halves every positionDecayWords words into the body.
+func mentionFactors(article *Article, company, body string) (float64, float64) {
+	for _, mention := range article.Mentions {
+		if mention.CompanyName != company {
+			continue
+		}
+		position := 1.0
+		if !mention.InTitle {
+			position = math.Pow(0.5, float64(mention.FirstPosition)/positionDecayWords)
+		}
+		return float64(mention.Count) / float64(mention.Count+1), position
+	}
+
+	// Not tagged by entity matching
+	switch {
+	case containsIgnoreCase(article.Title, company):
+		return 0.5, 1
+	case containsIgnoreCase(body, company):
+		return 0.5, 0.5
+	default:
+		return 0, 0
+	}
+}
+
+// recencyFactor decays exponentially with the article's age
+func recencyFactor(published, now time.Time) float64 {
+	if published.IsZero() {
+		return 0
+	}
+	age := now.Sub(published)
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(RecencyHalfLife))
+}
+
+// containsIgnoreCase checks if a string contains a substring (case-insensitive)
+func containsIgnoreCase(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,92 @@
+package news
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDefaultRelevanceScorerFactors(t *testing.T) {
+	now := time.Date(2025, 6, 16, 12, 0, 0, 0, time.UTC)
+	scorer := NewDefaultRelevanceScorer(DefaultRelevanceWeights())
+
+	article := &Article{
+		Title:         "Army awards Lockheed Martin missile contract",
+		Summary:       "The Army awarded Lockheed Martin a contract for missile and radar production.",
+		Companies:     []string{"US War Department", "Lockheed Martin"},
+		PublishedDate: now.Add(-RecencyHalfLife),
+		Mentions: []CompanyMention{
+			{CompanyName: "Lockheed Martin", Count: 3, FirstPosition: 2, InTitle: true},
+		},
+	}
+
+	relevance := scorer.Score(article, RelevanceInput{Industry: "Defense", SourceTrust: 0.8, Now: now})
+
+	if relevance.Company != "Lockheed Martin" {
+		t.Errorf("Expected the mentioned company to be scored, got %q", relevance.Company)
+	}
+	if relevance.Mentions != 0.75 || relevance.Position != 1 {
+		t.Errorf("Expected mention factors 0.75 and 1, got %f and %f", relevance.Mentions, relevance.Position)
+	}
+	// contract, award, missile, radar, army
+	if relevance.Keywords != 1 {
+		t.Errorf("Expected full keyword factor, got %f", relevance.Keywords)
+	}
+	if relevance.SourceTrust != 0.8 {
+		t.Errorf("Expected source trust 0.8, got %f", relevance.SourceTrust)
+	}
+	if math.Abs(relevance.Recency-0.5) > 1e-9 {
+		t.Errorf("Expected recency 0.5 after one half-life, got %f", relevance.Recency)
+	}
+
+	expected := 0.3*0.75 + 0.2*1 + 0.2*1 + 0.15*0.8 + 0.15*0.5
+	if math.Abs(relevance.Score-expected) > 0.001 {
+		t.Errorf("Expected score %.3f, got %.3f", expected, relevance.Score)
+	}
+}
+
+func TestDefaultRelevanceScorerRanksMentionedArticlesHigher(t *testing.T) {
+	now := time.Now()
+	scorer := NewDefaultRelevanceScorer(DefaultRelevanceWeights())
+	input := RelevanceInput{Industry: "Aerospace", SourceTrust: 1, Now: now}
+
+	mentioned := &Article{
+		Title:         "Raytheon Technologies reports engine deliveries",
+		Companies:     []string{"Raytheon Technologies"},
+		PublishedDate: now,
+	}
+	unrelated := &Article{
+		Title:         "Weekly roundup",
+		Summary:       "A summary of the week's events.",
+		Companies:     []string{"Raytheon Technologies"},
+		PublishedDate: now,
+	}
+
+	// Without entity mentions the company name is searched case-insensitively
+	mentionedScore := scorer.Score(mentioned, input)
+	unrelatedScore := scorer.Score(unrelated, input)
+
+	if mentionedScore.Mentions == 0 {
+		t.Error("Expected a case-insensitive title match to count as a mention")
+	}
+	if mentionedScore.Score <= unrelatedScore.Score {
+		t.Errorf("Expected mentioned article to score higher: %.3f <= %.3f", mentionedScore.Score, unrelatedScore.Score)
+	}
+}
+
+func TestContainsIgnoreCase(t *testing.T) {
+	tests := []struct {
+		s, substr string
+		expected  bool
+	}{
+		{"RTX wins Army contract", "rtx", true},
+		{"The contract went to Raytheon Technologies today", "raytheon technologies", true},
+		{"Boeing delivers aircraft", "Raytheon", false},
+	}
+
+	for _, tt := range tests {
+		if got := containsIgnoreCase(tt.s, tt.substr); got != tt.expected {
+			t.Errorf("containsIgnoreCase(%q, %q) = %v, expected %v", tt.s, tt.substr, got, tt.expected)
+		}
+	}
+}
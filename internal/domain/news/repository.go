@@ -2,6 +2,8 @@ package news
 
 import (
 	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Repository defines the interface for news data access
@@ -11,6 +13,10 @@ type Repository interface {
 	
 	// GetByID retrieves an article by its ID
 	GetByID(ctx context.Context, id string) (*Article, error)
+
+	// GetByIDs retrieves the articles with the given IDs, in no particular
+	// order; IDs without an article are skipped
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*Article, error)
 	
 	// GetByGUID retrieves an article by its GUID
 	GetByGUID(ctx context.Context, guid string) (*Article, error)
@@ -26,10 +32,26 @@ type Repository interface {
 	
 	// Update updates an existing article
 	Update(ctx context.Context, article *Article) error
+
+	// UpdateEnrichment saves the extracted text and the tags, categories,
+	// sentiment and relevance derived from it, merging the article's
+	// companies into those already stored
+	UpdateEnrichment(ctx context.Context, article *Article) error
 	
 	// Delete removes an article by ID
 	Delete(ctx context.Context, id string) error
 	
-	// ExistsByGUID checks if an article with the given GUID exists
+	// ExistsByGUID checks if an article with the given GUID exists,
+	// either as its own GUID or as one of its merged sources
 	ExistsByGUID(ctx context.Context, guid string) (bool, error)
+
+	// FindDuplicateCandidates retrieves articles sharing the canonical URL or
+	// a fingerprint band within the publish date range
+	FindDuplicateCandidates(ctx context.Context, query *DuplicateQuery) ([]*Article, error)
+
+	// AddSources merges sources and their companies into an existing article
+	AddSources(ctx context.Context, id primitive.ObjectID, sources []ArticleSource) error
+
+	// Searcher ranks articles matching a full-text query within a filter
+	Searcher
 }
\ No newline at end of file
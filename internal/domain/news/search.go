@@ -0,0 +1,439 @@
+package news
+
+import (
+	"context"
+	"html"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchQuery is a ranked full-text search restricted by a filter
+type SearchQuery struct {
+	// Text is matched against the title, summary and body. Words are
+	// stemmed and stop words ignored. Quoted phrases must appear in the
+	// article; "-word" and -"phrase" exclude articles containing them.
+	Text string
+	// Filter restricts the results. Results are ordered by descending
+	// score, so its sort must be empty or SortByMatch and its order
+	// descending; a cursor from NextCursor replaces Offset.
+	Filter *NewsFilter
+	Limit  int
+	Offset int
+}
+
+// NextCursor returns the cursor of the page after results, or nil when the
+// page is not full and so is the last one
+func (q *SearchQuery) NextCursor(results []*SearchResult) *Cursor {
+	if len(results) == 0 || len(results) < q.Limit {
+		return nil
+	}
+
+	return &Cursor{
+		Sort:   SortByMatch,
+		Order:  SortDescending,
+		Offset: q.Offset + len(results),
+		ID:     results[len(results)-1].Article.ID,
+	}
+}
+
+// SearchResult is an article matching a search, with its rank score and
+// the passages that matched
+type SearchResult struct {
+	Article    *Article
+	Score      float64
+	Highlights []Highlight
+}
+
+// Highlight is a passage of an article field with the matched words
+// wrapped in <mark> tags. The rest of the snippet is HTML-escaped.
+type Highlight struct {
+	Field   string `json:"field"` // title, summary or text
+	Snippet string `json:"snippet"`
+}
+
+// Searcher ranks articles against a full-text query
+type Searcher interface {
+	// Search returns one page of results ordered by descending score and
+	// the total number of matching articles
+	Search(ctx context.Context, query *SearchQuery) ([]*SearchResult, int64, error)
+}
+
+// searchStopWords are ignored in queries and documents
+var searchStopWords = map[string]bool{
+	"a": true, "about": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "did": true, "do": true, "does": true, "for": true, "from": true,
+	"has": true, "have": true, "how": true, "in": true, "is": true, "it": true, "its": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "were": true, "what": true, "when": true, "which": true, "who": true,
+	"will": true, "with": true,
+}
+
+// SearchTerms returns the stemmed, lower-case terms of text without stop
+// words and single characters
+func SearchTerms(text string) []string {
+	tokens := tokenize(strings.ToLower(text))
+	terms := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if utf8.RuneCountInString(token) > 1 && !searchStopWords[token] {
+			terms = append(terms, stem(token))
+		}
+	}
+	return terms
+}
+
+// Keywords returns the distinct lower-case words of text without stop words
+// and single characters, unstemmed and in order
+func Keywords(text string) []string {
+	var keywords []string
+	seen := make(map[string]bool)
+	for _, token := range tokenize(strings.ToLower(text)) {
+		if utf8.RuneCountInString(token) > 1 && !searchStopWords[token] && !seen[token] {
+			seen[token] = true
+			keywords = append(keywords, token)
+		}
+	}
+	return keywords
+}
+
+// searchText is parsed query text
+type searchText struct {
+	include         []string   // Terms ranking the articles, including those of phrases
+	exclude         []string   // Terms whose articles are excluded
+	phrases         [][]string // Phrases the articles must contain
+	excludedPhrases [][]string // Phrases whose articles are excluded
+}
+
+// parseSearchText splits query text into words, "-word" exclusions, quoted
+// phrases and -"phrase" exclusions, as MongoDB text search does
+func parseSearchText(text string) searchText {
+	var parsed searchText
+	negated := false
+	for i, part := range strings.Split(text, `"`) {
+		// Odd parts were inside quotes
+		if i%2 == 1 {
+			terms := SearchTerms(part)
+			switch {
+			case len(terms) == 0:
+			case negated:
+				parsed.excludedPhrases = append(parsed.excludedPhrases, terms)
+			default:
+				parsed.phrases = append(parsed.phrases, terms)
+				parsed.include = append(parsed.include, terms...)
+			}
+			continue
+		}
+
+		// A "-" just before a quote negates the phrase
+		negated = strings.HasSuffix(part, "-")
+		for _, word := range strings.Fields(part) {
+			if strings.HasPrefix(word, "-") {
+				parsed.exclude = append(parsed.exclude, SearchTerms(word[1:])...)
+				continue
+			}
+			parsed.include = append(parsed.include, SearchTerms(word)...)
+		}
+	}
+	return parsed
+}
+
+// stem strips common English suffixes so that "contracts", "contracted"
+// and "contracting" match "contract"
+func stem(word string) string {
+	if utf8.RuneCountInString(word) <= 3 {
+		return word
+	}
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ing") && len(word) > 5:
+		return word[:len(word)-3]
+	case strings.HasSuffix(word, "ed") && len(word) > 4:
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+const (
+	// bm25K1 controls term frequency saturation
+	bm25K1 = 1.2
+	// bm25B controls document length normalisation
+	bm25B = 0.75
+)
+
+// searchFieldWeights multiply term frequencies by the field they occur in,
+// mirroring the weights of the MongoDB text index
+var searchFieldWeights = struct{ title, summary, body float64 }{title: 10, summary: 5, body: 1}
+
+// bm25Document is an indexed article with its weighted term frequencies
+// and the terms of each field, in order, for phrase matching
+type bm25Document struct {
+	article *Article
+	freq    map[string]float64
+	fields  [][]string
+	length  float64
+}
+
+// BM25Index is an in-memory full-text index ranking articles with BM25. It
+// stands in for the MongoDB text index in tests and offline evaluation.
+type BM25Index struct {
+	documents []*bm25Document
+	docFreq   map[string]int
+	total     float64 // Sum of document lengths
+}
+
+// NewBM25Index indexes the articles
+func NewBM25Index(articles []*Article) *BM25Index {
+	index := &BM25Index{docFreq: make(map[string]int)}
+	for _, article := range articles {
+		index.Add(article)
+	}
+	return index
+}
+
+// Add indexes an article
+func (x *BM25Index) Add(article *Article) {
+	doc := &bm25Document{article: article, freq: make(map[string]float64)}
+
+	fields := []struct {
+		text   string
+		weight float64
+	}{
+		{article.Title, searchFieldWeights.title},
+		{article.Summary, searchFieldWeights.summary},
+		{article.BodyText(), searchFieldWeights.body},
+	}
+	if article.BodyText() == article.Summary {
+		fields = fields[:2]
+	}
+
+	for _, field := range fields {
+		terms := SearchTerms(field.text)
+		doc.fields = append(doc.fields, terms)
+		for _, term := range terms {
+			if doc.freq[term] == 0 {
+				x.docFreq[term]++
+			}
+			doc.freq[term] += field.weight
+			doc.length += field.weight
+		}
+	}
+
+	x.documents = append(x.documents, doc)
+	x.total += doc.length
+}
+
+// Search implements Searcher
+func (x *BM25Index) Search(ctx context.Context, query *SearchQuery) ([]*SearchResult, int64, error) {
+	text := parseSearchText(query.Text)
+	if len(text.include) == 0 || len(x.documents) == 0 {
+		return nil, 0, nil
+	}
+
+	avgLength := x.total / float64(len(x.documents))
+	var results []*SearchResult
+	for _, doc := range x.documents {
+		if query.Filter != nil && !query.Filter.Matches(doc.article) {
+			continue
+		}
+		if containsAny(doc.freq, text.exclude) || !doc.containsAll(text.phrases) || doc.containsAnyPhrase(text.excludedPhrases) {
+			continue
+		}
+
+		score := 0.0
+		for _, term := range text.include {
+			tf := doc.freq[term]
+			if tf == 0 {
+				continue
+			}
+			n := float64(x.docFreq[term])
+			idf := math.Log(1 + (float64(len(x.documents))-n+0.5)/(n+0.5))
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*doc.length/avgLength))
+		}
+		if score > 0 {
+			results = append(results, &SearchResult{Article: doc.article, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].Score > results[b].Score
+	})
+
+	total := int64(len(results))
+	if query.Offset >= len(results) {
+		return nil, total, nil
+	}
+	results = results[query.Offset:]
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, total, nil
+}
+
+// containsAny reports whether any of the terms occurs in the document
+func containsAny(freq map[string]float64, terms []string) bool {
+	for _, term := range terms {
+		if freq[term] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAll reports whether the document contains every phrase
+func (d *bm25Document) containsAll(phrases [][]string) bool {
+	for _, phrase := range phrases {
+		if !d.containsPhrase(phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAnyPhrase reports whether the document contains any of the phrases
+func (d *bm25Document) containsAnyPhrase(phrases [][]string) bool {
+	for _, phrase := range phrases {
+		if d.containsPhrase(phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPhrase reports whether a field of the document has the phrase's
+// terms in sequence
+func (d *bm25Document) containsPhrase(phrase []string) bool {
+	for _, field := range d.fields {
+		for i := 0; i+len(phrase) <= len(field); i++ {
+			if slices.Equal(field[i:i+len(phrase)], phrase) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maxSnippetRunes bounds the length of summary and text highlights
+const maxSnippetRunes = 200
+
+// HighlightArticle returns the passages of the title, summary and body
+// matching the query text
+func HighlightArticle(article *Article, text string) []Highlight {
+	include := parseSearchText(text).include
+	terms := make(map[string]bool, len(include))
+	for _, term := range include {
+		terms[term] = true
+	}
+
+	var highlights []Highlight
+	fields := []struct {
+		name, text string
+		limit      int
+	}{
+		{"title", article.Title, 0},
+		{"summary", article.Summary, maxSnippetRunes},
+		{"text", article.BodyText(), maxSnippetRunes},
+	}
+	for _, field := range fields {
+		if field.name == "text" && field.text == article.Summary {
+			continue
+		}
+		if snippet, ok := highlightText(field.text, terms, field.limit); ok {
+			highlights = append(highlights, Highlight{Field: field.name, Snippet: snippet})
+		}
+	}
+	return highlights
+}
+
+// wordSpan is the byte range of a word in a text
+type wordSpan struct{ start, end int }
+
+// wordSpans returns the byte ranges of the words of text
+func wordSpans(text string) []wordSpan {
+	var spans []wordSpan
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			spans = append(spans, wordSpan{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, wordSpan{start, len(text)})
+	}
+	return spans
+}
+
+// highlightText marks the words of text matching the terms. With a limit,
+// the snippet is a window of about limit runes starting shortly before the
+// first match. It reports false when no word matches.
+func highlightText(text string, terms map[string]bool, limit int) (string, bool) {
+	spans := wordSpans(text)
+	var matches []wordSpan
+	for _, span := range spans {
+		word := strings.ToLower(text[span.start:span.end])
+		if !searchStopWords[word] && terms[stem(word)] {
+			matches = append(matches, span)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(text)
+	if limit > 0 && utf8.RuneCountInString(text) > limit {
+		// Start a few words before the first match, at a word boundary
+		from = matches[0].start
+		for i := len(spans) - 1; i >= 0; i-- {
+			if spans[i].start <= matches[0].start {
+				from = spans[max(i-5, 0)].start
+				break
+			}
+		}
+		to = from
+		for runes := 0; to < len(text) && runes < limit; runes++ {
+			_, size := utf8.DecodeRuneInString(text[to:])
+			to += size
+		}
+		// End at a word boundary
+		for _, span := range spans {
+			if span.start < to && span.end > to {
+				to = span.start
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, match := range matches {
+		if match.start < from || match.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:match.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[match.start:match.end]))
+		b.WriteString("</mark>")
+		pos = match.end
+	}
+	b.WriteString(html.EscapeString(strings.TrimRightFunc(text[pos:to], unicode.IsSpace)))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
@@ -0,0 +1,199 @@
+package news
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testSearchArticles() []*Article {
+	return []*Article{
+		{
+			Title:     "Army awards hypersonic missile contract",
+			Summary:   "Lockheed Martin will build hypersonic glide bodies for the Army.",
+			Companies: []string{"Lockheed Martin"},
+		},
+		{
+			Title:     "Boeing delivers tanker to Air Force",
+			Summary:   "The KC-46 delivery follows months of delays. A hypersonic test is planned next year.",
+			Companies: []string{"Boeing"},
+		},
+		{
+			Title:     "Raytheon radar passes flight test",
+			Summary:   "The radar tracked several targets during the test.",
+			Companies: []string{"Raytheon Technologies"},
+		},
+	}
+}
+
+func TestBM25IndexSearch(t *testing.T) {
+	articles := testSearchArticles()
+	index := NewBM25Index(articles)
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []*Article
+	}{
+		{"title match ranks first", "hypersonic missiles", []*Article{articles[0], articles[1]}},
+		{"stemmed terms", "tested", []*Article{articles[2], articles[1]}},
+		{"excluded term", "hypersonic -boeing", []*Article{articles[0]}},
+		{"required phrase", `"hypersonic glide" test`, []*Article{articles[0]}},
+		{"phrase words not adjacent", `"tracked targets"`, nil},
+		{"phrase across stop words", `"tracked several targets"`, []*Article{articles[2]}},
+		{"excluded phrase", `hypersonic -"test is planned"`, []*Article{articles[0]}},
+		{"stop words only", "the of and", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total, err := index.Search(context.Background(), &SearchQuery{Text: tt.text})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if total != int64(len(tt.expected)) || len(results) != len(tt.expected) {
+				t.Fatalf("Expected %d results, got %d (total %d)", len(tt.expected), len(results), total)
+			}
+			for i, result := range results {
+				if result.Article != tt.expected[i] {
+					t.Errorf("Result %d: expected %q, got %q", i, tt.expected[i].Title, result.Article.Title)
+				}
+			}
+		})
+	}
+}
+
+func TestBM25IndexSearchFilterAndPaging(t *testing.T) {
+	articles := testSearchArticles()
+	index := NewBM25Index(articles)
+
+	results, total, err := index.Search(context.Background(), &SearchQuery{
+		Text:   "hypersonic test",
+		Filter: &NewsFilter{ExcludeCompanies: []string{"Raytheon Technologies"}},
+		Limit:  1,
+		Offset: 1,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 2 || len(results) != 1 {
+		t.Fatalf("Expected 1 of 2 results, got %d of %d", len(results), total)
+	}
+}
+
+func TestSearchQueryNextCursor(t *testing.T) {
+	articles := testSearchArticles()
+	results := []*SearchResult{{Article: articles[0]}, {Article: articles[1]}}
+
+	query := &SearchQuery{Text: "hypersonic", Limit: 2, Offset: 4}
+	cursor := query.NextCursor(results)
+	if cursor == nil || cursor.Sort != SortByMatch || cursor.Order != SortDescending || cursor.Offset != 6 {
+		t.Errorf("Expected a match cursor at offset 6, got %+v", cursor)
+	}
+
+	query.Limit = 3
+	if cursor := query.NextCursor(results); cursor != nil {
+		t.Errorf("Expected no cursor for the last page, got %+v", cursor)
+	}
+}
+
+func TestSearchArticlesSortOptions(t *testing.T) {
+	service := &Service{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	queries := map[string]*SearchQuery{
+		"another sort":    {Text: "radar", Filter: &NewsFilter{Sort: SortByDate}},
+		"ascending order": {Text: "radar", Filter: &NewsFilter{Sort: SortByMatch, Order: SortAscending}},
+		"date cursor": {Text: "radar", Filter: &NewsFilter{
+			Sort:  SortByMatch,
+			After: &Cursor{Sort: SortByDate, Order: SortDescending, ID: primitive.NewObjectID()},
+		}},
+	}
+	for name, query := range queries {
+		if _, _, err := service.SearchArticles(context.Background(), query); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("%s: expected ErrInvalidFilter, got %v", name, err)
+		}
+	}
+
+	if _, _, err := service.SearchArticles(context.Background(), &SearchQuery{Text: "-radar"}); !errors.Is(err, ErrEmptySearchQuery) {
+		t.Errorf("Expected ErrEmptySearchQuery for exclusions only, got %v", err)
+	}
+	if _, _, err := service.ListArticles(context.Background(), &NewsFilter{Sort: SortByMatch}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected listing by match to be rejected, got %v", err)
+	}
+}
+
+func TestHighlightArticle(t *testing.T) {
+	article := &Article{
+		Title:   "Army awards <hypersonic> missile contract",
+		Summary: strings.Repeat("Unrelated words fill the opening paragraph. ", 10) + "The contract covers missiles and launchers.",
+	}
+
+	highlights := HighlightArticle(article, "missile contracts")
+	if len(highlights) != 2 {
+		t.Fatalf("Expected title and summary highlights, got %+v", highlights)
+	}
+
+	title := highlights[0]
+	expected := "Army awards &lt;hypersonic&gt; <mark>missile</mark> <mark>contract</mark>"
+	if title.Field != "title" || title.Snippet != expected {
+		t.Errorf("Expected title snippet %q, got %+v", expected, title)
+	}
+
+	summary := highlights[1]
+	if !strings.HasPrefix(summary.Snippet, "…") || !strings.Contains(summary.Snippet, "The <mark>contract</mark> covers <mark>missiles</mark>") {
+		t.Errorf("Unexpected summary snippet %q", summary.Snippet)
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	got := SearchTerms("The Army's contracts for radars and missiles")
+	expected := []string{"army", "contract", "radar", "missile"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestNewsFilterMatches(t *testing.T) {
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	article := &Article{
+		Title:         "Raytheon wins radar award",
+		Companies:     []string{"Raytheon Technologies", "Lockheed Martin"},
+		PublishedDate: now,
+		Categories:    []Category{CategoryContracts},
+		Sentiment: &Sentiment{Score: 0.2, Label: SentimentPositive, Companies: []CompanySentiment{
+			{CompanyName: "Lockheed Martin", Score: -0.4, Label: SentimentNegative},
+		}},
+	}
+	negative := -0.1
+
+	tests := []struct {
+		name     string
+		filter   NewsFilter
+		expected bool
+	}{
+		{"empty filter", NewsFilter{}, true},
+		{"all companies", NewsFilter{Companies: []string{"Raytheon Technologies", "Boeing"}, CompanyMatch: MatchAllCompany}, false},
+		{"any company", NewsFilter{Companies: []string{"Raytheon Technologies", "Boeing"}}, true},
+		{"excluded category", NewsFilter{ExcludeCategories: []Category{CategoryContracts}}, false},
+		{"date range", NewsFilter{EndDate: &yesterday}, false},
+		{"query", NewsFilter{Query: `"radar award"`}, true},
+		{"company sentiment", NewsFilter{Company: "Lockheed Martin", MaxSentiment: &negative}, true},
+		{"overall sentiment", NewsFilter{MaxSentiment: &negative}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(article); got != tt.expected {
+				t.Errorf("Matches() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SentimentLabel buckets a sentiment score
+type SentimentLabel string
+
+const (
+	SentimentPositive SentimentLabel = "positive"
+	SentimentNeutral  SentimentLabel = "neutral"
+	SentimentNegative SentimentLabel = "negative"
+)
+
+// sentimentNeutralBand is the largest absolute score still labelled neutral
+const sentimentNeutralBand = 0.1
+
+// LabelForScore returns the label of a score between -1 and 1
+func LabelForScore(score float64) SentimentLabel {
+	switch {
+	case score > sentimentNeutralBand:
+		return SentimentPositive
+	case score < -sentimentNeutralBand:
+		return SentimentNegative
+	default:
+		return SentimentNeutral
+	}
+}
+
+// ParseSentimentLabel converts a string into a sentiment label
+func ParseSentimentLabel(value string) (SentimentLabel, error) {
+	label := SentimentLabel(strings.ToLower(strings.TrimSpace(value)))
+	switch label {
+	case SentimentPositive, SentimentNeutral, SentimentNegative:
+		return label, nil
+	default:
+		return "", fmt.Errorf("%w: unknown sentiment %q", ErrInvalidFilter, value)
+	}
+}
+
+// Sentiment is the tone of an article overall and towards each company it
+// mentions. Scores range from -1 (negative) to 1 (positive).
+type Sentiment struct {
+	Score      float64            `json:"score" bson:"score"`
+	Label      SentimentLabel     `json:"label" bson:"label"`
+	Companies  []CompanySentiment `json:"companies,omitempty" bson:"companies,omitempty"`
+	AnalyzedAt time.Time          `json:"analyzed_at" bson:"analyzed_at"`
+}
+
+// CompanySentiment is the tone of the sentences mentioning a company
+type CompanySentiment struct {
+	CompanyName string         `json:"company_name" bson:"company_name"`
+	Score       float64        `json:"score" bson:"score"`
+	Label       SentimentLabel `json:"label" bson:"label"`
+}
+
+// SentimentAnalyzer scores the tone of an article. companies finds the
+// companies mentioned in a passage and may be nil.
+type SentimentAnalyzer interface {
+	Analyze(ctx context.Context, article *Article, companies *EntityMatcher) (*Sentiment, error)
+}
+
+const (
+	// sentimentNormalization controls how quickly summed valences approach
+	// ±1: a sum of 3 scores about 0.6
+	sentimentNormalization = 15
+	// negationWindow is how many words after a negator have their valence flipped
+	negationWindow = 3
+	// titleSentimentWeight multiplies the valence of words in the title
+	titleSentimentWeight = 2
+)
+
+// SentimentLexicon holds the valence of words in defense and aerospace news
+var SentimentLexicon = map[string]float64{
+	// Positive
+	"award": 1, "awarded": 1, "awards": 1, "win": 1, "wins": 1, "won": 1, "selected": 1,
+	"secures": 1, "secured": 1, "growth": 1, "grew": 1, "record": 1, "strong": 1,
+	"beat": 1, "beats": 1, "exceeded": 1, "exceeds": 1, "raised": 0.5, "raises": 0.5,
+	"success": 1, "successful": 1, "successfully": 1, "milestone": 1, "approved": 1,
+	"approval": 1, "delivered": 0.5, "expands": 1, "expansion": 1, "profit": 1,
+	"gain": 1, "gains": 1, "surge": 1, "surged": 1, "upgrade": 0.5, "upgraded": 1,
+	"breakthrough": 1, "boost": 1, "boosted": 1, "outperform": 1, "outperformed": 1,
+	"improved": 1, "improvement": 1, "robust": 1, "momentum": 0.5, "certified": 1,
+	"ahead": 0.5, "innovative": 0.5, "partnership": 0.5, "advances": 0.5,
+	// Negative
+	"delay": -1, "delayed": -1, "delays": -1, "loss": -1, "losses": -1, "lawsuit": -1,
+	"sued": -1, "protest": -1, "protested": -1, "cancel": -1, "cancelled": -1,
+	"canceled": -1, "cancellation": -1, "crash": -1, "crashed": -1, "failure": -1,
+	"failed": -1, "fails": -1, "decline": -1, "declined": -1, "declines": -1,
+	"drop": -1, "dropped": -1, "fell": -1, "cuts": -0.5, "layoffs": -1, "overrun": -1,
+	"overruns": -1, "investigation": -1, "probe": -1, "fined": -1, "penalty": -1,
+	"grounded": -1, "grounding": -1, "recall": -1, "breach": -1, "weak": -1,
+	"missed": -1, "downgrade": -1, "downgraded": -1, "shortfall": -1, "halt": -1,
+	"halted": -1, "suspended": -1, "suspension": -1, "deficiency": -1,
+	"deficiencies": -1, "defect": -1, "defects": -1, "accident": -1, "mishap": -1,
+	"terminated": -1, "termination": -1, "shortage": -1, "shortages": -1,
+	"killed": -1, "fatal": -1, "charge": -0.5, "writedown": -1, "impairment": -1,
+}
+
+// negators flip the valence of the following words
+var negators = map[string]bool{
+	"not": true, "no": true, "never": true, "without": true, "nor": true, "cannot": true,
+}
+
+// negativeContractionRe matches negative contractions such as "didn't",
+// "won't" and "can't", with straight or curly apostrophes
+var negativeContractionRe = regexp.MustCompile(`(?i)\b(?:do|does|did|is|are|was|were|has|have|had|wo|would|ca|could|should|must|need|ai)n['’]t\b`)
+
+// LexiconSentimentAnalyzer scores sentiment offline by summing the valence
+// of lexicon words, flipping words that follow a negation
+type LexiconSentimentAnalyzer struct {
+	lexicon map[string]float64
+	now     func() time.Time
+}
+
+// NewLexiconSentimentAnalyzer creates an analyzer with the built-in lexicon
+func NewLexiconSentimentAnalyzer() *LexiconSentimentAnalyzer {
+	return &LexiconSentimentAnalyzer{lexicon: SentimentLexicon, now: time.Now}
+}
+
+// Analyze implements SentimentAnalyzer. The overall score covers the title
+// and body; each company is scored on the title and the sentences mentioning it.
+func (a *LexiconSentimentAnalyzer) Analyze(ctx context.Context, article *Article, companies *EntityMatcher) (*Sentiment, error) {
+	titleValence := a.valence(article.Title) * titleSentimentWeight
+	total := titleValence
+
+	companyValence := make(map[string]float64)
+	var order []string
+	addCompany := func(name string, valence float64) {
+		if _, seen := companyValence[name]; !seen {
+			order = append(order, name)
+		}
+		companyValence[name] += valence
+	}
+
+	if companies != nil {
+		for _, mention := range companies.Match(article.Title, "") {
+			addCompany(mention.CompanyName, titleValence)
+		}
+	}
+
+	for _, sentence := range splitSentences(article.BodyText()) {
+		valence := a.valence(sentence)
+		total += valence
+		if companies == nil {
+			continue
+		}
+		for _, mention := range companies.Match("", sentence) {
+			addCompany(mention.CompanyName, valence)
+		}
+	}
+
+	sentiment := &Sentiment{
+		Score:      normalizeValence(total),
+		AnalyzedAt: a.now(),
+	}
+	sentiment.Label = LabelForScore(sentiment.Score)
+
+	for _, name := range order {
+		score := normalizeValence(companyValence[name])
+		sentiment.Companies = append(sentiment.Companies, CompanySentiment{
+			CompanyName: name,
+			Score:       score,
+			Label:       LabelForScore(score),
+		})
+	}
+
+	return sentiment, nil
+}
+
+// valence sums the valence of the lexicon words in the text
+func (a *LexiconSentimentAnalyzer) valence(text string) float64 {
+	// Negative contractions become "not" before tokenizing, as "didn't"
+	// would tokenize as "didn", "t" like "AT&T" tokenizes as "at", "t"
+	text = negativeContractionRe.ReplaceAllString(text, " not ")
+	tokens := tokenize(strings.ToLower(text))
+
+	total := 0.0
+	negatedUntil := -1
+	for i, token := range tokens {
+		if negators[token] {
+			negatedUntil = i + negationWindow
+			continue
+		}
+
+		valence := a.lexicon[token]
+		if valence != 0 && i <= negatedUntil {
+			valence = -valence
+		}
+		total += valence
+	}
+
+	return total
+}
+
+// normalizeValence maps a summed valence into (-1, 1)
+func normalizeValence(total float64) float64 {
+	score := total / math.Sqrt(total*total+sentimentNormalization)
+	return math.Round(score*1000) / 1000
+}
+
+// splitSentences splits text into sentences
+func splitSentences(text string) []string {
+	runes := []rune(text)
+	spans := sentenceSpans(runes)
+	sentences := make([]string, len(spans))
+	for i, s := range spans {
+		sentences[i] = string(runes[s.start:s.end])
+	}
+	return sentences
+}
@@ -0,0 +1,108 @@
+package news
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLexiconSentimentAnalyzerAnalyze(t *testing.T) {
+	analyzer := NewLexiconSentimentAnalyzer()
+
+	tests := []struct {
+		name     string
+		title    string
+		body     string
+		expected SentimentLabel
+	}{
+		{
+			name:     "contract win",
+			title:    "Lockheed Martin wins $2B missile award",
+			body:     "The company secured a record order after a successful test.",
+			expected: SentimentPositive,
+		},
+		{
+			name:     "program trouble",
+			title:    "F-35 deliveries halted after engine defects",
+			body:     "The program faces further delays and cost overruns.",
+			expected: SentimentNegative,
+		},
+		{
+			name:     "factual announcement",
+			title:    "Contracts for June 16",
+			body:     "The Navy issued a solicitation for radar maintenance.",
+			expected: SentimentNeutral,
+		},
+		{
+			name:     "negated positive",
+			title:    "Boeing did not win the tanker competition",
+			expected: SentimentNegative,
+		},
+		{
+			name:     "negative contraction",
+			title:    "Boeing didn't win the tanker competition",
+			expected: SentimentNegative,
+		},
+		{
+			name:     "curly negative contraction",
+			title:    "Boeing can’t win the tanker competition",
+			expected: SentimentNegative,
+		},
+		{
+			name:     "ampersand name is not a negation",
+			title:    "AT&T wins Army network contract",
+			expected: SentimentPositive,
+		},
+		{
+			name:     "split letters are not a negation",
+			title:    "Ready at T minus ten, the launch was a success",
+			expected: SentimentPositive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentiment, err := analyzer.Analyze(context.Background(), &Article{Title: tt.title, Summary: tt.body}, nil)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if sentiment.Label != tt.expected {
+				t.Errorf("Expected %s, got %s (score %v)", tt.expected, sentiment.Label, sentiment.Score)
+			}
+			if sentiment.Score < -1 || sentiment.Score > 1 {
+				t.Errorf("Score %v out of range", sentiment.Score)
+			}
+		})
+	}
+}
+
+func TestLexiconSentimentAnalyzerPerCompany(t *testing.T) {
+	analyzer := NewLexiconSentimentAnalyzer()
+	article := &Article{
+		Title:   "Army picks new air defense radar",
+		Summary: "Raytheon won the competition with a strong bid. Lockheed Martin protested the decision after its bid failed.",
+	}
+
+	sentiment, err := analyzer.Analyze(context.Background(), article, testEntityMatcher())
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if len(sentiment.Companies) != 2 {
+		t.Fatalf("Expected 2 company sentiments, got %+v", sentiment.Companies)
+	}
+	if got := sentiment.Companies[0]; got.CompanyName != "Raytheon Technologies" || got.Label != SentimentPositive {
+		t.Errorf("Unexpected Raytheon sentiment: %+v", got)
+	}
+	if got := sentiment.Companies[1]; got.CompanyName != "Lockheed Martin" || got.Label != SentimentNegative {
+		t.Errorf("Unexpected Lockheed sentiment: %+v", got)
+	}
+}
+
+func TestParseSentimentLabel(t *testing.T) {
+	if label, err := ParseSentimentLabel(" Positive "); err != nil || label != SentimentPositive {
+		t.Errorf("Expected positive, got %q (error %v)", label, err)
+	}
+	if _, err := ParseSentimentLabel("bullish"); err == nil {
+		t.Error("Expected an error for an unknown label")
+	}
+}
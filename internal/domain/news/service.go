@@ -2,6 +2,7 @@ package news
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -10,15 +11,34 @@ import (
 
 // Service handles business logic for news operations
 type Service struct {
-	repo   Repository
-	logger *slog.Logger
+	repo       Repository
+	scorer     RelevanceScorer
+	classifier Classifier
+	sentiment  SentimentAnalyzer
+	logger     *slog.Logger
 }
 
-// NewService creates a new news service
-func NewService(repo Repository, logger *slog.Logger) *Service {
+// NewService creates a new news service.
+// scorer, classifier and sentiment are optional; when nil, the default
+// relevance scorer, the rule-based classifier and the lexicon sentiment
+// analyzer are used.
+func NewService(repo Repository, scorer RelevanceScorer, classifier Classifier, sentiment SentimentAnalyzer, logger *slog.Logger) *Service {
+	if scorer == nil {
+		scorer = NewDefaultRelevanceScorer(DefaultRelevanceWeights())
+	}
+	if classifier == nil {
+		classifier = NewRuleClassifier()
+	}
+	if sentiment == nil {
+		sentiment = NewLexiconSentimentAnalyzer()
+	}
+
 	return &Service{
-		repo:   repo,
-		logger: logger,
+		repo:       repo,
+		scorer:     scorer,
+		classifier: classifier,
+		sentiment:  sentiment,
+		logger:     logger,
 	}
 }
 
@@ -40,6 +60,22 @@ func (s *Service) CreateArticle(ctx context.Context, article *Article) error {
 		return ErrDuplicateArticle
 	}
 
+	// Merge near-duplicates (syndicated or republished stories) into the stored article
+	article.PrepareDeduplication()
+	duplicate, err := s.findDuplicate(ctx, article)
+	if err != nil {
+		s.logger.Error("Error checking for duplicate articles", "error", err, "guid", article.GUID)
+		return err
+	}
+	if duplicate != nil {
+		if err := s.repo.AddSources(ctx, duplicate.ID, article.Sources); err != nil {
+			s.logger.Error("Failed to merge duplicate article", "error", err, "id", duplicate.ID.Hex(), "guid", article.GUID)
+			return err
+		}
+		s.logger.Info("Merged duplicate article", "id", duplicate.ID.Hex(), "guid", article.GUID, "source", article.FeedSource)
+		return ErrArticleMerged
+	}
+
 	// Set processed date
 	article.ProcessedDate = time.Now()
 
@@ -57,6 +93,52 @@ func (s *Service) CreateArticle(ctx context.Context, article *Article) error {
 	return nil
 }
 
+// findDuplicate returns the stored article that reports the same story, if any
+func (s *Service) findDuplicate(ctx context.Context, article *Article) (*Article, error) {
+	candidates, err := s.repo.FindDuplicateCandidates(ctx, article.DuplicateQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if article.IsDuplicateOf(candidate) {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// UpdateArticle saves changes to an existing article
+func (s *Service) UpdateArticle(ctx context.Context, article *Article) error {
+	if err := article.Validate(); err != nil {
+		s.logger.Error("Invalid article data", "error", err)
+		return err
+	}
+
+	if err := s.repo.Update(ctx, article); err != nil {
+		s.logger.Error("Failed to update article", "error", err, "id", article.ID.Hex())
+		return err
+	}
+
+	return nil
+}
+
+// UpdateArticleEnrichment saves the enrichment of an existing article
+// without overwriting the sources merged into it since it was stored
+func (s *Service) UpdateArticleEnrichment(ctx context.Context, article *Article) error {
+	if err := article.Validate(); err != nil {
+		s.logger.Error("Invalid article data", "error", err)
+		return err
+	}
+
+	if err := s.repo.UpdateEnrichment(ctx, article); err != nil {
+		s.logger.Error("Failed to update article enrichment", "error", err, "id", article.ID.Hex())
+		return err
+	}
+
+	return nil
+}
+
 // GetArticleByID retrieves an article by its ID
 func (s *Service) GetArticleByID(ctx context.Context, id string) (*Article, error) {
 	article, err := s.repo.GetByID(ctx, id)
@@ -67,6 +149,33 @@ func (s *Service) GetArticleByID(ctx context.Context, id string) (*Article, erro
 	return article, nil
 }
 
+// GetArticlesByIDs retrieves the articles with the given IDs in one query,
+// in the order of the IDs; IDs without an article are skipped
+func (s *Service) GetArticlesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*Article, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to get articles by ID", "error", err, "count", len(ids))
+		return nil, err
+	}
+
+	byID := make(map[primitive.ObjectID]*Article, len(found))
+	for _, article := range found {
+		byID[article.ID] = article
+	}
+	articles := make([]*Article, 0, len(found))
+	for _, id := range ids {
+		if article, ok := byID[id]; ok {
+			articles = append(articles, article)
+			delete(byID, id)
+		}
+	}
+	return articles, nil
+}
+
 // Get article by the Company name
 func (s *Service) GetArticlesByCompany(ctx context.Context, companyName string) ([]*Article, error) {
 	articles, err := s.repo.GetByCompany(ctx, companyName)
@@ -88,6 +197,11 @@ func (s *Service) ListArticles(ctx context.Context, filter *NewsFilter) ([]*Arti
 		filter.Limit = 50
 	}
 
+	// Ranking by match is a full-text search; see SearchArticles
+	if filter.Sort == SortByMatch {
+		return nil, 0, fmt.Errorf("%w: sort by match requires a search", ErrInvalidFilter)
+	}
+
 	// Validate filter
 	if err := s.validateFilter(filter); err != nil {
 		s.logger.Error("Invalid filter", "error", err)
@@ -109,7 +223,53 @@ func (s *Service) ListArticles(ctx context.Context, filter *NewsFilter) ([]*Arti
 	return articles, count, nil
 }
 
-// ProcessRSSFeedItem processes an RSS feed item into an article
+// SearchArticles ranks articles against the query text within its filter and
+// highlights the matching passages
+func (s *Service) SearchArticles(ctx context.Context, query *SearchQuery) ([]*SearchResult, int64, error) {
+	if len(parseSearchText(query.Text).include) == 0 {
+		return nil, 0, ErrEmptySearchQuery
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = 50
+	}
+	if query.Limit > 1000 || query.Offset < 0 {
+		return nil, 0, ErrInvalidFilter
+	}
+
+	// Results are ordered by descending score; a cursor continues at the
+	// offset of the next page
+	if filter := query.Filter; filter != nil {
+		if (filter.Sort != "" && filter.Sort != SortByMatch) || filter.EffectiveOrder() != SortDescending {
+			return nil, 0, fmt.Errorf("%w: search results are ordered by descending match score", ErrInvalidFilter)
+		}
+		if err := s.validateFilter(filter); err != nil {
+			s.logger.Error("Invalid filter", "error", err)
+			return nil, 0, err
+		}
+		if filter.After != nil {
+			if query.Offset > 0 {
+				return nil, 0, ErrInvalidFilter
+			}
+			query.Offset = filter.After.Offset
+		}
+	}
+
+	results, total, err := s.repo.Search(ctx, query)
+	if err != nil {
+		s.logger.Error("Failed to search articles", "error", err, "query", query.Text)
+		return nil, 0, err
+	}
+
+	for _, result := range results {
+		result.Highlights = HighlightArticle(result.Article, query.Text)
+	}
+
+	return results, total, nil
+}
+
+// ProcessRSSFeedItem processes an RSS feed item into an article.
+// The article is unscored until ScoreRelevance is called.
 func (s *Service) ProcessRSSFeedItem(ctx context.Context, item *RSSFeedItem, companyName, feedSource string) (*Article, error) {
 	article := &Article{
 		Title:          item.Title,
@@ -117,36 +277,43 @@ func (s *Service) ProcessRSSFeedItem(ctx context.Context, item *RSSFeedItem, com
 		SourceURL:      item.Link,
 		Companies:      []string{companyName},
 		PublishedDate:  item.PublishDate,
-		RelevanceScore: s.calculateRelevanceScore(item, companyName),
 		FeedSource:     feedSource,
 		Content:        item.Content,
+		ContentHTML:    item.ContentHTML,
+		Media:          item.Media,
 		GUID:           item.GUID,
 	}
 
 	return article, nil
 }
 
-// calculateRelevanceScore calculates a basic relevance score for the article
-func (s *Service) calculateRelevanceScore(item *RSSFeedItem, companyName string) float64 {
-	// Simple relevance scoring - can be enhanced with ML/NLP
-	score := 0.5 // Base score
-	
-	// Check if company name appears in title (higher weight)
-	if containsIgnoreCase(item.Title, companyName) {
-		score += 0.3
-	}
-	
-	// Check if company name appears in summary
-	if containsIgnoreCase(item.Summary, companyName) {
-		score += 0.2
+// ScoreRelevance scores the article and stores the score with its per-factor breakdown
+func (s *Service) ScoreRelevance(article *Article, input RelevanceInput) {
+	article.Relevance = s.scorer.Score(article, input)
+	article.RelevanceScore = article.Relevance.Score
+}
+
+// ClassifyArticle assigns taxonomy categories to the article. A failing
+// classifier leaves the article's categories unchanged.
+func (s *Service) ClassifyArticle(ctx context.Context, article *Article) {
+	categories, err := s.classifier.Classify(ctx, article)
+	if err != nil {
+		s.logger.Warn("Failed to classify article", "error", err, "title", article.Title)
+		return
 	}
-	
-	// Ensure score is within bounds
-	if score > 1.0 {
-		score = 1.0
+	article.Categories = categories
+}
+
+// AnalyzeSentiment scores the tone of the article overall and towards the
+// companies found by the matcher. A failing analyzer leaves the article's
+// sentiment unchanged.
+func (s *Service) AnalyzeSentiment(ctx context.Context, article *Article, companies *EntityMatcher) {
+	sentiment, err := s.sentiment.Analyze(ctx, article, companies)
+	if err != nil {
+		s.logger.Warn("Failed to analyze article sentiment", "error", err, "title", article.Title)
+		return
 	}
-	
-	return score
+	article.Sentiment = sentiment
 }
 
 // validateFilter validates the news filter parameters
@@ -161,6 +328,53 @@ func (s *Service) validateFilter(filter *NewsFilter) error {
 		return ErrInvalidFilter
 	}
 	
+	for _, category := range append(append([]Category{}, filter.Categories...), filter.ExcludeCategories...) {
+		if !category.IsValid() {
+			return ErrInvalidFilter
+		}
+	}
+	
+	if filter.CompanyMatch != "" {
+		if _, err := ParseCompanyMatch(string(filter.CompanyMatch)); err != nil {
+			return ErrInvalidFilter
+		}
+	}
+	
+	if _, err := ParseSortField(string(filter.Sort)); err != nil {
+		return ErrInvalidFilter
+	}
+	
+	if _, err := ParseSortOrder(string(filter.Order)); err != nil {
+		return ErrInvalidFilter
+	}
+	
+	// A cursor only continues the order it was issued for
+	if filter.After != nil {
+		if filter.Offset > 0 || filter.After.Sort != filter.EffectiveSort() || filter.After.Order != filter.EffectiveOrder() {
+			return ErrInvalidFilter
+		}
+	}
+	
+	if !validSentimentBound(filter.MinSentiment) || !validSentimentBound(filter.MaxSentiment) {
+		return ErrInvalidFilter
+	}
+	
+	if filter.MinSentiment != nil && filter.MaxSentiment != nil && *filter.MinSentiment > *filter.MaxSentiment {
+		return ErrInvalidFilter
+	}
+	
+	if filter.Sentiment != "" {
+		if _, err := ParseSentimentLabel(string(filter.Sentiment)); err != nil {
+			return ErrInvalidFilter
+		}
+	}
+	
+	if filter.Entity != "" {
+		if _, err := ParseEntityID(filter.Entity); err != nil {
+			return ErrInvalidFilter
+		}
+	}
+	
 	if filter.Limit < 0 || filter.Limit > 1000 {
 		return ErrInvalidFilter
 	}
@@ -172,12 +386,7 @@ func (s *Service) validateFilter(filter *NewsFilter) error {
 	return nil
 }
 
-// containsIgnoreCase checks if a string contains a substring (case-insensitive)
-func containsIgnoreCase(s, substr string) bool {
-	// Simple implementation - can be enhanced with better text matching
-	return len(s) >= len(substr) && 
-		   (s == substr || 
-		    len(s) > len(substr) && 
-		    (s[:len(substr)] == substr || 
-		     s[len(s)-len(substr):] == substr))
-}
\ No newline at end of file
+// validSentimentBound reports whether an optional sentiment bound lies between -1 and 1
+func validSentimentBound(bound *float64) bool {
+	return bound == nil || (*bound >= -1 && *bound <= 1)
+}
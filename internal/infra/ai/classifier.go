@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// maxClassifierInputRunes bounds the article text sent to the model
+const maxClassifierInputRunes = 4000
+
+// OpenAIClassifier classifies articles with a language model. When the
+// model fails or answers with no valid category, it falls back to another
+// classifier.
+type OpenAIClassifier struct {
+	provider LLMProvider
+	fallback news.Classifier
+	model    string
+	logger   *slog.Logger
+}
+
+// NewOpenAIClassifier creates an LLM classifier; fallback is usually the
+// rule-based classifier
+func NewOpenAIClassifier(provider LLMProvider, model string, fallback news.Classifier, logger *slog.Logger) *OpenAIClassifier {
+	return &OpenAIClassifier{
+		provider: provider,
+		fallback: fallback,
+		model:    model,
+		logger:   logger,
+	}
+}
+
+// Classify implements news.Classifier
+func (c *OpenAIClassifier) Classify(ctx context.Context, article *news.Article) ([]news.Category, error) {
+	categories, err := c.classify(ctx, article)
+	if err != nil {
+		c.logger.Warn("LLM classification failed, using fallback classifier", "error", err, "title", article.Title)
+		return c.fallback.Classify(ctx, article)
+	}
+	return categories, nil
+}
+
+// classify asks the model for the article's categories and validates the answer
+func (c *OpenAIClassifier) classify(ctx context.Context, article *news.Article) ([]news.Category, error) {
+	names := make([]string, 0, len(news.Categories))
+	for _, category := range news.Categories {
+		names = append(names, string(category))
+	}
+
+	systemPrompt := `You classify defense and aerospace news articles into topics.
+Allowed categories: ` + strings.Join(names, ", ") + `.
+Respond with a JSON array of the categories that apply, for example ["contracts", "programs"].
+Respond with [] when none apply. Do not add any other text.`
+
+	text := article.Title + "\n\n" + article.BodyText()
+	if runes := []rune(text); len(runes) > maxClassifierInputRunes {
+		text = string(runes[:maxClassifierInputRunes])
+	}
+
+	content, err := c.provider.Chat(ctx, chatRequest(c.model, systemPrompt, text, 60, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCategories(content)
+}
+
+// parseCategories decodes a JSON array of category names, keeping the valid
+// ones in taxonomy order
+func parseCategories(content string) ([]news.Category, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.Trim(content, "` \n")
+
+	var values []string
+	if err := json.Unmarshal([]byte(content), &values); err != nil {
+		return nil, fmt.Errorf("invalid classification response: %w", err)
+	}
+
+	found := make(map[news.Category]bool, len(values))
+	for _, value := range values {
+		if category, err := news.ParseCategory(value); err == nil {
+			found[category] = true
+		}
+	}
+	if len(values) > 0 && len(found) == 0 {
+		return nil, fmt.Errorf("classification response has no valid category: %q", content)
+	}
+
+	var categories []news.Category
+	for _, category := range news.Categories {
+		if found[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
@@ -0,0 +1,238 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+// CompatibleProvider implements LLMProvider with a self-hosted server
+// speaking the OpenAI API, such as Ollama, vLLM or the llama.cpp server.
+// Unlike OpenAI, these servers have differing default temperatures, so the
+// temperature is always sent.
+type CompatibleProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewCompatibleProvider creates a provider for the API at baseURL, e.g.
+// http://localhost:11434/v1 for Ollama. apiKey is sent as a bearer token
+// when set. There is no client timeout, as answers are streamed; requests
+// end with their context.
+func NewCompatibleProvider(baseURL, apiKey string) *CompatibleProvider {
+	return &CompatibleProvider{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+	}
+}
+
+// compatibleMessage is a chat message of the API
+type compatibleMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// compatibleChatRequest is the body of a chat completion request
+type compatibleChatRequest struct {
+	Model          string                    `json:"model"`
+	Messages       []compatibleMessage       `json:"messages"`
+	MaxTokens      int                       `json:"max_tokens,omitempty"`
+	Temperature    float32                   `json:"temperature"`
+	Stream         bool                      `json:"stream,omitempty"`
+	ResponseFormat *compatibleResponseFormat `json:"response_format,omitempty"`
+}
+
+// compatibleResponseFormat constrains the answer to a JSON schema
+type compatibleResponseFormat struct {
+	Type       string `json:"type"`
+	JSONSchema struct {
+		Name   string                 `json:"name"`
+		Schema *jsonschema.Definition `json:"schema"`
+		Strict bool                   `json:"strict"`
+	} `json:"json_schema"`
+}
+
+// compatibleChatResponse is a chat completion, or a part of one when streaming
+type compatibleChatResponse struct {
+	Choices []struct {
+		Message compatibleMessage `json:"message"`
+		Delta   compatibleMessage `json:"delta"`
+	} `json:"choices"`
+}
+
+// compatibleEmbeddingResponse holds the vectors of an embedding request
+type compatibleEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Chat implements LLMProvider
+func (p *CompatibleProvider) Chat(ctx context.Context, req ChatRequest) (string, error) {
+	resp, err := p.post(ctx, "/chat/completions", compatibleRequest(req, false))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var completion compatibleChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", fmt.Errorf("invalid chat completion response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", ErrNoResponse
+	}
+
+	return completion.Choices[0].Message.Content, nil
+}
+
+// ChatStream implements LLMProvider
+func (p *CompatibleProvider) ChatStream(ctx context.Context, req ChatRequest) (ChatStream, error) {
+	resp, err := p.post(ctx, "/chat/completions", compatibleRequest(req, true))
+	if err != nil {
+		return nil, err
+	}
+	return &compatibleStream{body: resp.Body, reader: bufio.NewReader(resp.Body)}, nil
+}
+
+// Embed implements LLMProvider
+func (p *CompatibleProvider) Embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	resp, err := p.post(ctx, "/embeddings", map[string]interface{}{
+		"model": model,
+		"input": texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var embeddings compatibleEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embeddings); err != nil {
+		return nil, fmt.Errorf("invalid embedding response: %w", err)
+	}
+
+	vectors := make([][]float32, len(embeddings.Data))
+	indexes := make([]int, len(embeddings.Data))
+	for i, data := range embeddings.Data {
+		vectors[i] = data.Embedding
+		indexes[i] = data.Index
+	}
+	return orderEmbeddings(vectors, indexes, len(texts))
+}
+
+// compatibleRequest converts a chat request to the API's
+func compatibleRequest(req ChatRequest, stream bool) compatibleChatRequest {
+	messages := make([]compatibleMessage, len(req.Messages))
+	for i, message := range req.Messages {
+		messages[i] = compatibleMessage(message)
+	}
+
+	request := compatibleChatRequest{
+		Model:       req.Model,
+		Messages:    messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		Stream:      stream,
+	}
+	if req.Schema != nil {
+		request.ResponseFormat = &compatibleResponseFormat{Type: "json_schema"}
+		request.ResponseFormat.JSONSchema.Name = req.Schema.Name
+		request.ResponseFormat.JSONSchema.Schema = req.Schema.Definition
+		request.ResponseFormat.JSONSchema.Strict = req.Schema.Strict
+	}
+	return request
+}
+
+// post sends a JSON request to the API, returning the response when it succeeds
+func (p *CompatibleProvider) post(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, compatibleError(resp)
+	}
+	return resp, nil
+}
+
+// compatibleError describes a failed response, with the error message of
+// its body when there is one
+func compatibleError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	var apiError struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &apiError) == nil && apiError.Error.Message != "" {
+		message = apiError.Error.Message
+	}
+	return fmt.Errorf("model API returned status %d: %s", resp.StatusCode, message)
+}
+
+// compatibleStream reads the parts of a streamed answer: Server-Sent Events
+// of completion chunks, ended by [DONE] or by the end of the body
+type compatibleStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+}
+
+// Recv implements ChatStream, skipping chunks without content
+func (s *compatibleStream) Recv() (string, error) {
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", err
+		}
+
+		data, ok := strings.CutPrefix(strings.TrimSpace(line), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return "", io.EOF
+		}
+
+		var chunk compatibleChatResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", fmt.Errorf("invalid stream chunk: %w", err)
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			return chunk.Choices[0].Delta.Content, nil
+		}
+	}
+}
+
+// Close implements ChatStream
+func (s *compatibleStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+const (
+	// maxContractInputRunes bounds the article text sent to the model
+	maxContractInputRunes = 8000
+	// maxLLMAwards bounds the awards taken from one model response
+	maxLLMAwards = 50
+)
+
+// llmContractAward is a contract award as returned by the model
+type llmContractAward struct {
+	Awardee        string  `json:"awardee"`
+	Amount         float64 `json:"amount"`
+	Agency         string  `json:"agency"`
+	ContractNumber string  `json:"contract_number"`
+	CompletionDate string  `json:"completion_date"`
+	Location       string  `json:"location"`
+	Description    string  `json:"description"`
+}
+
+// OpenAIContractExtractor extracts contract awards with a language model.
+// It is meant as the fallback of the rule-based extractor for announcements
+// written in unusual forms.
+type OpenAIContractExtractor struct {
+	provider LLMProvider
+	model    string
+}
+
+// NewOpenAIContractExtractor creates an LLM contract award extractor
+func NewOpenAIContractExtractor(provider LLMProvider, model string) *OpenAIContractExtractor {
+	return &OpenAIContractExtractor{
+		provider: provider,
+		model:    model,
+	}
+}
+
+// Extract implements contract.Extractor
+func (e *OpenAIContractExtractor) Extract(ctx context.Context, article *news.Article) ([]*contract.ContractAward, error) {
+	systemPrompt := `You extract defense contract awards from news articles.
+Respond with a JSON array with one object per award and no other text:
+[{"awardee": "company awarded the contract", "amount": total value in US dollars as a number,
+"agency": "contracting agency", "contract_number": "", "completion_date": "YYYY-MM-DD or empty",
+"location": "place of performance", "description": "what the contract is for"}]
+Use empty strings for unknown fields. Respond with [] when the article announces no award with a dollar amount.`
+
+	text := article.Title + "\n\n" + article.BodyText()
+	if runes := []rune(text); len(runes) > maxContractInputRunes {
+		text = string(runes[:maxContractInputRunes])
+	}
+
+	content, err := e.provider.Chat(ctx, chatRequest(e.model, systemPrompt, text, 800, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	awards, err := parseContractAwards(content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, award := range awards {
+		award.ArticleID = article.ID
+		award.AnnouncedDate = article.PublishedDate
+		award.SourceURL = article.SourceURL
+		award.Method = contract.ExtractionLLM
+		award.ExtractedAt = now
+	}
+	return awards, nil
+}
+
+// parseContractAwards decodes the model's JSON array, dropping awards without
+// an awardee or a positive amount
+func parseContractAwards(content string) ([]*contract.ContractAward, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.Trim(content, "` \n")
+
+	var values []llmContractAward
+	if err := json.Unmarshal([]byte(content), &values); err != nil {
+		return nil, fmt.Errorf("invalid contract extraction response: %w", err)
+	}
+
+	var awards []*contract.ContractAward
+	for _, value := range values {
+		if len(awards) >= maxLLMAwards {
+			break
+		}
+		if strings.TrimSpace(value.Awardee) == "" || value.Amount <= 0 {
+			continue
+		}
+
+		award := &contract.ContractAward{
+			Awardee:        strings.TrimSpace(value.Awardee),
+			Amount:         value.Amount,
+			Agency:         strings.TrimSpace(value.Agency),
+			ContractNumber: strings.TrimSpace(value.ContractNumber),
+			Location:       strings.TrimSpace(value.Location),
+			Description:    strings.TrimSpace(value.Description),
+		}
+		if date, err := time.Parse("2006-01-02", value.CompletionDate); err == nil {
+			award.CompletionDate = &date
+		}
+		awards = append(awards, award)
+	}
+
+	return awards, nil
+}
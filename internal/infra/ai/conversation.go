@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Neph-dev/october_backend/internal/domain/conversation"
+)
+
+// maxHistoryAnswerRunes bounds each answer of the history sent to the model
+const maxHistoryAnswerRunes = 600
+
+// OpenAIRewriter rewrites follow-up questions with a language model. When
+// the model fails, it falls back to another rewriter.
+type OpenAIRewriter struct {
+	provider LLMProvider
+	fallback conversation.Rewriter
+	model    string
+	logger   *slog.Logger
+}
+
+// NewOpenAIRewriter creates an LLM question rewriter; fallback is usually
+// the rule-based rewriter
+func NewOpenAIRewriter(provider LLMProvider, model string, fallback conversation.Rewriter, logger *slog.Logger) *OpenAIRewriter {
+	return &OpenAIRewriter{
+		provider: provider,
+		fallback: fallback,
+		model:    model,
+		logger:   logger,
+	}
+}
+
+// Rewrite implements conversation.Rewriter
+func (r *OpenAIRewriter) Rewrite(ctx context.Context, history conversation.History, question string) (string, error) {
+	systemPrompt := `You rewrite follow-up questions about defense and aerospace news into standalone questions.
+Using the conversation, replace pronouns and elliptical references in the final question with the companies,
+programs, topics and periods they refer to. Keep any company or period the final question names itself.
+If the question already stands on its own, return it unchanged. Respond with the question only.`
+
+	prompt := historyText(history) + "\nFinal question: " + question
+
+	standalone, err := completeText(ctx, r.provider, r.model, systemPrompt, prompt, 100)
+	if err != nil {
+		r.logger.Warn("LLM question rewriting failed, using fallback rewriter", "error", err)
+		return r.fallback.Rewrite(ctx, history, question)
+	}
+	return strings.Trim(standalone, "\"“” \n"), nil
+}
+
+// OpenAISummarizer keeps the rolling summary of conversations with a
+// language model. When the model fails, it falls back to another summarizer.
+type OpenAISummarizer struct {
+	provider LLMProvider
+	fallback conversation.Summarizer
+	model    string
+	logger   *slog.Logger
+}
+
+// NewOpenAISummarizer creates an LLM conversation summarizer; fallback is
+// usually the rule-based summarizer
+func NewOpenAISummarizer(provider LLMProvider, model string, fallback conversation.Summarizer, logger *slog.Logger) *OpenAISummarizer {
+	return &OpenAISummarizer{
+		provider: provider,
+		fallback: fallback,
+		model:    model,
+		logger:   logger,
+	}
+}
+
+// Summarize implements conversation.Summarizer
+func (s *OpenAISummarizer) Summarize(ctx context.Context, summary string, messages []conversation.Message) (string, error) {
+	systemPrompt := `You maintain the summary of a conversation about defense and aerospace news.
+Update the summary with the new messages. Keep the companies, programs, figures and periods discussed,
+and what the user wanted to know. Write at most 150 words of plain text.`
+
+	prompt := historyText(conversation.History{Summary: summary, Messages: messages})
+
+	updated, err := completeText(ctx, s.provider, s.model, systemPrompt, prompt, 250)
+	if err != nil {
+		s.logger.Warn("LLM conversation summary failed, using fallback summarizer", "error", err)
+		return s.fallback.Summarize(ctx, summary, messages)
+	}
+	return updated, nil
+}
+
+// historyText writes a conversation history for a prompt. Questions are
+// written as asked, so that earlier rewrites do not pile up in later ones.
+func historyText(history conversation.History) string {
+	var text strings.Builder
+	if history.Summary != "" {
+		text.WriteString("Summary of the earlier conversation:\n" + history.Summary + "\n\n")
+	}
+
+	text.WriteString("Conversation:\n")
+	for _, message := range history.Messages {
+		switch message.Role {
+		case conversation.RoleUser:
+			fmt.Fprintf(&text, "User: %s\n", message.Content)
+		case conversation.RoleAssistant:
+			answer := message.Content
+			if runes := []rune(answer); len(runes) > maxHistoryAnswerRunes {
+				answer = string(runes[:maxHistoryAnswerRunes]) + "…"
+			}
+			fmt.Fprintf(&text, "Assistant: %s\n", answer)
+		}
+	}
+	return text.String()
+}
+
+// completeText asks a model for a short plain-text answer
+func completeText(ctx context.Context, provider LLMProvider, model, systemPrompt, prompt string, maxTokens int) (string, error) {
+	content, err := provider.Chat(ctx, chatRequest(model, systemPrompt, prompt, maxTokens, 0))
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return "", ErrNoResponse
+	}
+
+	return strings.TrimSpace(content), nil
+}
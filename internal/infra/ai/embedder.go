@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// OpenAIEmbeddingDimensions is the vector length of text-embedding-3-small
+	OpenAIEmbeddingDimensions = 1536
+
+	// maxEmbeddingInputRunes bounds each text sent to the model, well below
+	// the 8191 token input limit of OpenAI models
+	maxEmbeddingInputRunes = 12000
+)
+
+// OpenAIEmbedder embeds texts with an embedding model. There is no
+// fallback: vectors of another embedder are not comparable, so failures are
+// returned to the caller.
+type OpenAIEmbedder struct {
+	provider   LLMProvider
+	model      string
+	dimensions int
+}
+
+// NewOpenAIEmbedder creates an embedder using a model returning vectors of
+// the given length
+func NewOpenAIEmbedder(provider LLMProvider, model string, dimensions int) *OpenAIEmbedder {
+	return &OpenAIEmbedder{
+		provider:   provider,
+		model:      model,
+		dimensions: dimensions,
+	}
+}
+
+// Model implements embedding.Embedder
+func (e *OpenAIEmbedder) Model() string {
+	return e.model
+}
+
+// Dimensions implements embedding.Embedder
+func (e *OpenAIEmbedder) Dimensions() int {
+	return e.dimensions
+}
+
+// Embed implements embedding.Embedder
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	inputs := make([]string, len(texts))
+	for i, text := range texts {
+		if runes := []rune(text); len(runes) > maxEmbeddingInputRunes {
+			text = string(runes[:maxEmbeddingInputRunes])
+		}
+		inputs[i] = text
+	}
+
+	vectors, err := e.provider.Embed(ctx, e.model, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vectors of another length would not fit the index
+	for _, vector := range vectors {
+		if len(vector) != e.dimensions {
+			return nil, fmt.Errorf("expected %d dimensions from %s, got %d", e.dimensions, e.model, len(vector))
+		}
+	}
+	return vectors, nil
+}
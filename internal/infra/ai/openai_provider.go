@@ -0,0 +1,132 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAIProvider implements LLMProvider with the OpenAI API
+type OpenAIProvider struct {
+	client *openai.Client
+}
+
+// NewOpenAIProvider creates a provider using an OpenAI client
+func NewOpenAIProvider(client *openai.Client) *OpenAIProvider {
+	return &OpenAIProvider{client: client}
+}
+
+// Chat implements LLMProvider
+func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest) (string, error) {
+	resp, err := p.client.CreateChatCompletion(ctx, openAIRequest(req))
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", ErrNoResponse
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
+// ChatStream implements LLMProvider
+func (p *OpenAIProvider) ChatStream(ctx context.Context, req ChatRequest) (ChatStream, error) {
+	request := openAIRequest(req)
+	request.Stream = true
+
+	stream, err := p.client.CreateChatCompletionStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &openAIStream{stream: stream}, nil
+}
+
+// Embed implements LLMProvider
+func (p *OpenAIProvider) Embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	resp, err := p.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input: texts,
+		Model: openai.EmbeddingModel(model),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(resp.Data))
+	indexes := make([]int, len(resp.Data))
+	for i, data := range resp.Data {
+		vectors[i] = data.Embedding
+		indexes[i] = data.Index
+	}
+	return orderEmbeddings(vectors, indexes, len(texts))
+}
+
+// openAIRequest converts a chat request to the OpenAI client's
+func openAIRequest(req ChatRequest) openai.ChatCompletionRequest {
+	messages := make([]openai.ChatCompletionMessage, len(req.Messages))
+	for i, message := range req.Messages {
+		messages[i] = openai.ChatCompletionMessage{
+			Role:    message.Role,
+			Content: message.Content,
+		}
+	}
+
+	request := openai.ChatCompletionRequest{
+		Model:       req.Model,
+		Messages:    messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+	}
+	if req.Schema != nil {
+		request.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   req.Schema.Name,
+				Schema: req.Schema.Definition,
+				Strict: req.Schema.Strict,
+			},
+		}
+	}
+	return request
+}
+
+// openAIStream reads the parts of a streamed OpenAI answer
+type openAIStream struct {
+	stream *openai.ChatCompletionStream
+}
+
+// Recv implements ChatStream, skipping chunks without content
+func (s *openAIStream) Recv() (string, error) {
+	for {
+		resp, err := s.stream.Recv()
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Choices) > 0 && resp.Choices[0].Delta.Content != "" {
+			return resp.Choices[0].Delta.Content, nil
+		}
+	}
+}
+
+// Close implements ChatStream
+func (s *openAIStream) Close() error {
+	return s.stream.Close()
+}
+
+// orderEmbeddings puts the vectors of an embedding response in input order.
+// Each vector carries the index of its input.
+func orderEmbeddings(vectors [][]float32, indexes []int, count int) ([][]float32, error) {
+	if len(vectors) != count {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", count, len(vectors))
+	}
+
+	ordered := make([][]float32, count)
+	for i, index := range indexes {
+		if index < 0 || index >= count || ordered[index] != nil {
+			return nil, fmt.Errorf("invalid embedding index %d", index)
+		}
+		ordered[index] = vectors[i]
+	}
+	return ordered, nil
+}
@@ -2,42 +2,160 @@ package ai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/company"
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 	"github.com/Neph-dev/october_backend/internal/infra/cache"
 	"github.com/Neph-dev/october_backend/internal/infra/search"
 	"github.com/Neph-dev/october_backend/pkg/logger"
-	"github.com/sashabaranov/go-openai"
 )
 
 type OpenAIService struct {
-	client         *openai.Client
-	newsService    *news.Service
-	googleSearch   *search.GoogleSearchService
-	summaryCache   ai.SummaryCache
-	model          string
-	logger         logger.Logger
+	provider        LLMProvider
+	models          Models
+	newsService     *news.Service
+	analyzer        ai.QueryAnalyzer
+	retrieval       *RetrievalPipeline
+	companyResolver company.Resolver
+	googleSearch    *search.GoogleSearchService
+	summaryCache    ai.SummaryCache
+	budgets         ai.TokenBudgets
+	times           *ai.TimeResolver
+	logger          logger.Logger
 }
 
-// NewOpenAIService creates a new OpenAI service instance
-func NewOpenAIService(client *openai.Client, newsService *news.Service, googleSearch *search.GoogleSearchService, summaryCache ai.SummaryCache, logger logger.Logger) *OpenAIService {
+// NewOpenAIService creates a new AI service answering with the models of
+// a provider. analyzer is optional; when nil, questions are analysed by rules.
+// budgets bounds the article text sent to each model; nil uses the defaults.
+func NewOpenAIService(provider LLMProvider, models Models, newsService *news.Service, analyzer ai.QueryAnalyzer, retrieval *RetrievalPipeline, companyResolver company.Resolver, googleSearch *search.GoogleSearchService, summaryCache ai.SummaryCache, budgets ai.TokenBudgets, logger logger.Logger) *OpenAIService {
+	if analyzer == nil {
+		analyzer = ai.NewRuleQueryAnalyzer()
+	}
+	if budgets == nil {
+		budgets = ai.DefaultTokenBudgets()
+	}
+
 	return &OpenAIService{
-		client:       client,
-		newsService:  newsService,
-		googleSearch: googleSearch,
-		summaryCache: summaryCache,
-		model:        openai.GPT4oMini, // Default model
-		logger:       logger,
+		provider:        provider,
+		models:          models,
+		newsService:     newsService,
+		analyzer:        analyzer,
+		retrieval:       retrieval,
+		companyResolver: companyResolver,
+		googleSearch:    googleSearch,
+		summaryCache:    summaryCache,
+		budgets:         budgets,
+		times:           ai.NewTimeResolver(nil, nil),
+		logger:          logger,
 	}
 }
 
 func (s *OpenAIService) ProcessQuery(ctx context.Context, req *ai.QueryRequest) (*ai.QueryResponse, error) {
 	startTime := time.Now()
-	
+
+	plan, err := s.planAnswer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 5: Generate the answer, unless it is fixed
+	result := plan.response
+	if plan.request != nil {
+		answer, err := s.complete(ctx, *plan.request)
+		if err != nil {
+			s.logger.Error("Failed to generate AI response", "error", err)
+			return nil, fmt.Errorf("%w: failed to generate response", ai.ErrAIService)
+		}
+		result.Answer = answer
+	}
+	result.ProcessingTime = time.Since(startTime)
+
+	s.logger.Info("AI query processed successfully", 
+		"processing_time", result.ProcessingTime,
+		"sources_used", len(result.Sources),
+		"web_sources_used", len(result.WebSources),
+		"confidence", result.Confidence)
+
+	return result, nil
+}
+
+// StreamQuery processes a query like ProcessQuery, streaming the answer from
+// the model as it is generated. Cancelling ctx, as when the client
+// disconnects, cancels the request to the model.
+func (s *OpenAIService) StreamQuery(ctx context.Context, req *ai.QueryRequest, send ai.StreamSender) error {
+	startTime := time.Now()
+
+	plan, err := s.planAnswer(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	result := plan.response
+	err = send(ai.StreamEvent{Type: ai.StreamEventSources, Data: ai.StreamSources{
+		Sources:             result.Sources,
+		WebSources:          result.WebSources,
+		UsedWebSearch:       result.UsedWebSearch,
+		CompaniesReferenced: result.CompaniesReferenced,
+	}})
+	if err != nil {
+		return err
+	}
+
+	if plan.request == nil {
+		err = send(ai.StreamEvent{Type: ai.StreamEventDelta, Data: ai.StreamDelta{Text: result.Answer}})
+		if err != nil {
+			return err
+		}
+	} else {
+		var sendErr error
+		answer, err := s.streamCompletion(ctx, *plan.request, func(text string) error {
+			sendErr = send(ai.StreamEvent{Type: ai.StreamEventDelta, Data: ai.StreamDelta{Text: text}})
+			return sendErr
+		})
+		if sendErr != nil {
+			return sendErr
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.logger.Error("Failed to stream AI response", "error", err)
+			return fmt.Errorf("%w: failed to generate response", ai.ErrAIService)
+		}
+		result.Answer = answer
+	}
+	result.ProcessingTime = time.Since(startTime)
+
+	s.logger.Info("AI query streamed successfully",
+		"processing_time", result.ProcessingTime,
+		"answer_length", len(result.Answer),
+		"sources_used", len(result.Sources),
+		"confidence", result.Confidence)
+
+	return send(ai.StreamEvent{Type: ai.StreamEventDone, Data: ai.StreamDone{
+		Confidence:     result.Confidence,
+		ProcessingTime: result.ProcessingTime,
+	}})
+}
+
+// answerPlan is a query ready to be answered: the response without its
+// answer, and the completion request generating the answer. The request is
+// nil when the response has a fixed answer.
+type answerPlan struct {
+	response *ai.QueryResponse
+	request  *ChatRequest
+}
+
+// planAnswer analyzes the query, retrieves the sources to answer it from,
+// and prepares the request generating the answer
+func (s *OpenAIService) planAnswer(ctx context.Context, req *ai.QueryRequest) (*answerPlan, error) {
 	if strings.TrimSpace(req.Question) == "" {
 		return nil, fmt.Errorf("%w: question cannot be empty", ai.ErrInvalidQuery)
 	}
@@ -52,167 +170,138 @@ func (s *OpenAIService) ProcessQuery(ctx context.Context, req *ai.QueryRequest)
 	}
 
 	// Step 2: Retrieve relevant articles
-	sources, err := s.retrieveRelevantArticles(ctx, analysis, req.CompanyContext)
+	sources, err := s.retrieveRelevantArticles(ctx, req.Question, analysis, req.CompanyContext)
 	if err != nil {
 		s.logger.Error("Failed to retrieve articles", "error", err)
 		return nil, fmt.Errorf("%w: failed to retrieve articles", ai.ErrAIService)
 	}
 
+	response := &ai.QueryResponse{
+		Sources:             []ai.SourceReference{},
+		WebSources:          []ai.WebSearchSource{},
+		CompaniesReferenced: analysis.CompanyNames,
+	}
+
 	// Step 3: If insufficient database context, use Google Custom Search + OpenAI
 	if len(sources) < 3 || s.hasLowConfidenceContext(sources) {
 		s.logger.Info("Insufficient database context, using Google Custom Search + OpenAI", "db_sources", len(sources))
-		
-		// Check if the question is about defense/aeronautics companies or topics
-		if s.isDefenseAeronauticsQuestion(req.Question, analysis.CompanyNames) {
-			// Perform Google Custom Search
-			searchResults, err := s.googleSearch.SearchDefenseAndAerospace(ctx, req.Question)
-			if err != nil {
-				s.logger.Error("Failed to perform Google search, falling back to direct OpenAI", "error", err)
-				// Fallback to direct OpenAI response
-				directResponse, directErr := s.generateDirectResponse(ctx, req.Question, analysis)
-				if directErr != nil {
-					s.logger.Error("Failed to generate direct response", "error", directErr)
-					return nil, fmt.Errorf("%w: failed to generate response", ai.ErrAIService)
-				}
-
-				return &ai.QueryResponse{
-					Answer:              directResponse,
-					Sources:             []ai.SourceReference{},
-					WebSources:          []ai.WebSearchSource{},
-					UsedWebSearch:       false,
-					Confidence:          0.7,
-					ProcessingTime:      time.Since(startTime),
-					CompaniesReferenced: analysis.CompanyNames,
-				}, nil
-			}
-
-			// Generate response using Google search results
-			webSources := s.convertGoogleResultsToWebSources(searchResults)
-			searchResponse, err := s.generateResponseWithWebSearch(ctx, req.Question, searchResults, analysis)
-			if err != nil {
-				s.logger.Error("Failed to generate response with search results", "error", err)
-				return nil, fmt.Errorf("%w: failed to generate search-based response", ai.ErrAIService)
-			}
-
-			result := &ai.QueryResponse{
-				Answer:              searchResponse,
-				Sources:             []ai.SourceReference{},
-				WebSources:          webSources,
-				UsedWebSearch:       true,
-				Confidence:          0.8, // High confidence for search + AI combination
-				ProcessingTime:      time.Since(startTime),
-				CompaniesReferenced: analysis.CompanyNames,
-			}
-
-			s.logger.Info("Google search + OpenAI response provided", 
-				"processing_time", result.ProcessingTime,
-				"search_results", len(searchResults),
-				"confidence", result.Confidence)
 
-			return result, nil
-		} else {
+		// Check if the question is about defense/aeronautics companies or topics
+		if !s.isDefenseAeronauticsQuestion(req.Question, analysis.CompanyNames) {
 			// Not a defense/aeronautics question, return no results
-			return &ai.QueryResponse{
-				Answer:              "I can only provide information about defense and aeronautics companies and topics. Please ask about RTX, US War Department, or related defense/aerospace subjects.",
-				Sources:             []ai.SourceReference{},
-				WebSources:          []ai.WebSearchSource{},
-				UsedWebSearch:       false,
-				Confidence:          0.0,
-				ProcessingTime:      time.Since(startTime),
-				CompaniesReferenced: analysis.CompanyNames,
-			}, nil
+			response.Answer = "I can only provide information about defense and aeronautics companies and topics. Please ask about RTX, US War Department, or related defense/aerospace subjects."
+			return &answerPlan{response: response}, nil
 		}
-	}
 
-	// Step 4: Generate AI response using retrieved context from database
-	response, err := s.generateResponse(ctx, req.Question, sources, []ai.WebSearchSource{}, analysis)
-	if err != nil {
-		s.logger.Error("Failed to generate AI response", "error", err)
-		return nil, fmt.Errorf("%w: failed to generate response", ai.ErrAIService)
-	}
+		// Perform Google Custom Search
+		searchResults, err := s.googleSearch.SearchDefenseAndAerospace(ctx, req.Question)
+		if err != nil {
+			s.logger.Error("Failed to perform Google search, falling back to direct OpenAI", "error", err)
+			// Fallback to direct OpenAI response
+			response.Confidence = 0.7
+			request := s.directRequest(req.Question)
+			return &answerPlan{response: response, request: &request}, nil
+		}
 
-	// Step 5: Build final response
-	result := &ai.QueryResponse{
-		Answer:              response,
-		Sources:             sources,
-		WebSources:          []ai.WebSearchSource{},
-		UsedWebSearch:       false,
-		Confidence:          s.calculateConfidence(sources, []ai.WebSearchSource{}, analysis),
-		ProcessingTime:      time.Since(startTime),
-		CompaniesReferenced: analysis.CompanyNames,
+		// Generate response using Google search results
+		s.logger.Info("Answering from Google search results", "search_results", len(searchResults))
+		response.WebSources = s.convertGoogleResultsToWebSources(searchResults)
+		response.UsedWebSearch = true
+		response.Confidence = 0.8 // High confidence for search + AI combination
+		request := s.webSearchRequest(req.Question, searchResults)
+		return &answerPlan{response: response, request: &request}, nil
 	}
 
-	s.logger.Info("AI query processed successfully", 
-		"processing_time", result.ProcessingTime,
-		"sources_used", len(sources),
-		"confidence", result.Confidence)
-
-	return result, nil
+	// Step 4: Prepare the AI response using retrieved context from database
+	request := s.answerRequest(ctx, req.Question, sources, []ai.WebSearchSource{}, analysis)
+	response.Sources = sources
+	response.Confidence = s.calculateConfidence(sources, []ai.WebSearchSource{}, analysis)
+	return &answerPlan{response: response, request: &request}, nil
 }
 
-// AnalyzeQuery analyzes the user's question to extract intent and entities
+// AnalyzeQuery analyzes the user's question to extract intent and entities,
+// resolving the companies it mentions against the companies collection and
+// the period it names to dates
 func (s *OpenAIService) AnalyzeQuery(ctx context.Context, question string) (*ai.QueryAnalysisResult, error) {
-	systemPrompt := `You are a query analyzer for a defense industry news system. 
-Analyze the user's question and extract:
-1. Query type (financial, contracts, general, comparison, news)
-2. Company names mentioned (RTX, Raytheon Technologies, US War Department, Lockheed Martin, etc.)
-3. Key search terms and keywords
-4. Time window if mentioned (this quarter, recent, this year, etc.)
-
-Respond in this exact JSON format:
-{
-	"query_type": "financial|contracts|general|comparison|news",
-	"company_names": ["Company1", "Company2"],
-	"keywords": ["keyword1", "keyword2"],
-	"time_window": "recent|this_quarter|this_year|null",
-	"search_terms": ["term1", "term2"]
-}`
-
-	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role:    openai.ChatMessageRoleSystem,
-				Content: systemPrompt,
-			},
-			{
-				Role:    openai.ChatMessageRoleUser,
-				Content: question,
-			},
-		},
-		MaxTokens:   200,
-		Temperature: 0.1, // Low temperature for consistent analysis
-	})
-
+	analysis, err := s.analyzer.Analyze(ctx, question)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no response from OpenAI")
+	analysis.CompanyNames = s.resolveCompanyNames(ctx, question, analysis.CompanyNames)
+	analysis.TimeWindow = s.resolveTimeWindow(question, analysis.TimeWindow)
+	return analysis, nil
+}
+
+// resolveTimeWindow returns the dates of the analysed time window: those
+// of its expression, or else of the first time expression in the question.
+// Windows that cannot be resolved are dropped.
+func (s *OpenAIService) resolveTimeWindow(question string, window *ai.TimeWindow) *ai.TimeWindow {
+	if window != nil && window.StartDate != nil {
+		return window
 	}
 
-	// Parse the JSON response (simplified - in production, use proper JSON parsing)
-	analysis := s.parseAnalysisResponse(resp.Choices[0].Message.Content, question)
-	
-	return analysis, nil
+	if window != nil && window.Expression != "" {
+		if resolved, ok := s.times.Resolve(window.Expression); ok {
+			resolved.Expression = window.Expression
+			return resolved
+		}
+		s.logger.Debug("Ignoring unresolved time expression", "expression", window.Expression)
+	}
+	if resolved, ok := s.times.Resolve(question); ok {
+		return resolved
+	}
+	return nil
 }
 
-// retrieveRelevantArticles finds articles relevant to the query
-func (s *OpenAIService) retrieveRelevantArticles(ctx context.Context, analysis *ai.QueryAnalysisResult, companyContext []string) ([]ai.SourceReference, error) {
-	filter := &news.NewsFilter{
-		Limit:  20,
-		Offset: 0,
+// resolveCompanyNames returns the canonical names of the known companies
+// among the analysed names and those mentioned in the question, matched by
+// name, alias, ticker or misspelling. Unknown names are dropped.
+func (s *OpenAIService) resolveCompanyNames(ctx context.Context, question string, names []string) []string {
+	resolved := []string{}
+	add := func(name string) {
+		if !slices.Contains(resolved, name) {
+			resolved = append(resolved, name)
+		}
+	}
+
+	for _, name := range names {
+		resolution, err := s.companyResolver.Resolve(ctx, name)
+		if err != nil {
+			s.logger.Debug("Ignoring unknown company in question", "name", name, "error", err)
+			continue
+		}
+		add(resolution.Company.Name)
+	}
+
+	mentions, err := s.companyResolver.ResolveMentions(ctx, question)
+	if err != nil {
+		s.logger.Warn("Failed to resolve companies in question", "error", err)
+		return resolved
 	}
+	for _, mention := range mentions {
+		add(mention.Company.Name)
+	}
+	return resolved
+}
 
-	// Add company filter
-	companies := analysis.CompanyNames
-	if len(companyContext) > 0 {
-		companies = append(companies, companyContext...)
+// retrieveRelevantArticles finds articles relevant to the query
+func (s *OpenAIService) retrieveRelevantArticles(ctx context.Context, question string, analysis *ai.QueryAnalysisResult, companyContext []string) ([]ai.SourceReference, error) {
+	filter := &news.NewsFilter{}
+
+	// Add company filter, resolving the caller's company context to canonical names
+	companies := append([]string{}, analysis.CompanyNames...)
+	for _, name := range companyContext {
+		if resolution, err := s.companyResolver.Resolve(ctx, name); err == nil {
+			name = resolution.Company.Name
+		}
+		if !slices.Contains(companies, name) {
+			companies = append(companies, name)
+		}
 	}
 
 	// Add time window if specified
-	if analysis.TimeWindow != nil {
+	if analysis.TimeWindow != nil && analysis.TimeWindow.StartDate != nil {
 		filter.StartDate = analysis.TimeWindow.StartDate
 		filter.EndDate = analysis.TimeWindow.EndDate
 	} else {
@@ -221,48 +310,68 @@ func (s *OpenAIService) retrieveRelevantArticles(ctx context.Context, analysis *
 		filter.StartDate = &recent
 	}
 
-	var allArticles []*news.Article
+	// Retrieve the articles matching the question by words and meaning,
+	// falling back to the most recent articles
+	query := &RetrievalQuery{
+		Question:  question,
+		Analysis:  analysis,
+		Companies: companies,
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+	}
+	sources, err := s.retrieval.Retrieve(ctx, query)
+	if err != nil {
+		s.logger.Warn("Article retrieval failed, using recent articles", "error", err)
+	}
+	if len(sources) > 0 {
+		return sources, nil
+	}
+
+	recentArticles, err := s.listRecentArticles(ctx, filter, companies)
+	if err != nil {
+		return nil, err
+	}
+	return s.retrieval.Rank(ctx, query, recentArticles)
+}
+
+// listRecentArticles retrieves the newest articles within the filter, for
+// each of the companies when there are any
+func (s *OpenAIService) listRecentArticles(ctx context.Context, filter *news.NewsFilter, companies []string) ([]*news.Article, error) {
+	filter.Limit = 20
 
 	// If specific companies mentioned, get articles for each
-	if len(companies) > 0 {
-		for _, companyName := range companies {
-			filter.Company = companyName
-			articles, _, err := s.newsService.ListArticles(ctx, filter)
-			if err != nil {
-				s.logger.Warn("Failed to get articles for company", "company", companyName, "error", err)
-				continue
-			}
-			allArticles = append(allArticles, articles...)
-		}
-	} else {
-		// Get general articles
+	if len(companies) == 0 {
 		articles, _, err := s.newsService.ListArticles(ctx, filter)
-		if err != nil {
-			return nil, err
-		}
-		allArticles = articles
+		return articles, err
 	}
 
-	// Convert to source references and rank by relevance
-	sources := s.rankArticlesByRelevance(allArticles, analysis)
-	
-	// Return top 10 most relevant
-	if len(sources) > 10 {
-		sources = sources[:10]
+	var allArticles []*news.Article
+	for _, companyName := range companies {
+		filter.Company = companyName
+		articles, _, err := s.newsService.ListArticles(ctx, filter)
+		if err != nil {
+			s.logger.Warn("Failed to get articles for company", "company", companyName, "error", err)
+			continue
+		}
+		allArticles = append(allArticles, articles...)
 	}
-
-	return sources, nil
+	return allArticles, nil
 }
 
-// generateResponse creates an AI response using the retrieved context (both DB and web)
-func (s *OpenAIService) generateResponse(ctx context.Context, question string, sources []ai.SourceReference, webSources []ai.WebSearchSource, analysis *ai.QueryAnalysisResult) (string, error) {
-	contextText := s.buildContextFromSources(sources, webSources)
+// answerRequest prepares the request answering from the retrieved context (both DB and web)
+// It sets the citations of the sources whose passages are given to the model.
+func (s *OpenAIService) answerRequest(ctx context.Context, question string, sources []ai.SourceReference, webSources []ai.WebSearchSource, analysis *ai.QueryAnalysisResult) ChatRequest {
+	// Pack the passages that best answer the question into the model's budget
+	builder := ai.NewContextBuilder(s.budgets.For(s.models.Answer))
+	passages := builder.Pack(question, analysis.Keywords, sources, sourceArticles(sources))
+	contextText := s.buildContextFromSources(sources, passages, webSources)
 
 	systemPrompt := `You are an expert analyst for defense industry news and information. 
 Answer the user's question based ONLY on the provided context from recent news articles and web sources.
 
 Guidelines:
 - Be factual and cite specific information from the articles
+- Cite the article passages you use with their [n] markers, e.g. [2]
 - If the context doesn't contain enough information, say so
 - Focus on the companies mentioned: RTX (Raytheon Technologies) and US War Department
 - Provide specific details like dates, numbers, and contract values when available
@@ -274,135 +383,76 @@ Guidelines:
 Context from recent articles and web sources:
 ` + contextText
 
-	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: s.model,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role:    openai.ChatMessageRoleSystem,
-				Content: systemPrompt,
-			},
-			{
-				Role:    openai.ChatMessageRoleUser,
-				Content: question,
-			},
-		},
-		MaxTokens:   500,
-		Temperature: 0.3, // Slightly higher for more natural responses
-	})
-
-	if err != nil {
-		return "", err
-	}
-
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no response from OpenAI")
-	}
-
-	return resp.Choices[0].Message.Content, nil
+	// Slightly higher temperature for more natural responses
+	return chatRequest(s.models.Answer, systemPrompt, question, 500, 0.3)
 }
 
-// Helper methods
-
-func (s *OpenAIService) parseAnalysisResponse(content, originalQuestion string) *ai.QueryAnalysisResult {
-	// Simplified parsing - in production, use proper JSON unmarshaling
-	analysis := &ai.QueryAnalysisResult{
-		QueryType:    ai.QueryTypeGeneral, // Default
-		CompanyNames: []string{},
-		Keywords:     []string{},
-		SearchTerms:  []string{},
-	}
-
-	// Basic parsing logic - extract company names
-	lowerContent := strings.ToLower(originalQuestion)
-	if strings.Contains(lowerContent, "rtx") || strings.Contains(lowerContent, "raytheon") {
-		analysis.CompanyNames = append(analysis.CompanyNames, "Raytheon Technologies")
-	}
-	if strings.Contains(lowerContent, "war department") || strings.Contains(lowerContent, "defense") || strings.Contains(lowerContent, "military") {
-		analysis.CompanyNames = append(analysis.CompanyNames, "US War Department")
-	}
-
-	// Determine query type
-	if strings.Contains(lowerContent, "quarter") || strings.Contains(lowerContent, "earnings") || strings.Contains(lowerContent, "revenue") || strings.Contains(lowerContent, "financial") {
-		analysis.QueryType = ai.QueryTypeFinancial
-	} else if strings.Contains(lowerContent, "contract") || strings.Contains(lowerContent, "award") || strings.Contains(lowerContent, "deal") {
-		analysis.QueryType = ai.QueryTypeContracts
-	}
-
-	// Set time window for recent queries
-	if strings.Contains(lowerContent, "recent") || strings.Contains(lowerContent, "latest") || strings.Contains(lowerContent, "this quarter") {
-		analysis.TimeWindow = &ai.TimeWindow{
-			Period: "recent",
-		}
-	}
-
-	return analysis
+// complete generates the answer of a completion request
+func (s *OpenAIService) complete(ctx context.Context, request ChatRequest) (string, error) {
+	return s.provider.Chat(ctx, request)
 }
 
-func (s *OpenAIService) rankArticlesByRelevance(articles []*news.Article, analysis *ai.QueryAnalysisResult) []ai.SourceReference {
-	sources := make([]ai.SourceReference, 0, len(articles))
-
-	for _, article := range articles {
-		// Calculate relevance score based on query analysis
-		relevanceScore := article.RelevanceScore
-
-		// Boost score if article matches query type
-		if analysis.QueryType == ai.QueryTypeFinancial && 
-		   (strings.Contains(strings.ToLower(article.Title), "earnings") || 
-			strings.Contains(strings.ToLower(article.Title), "quarter") ||
-			strings.Contains(strings.ToLower(article.Title), "revenue")) {
-			relevanceScore += 0.2
-		}
+// streamCompletion generates the answer of a completion request, passing
+// each part to onDelta as it arrives. It stops when onDelta fails, closing
+// the stream to the model.
+func (s *OpenAIService) streamCompletion(ctx context.Context, request ChatRequest, onDelta func(text string) error) (string, error) {
+	stream, err := s.provider.ChatStream(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
 
-		if analysis.QueryType == ai.QueryTypeContracts && 
-		   (strings.Contains(strings.ToLower(article.Title), "contract") || 
-			strings.Contains(strings.ToLower(article.Title), "award")) {
-			relevanceScore += 0.2
+	var answer strings.Builder
+	for {
+		text, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
 		}
-
-		// Determine primary company for this article
-		companyName := "Unknown"
-		if len(article.Companies) > 0 {
-			companyName = article.Companies[0]
+		if err != nil {
+			return answer.String(), err
 		}
 
-		source := ai.SourceReference{
-			ArticleID:      article.ID.Hex(),
-			Title:          article.Title,
-			Summary:        article.Summary,
-			CompanyName:    companyName,
-			PublishedDate:  article.PublishedDate,
-			SourceURL:      article.SourceURL,
-			RelevanceScore: relevanceScore,
+		answer.WriteString(text)
+		if err := onDelta(text); err != nil {
+			return answer.String(), err
 		}
-
-		sources = append(sources, source)
 	}
 
-	// Sort by relevance score (descending)
-	for i := 0; i < len(sources)-1; i++ {
-		for j := i + 1; j < len(sources); j++ {
-			if sources[i].RelevanceScore < sources[j].RelevanceScore {
-				sources[i], sources[j] = sources[j], sources[i]
-			}
-		}
+	if answer.Len() == 0 {
+		return "", ErrNoResponse
 	}
+	return answer.String(), nil
+}
+
+// Helper methods
 
-	return sources
+// sourceArticles returns the articles retrieved with the sources; sources
+// without one are given to the model by their summary
+func sourceArticles(sources []ai.SourceReference) []*news.Article {
+	articles := make([]*news.Article, len(sources))
+	for i, source := range sources {
+		articles[i] = source.Article
+	}
+	return articles
 }
 
-func (s *OpenAIService) buildContextFromSources(sources []ai.SourceReference, webSources []ai.WebSearchSource) string {
+// buildContextFromSources formats the passages of each source, followed by the web sources
+func (s *OpenAIService) buildContextFromSources(sources []ai.SourceReference, passages []ai.ContextPassage, webSources []ai.WebSearchSource) string {
 	var contextBuilder strings.Builder
 	
 	// Add database sources
-	if len(sources) > 0 {
+	if len(passages) > 0 {
 		contextBuilder.WriteString("\n=== DATABASE SOURCES ===\n")
-		for i, source := range sources {
-			contextBuilder.WriteString(fmt.Sprintf("\n--- Article %d ---\n", i+1))
-			contextBuilder.WriteString(fmt.Sprintf("Company: %s\n", source.CompanyName))
-			contextBuilder.WriteString(fmt.Sprintf("Title: %s\n", source.Title))
-			contextBuilder.WriteString(fmt.Sprintf("Date: %s\n", source.PublishedDate.Format("2006-01-02")))
-			contextBuilder.WriteString(fmt.Sprintf("Summary: %s\n", source.Summary))
-			contextBuilder.WriteString(fmt.Sprintf("URL: %s\n", source.SourceURL))
+		for i, passage := range passages {
+			if i == 0 || passages[i-1].Source != passage.Source {
+				source := sources[passage.Source]
+				contextBuilder.WriteString(fmt.Sprintf("\n--- Article %d ---\n", passage.Source+1))
+				contextBuilder.WriteString(fmt.Sprintf("Company: %s\n", source.CompanyName))
+				contextBuilder.WriteString(fmt.Sprintf("Title: %s\n", source.Title))
+				contextBuilder.WriteString(fmt.Sprintf("Date: %s\n", source.PublishedDate.Format("2006-01-02")))
+				contextBuilder.WriteString(fmt.Sprintf("URL: %s\n", source.SourceURL))
+			}
+			contextBuilder.WriteString(fmt.Sprintf("[%d] %s\n", passage.Marker, passage.Chunk.Text))
 		}
 	}
 	
@@ -532,23 +582,13 @@ func (s *OpenAIService) isDefenseAeronauticsQuestion(question string, companyNam
 		}
 	}
 
-	// Check if any of the identified company names are defense-related
-	for _, companyName := range companyNames {
-		lowerCompany := strings.ToLower(companyName)
-		if strings.Contains(lowerCompany, "raytheon") ||
-		   strings.Contains(lowerCompany, "rtx") ||
-		   strings.Contains(lowerCompany, "war department") ||
-		   strings.Contains(lowerCompany, "lockheed") ||
-		   strings.Contains(lowerCompany, "defense") {
-			return true
-		}
-	}
-
-	return false
+	// Identified company names are resolved against the tracked defense,
+	// aerospace and government companies
+	return len(companyNames) > 0
 }
 
-// generateDirectResponse generates a response using OpenAI's knowledge without database context
-func (s *OpenAIService) generateDirectResponse(ctx context.Context, question string, analysis *ai.QueryAnalysisResult) (string, error) {
+// directRequest prepares a request answering from the model's knowledge without database context
+func (s *OpenAIService) directRequest(question string) ChatRequest {
 	systemPrompt := `You are a concise defense and aerospace industry analyst. Answer questions directly and briefly.
 
 Guidelines:
@@ -562,31 +602,8 @@ Guidelines:
 
 Important: Keep responses short, direct, and to the point. Only provide information about defense and aerospace companies and related topics. Give longer answers only if specifically requested. such as "explain in detail" or "provide a comprehensive overview".`
 
-	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: s.model,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role:    openai.ChatMessageRoleSystem,
-				Content: systemPrompt,
-			},
-			{
-				Role:    openai.ChatMessageRoleUser,
-				Content: question,
-			},
-		},
-		MaxTokens:   150, // Reduced for shorter responses
-		Temperature: 0.3,
-	})
-
-	if err != nil {
-		return "", err
-	}
-
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no response from OpenAI")
-	}
-
-	return resp.Choices[0].Message.Content, nil
+	// Reduced token limit for shorter responses
+	return chatRequest(s.models.Answer, systemPrompt, question, 150, 0.3)
 }
 
 // convertGoogleResultsToWebSources converts Google search results to WebSearchSource format
@@ -605,8 +622,8 @@ func (s *OpenAIService) convertGoogleResultsToWebSources(results []search.Google
 	return webSources
 }
 
-// generateResponseWithWebSearch generates a response using Google search results and OpenAI
-func (s *OpenAIService) generateResponseWithWebSearch(ctx context.Context, question string, searchResults []search.GoogleSearchResult, analysis *ai.QueryAnalysisResult) (string, error) {
+// webSearchRequest prepares a request answering from Google search results
+func (s *OpenAIService) webSearchRequest(question string, searchResults []search.GoogleSearchResult) ChatRequest {
 	// Build context from search results
 	var contextBuilder strings.Builder
 	contextBuilder.WriteString("Search Results:\n")
@@ -632,31 +649,8 @@ Important: Base your answer on the provided search results. Keep responses conci
 
 	userPrompt := fmt.Sprintf("Question: %s\n\n%s", question, contextBuilder.String())
 
-	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: s.model,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role:    openai.ChatMessageRoleSystem,
-				Content: systemPrompt,
-			},
-			{
-				Role:    openai.ChatMessageRoleUser,
-				Content: userPrompt,
-			},
-		},
-		MaxTokens:   300, // Moderate token limit for search-based responses
-		Temperature: 0.3,
-	})
-
-	if err != nil {
-		return "", err
-	}
-
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no response from OpenAI")
-	}
-
-	return resp.Choices[0].Message.Content, nil
+	// Moderate token limit for search-based responses
+	return chatRequest(s.models.Answer, systemPrompt, userPrompt, 300, 0.3)
 }
 
 // SummarizeArticle generates a concise summary of an article using AI
@@ -704,8 +698,15 @@ func (s *OpenAIService) SummarizeArticle(ctx context.Context, articleID string)
 		contentBuilder.WriteString(fmt.Sprintf("Summary: %s\n\n", article.Summary))
 	}
 	
-	if article.Content != "" {
-		contentBuilder.WriteString(fmt.Sprintf("Content: %s", article.Content))
+	// Prefer the extracted full text, falling back to feed content and then
+	// the summary, cut at a sentence to fit the model's budget
+	if body := article.BodyText(); body != article.Summary {
+		budget := s.budgets.For(s.models.Summary)
+		if truncated := news.TruncateTokens(body, budget); len(truncated) < len(body) {
+			s.logger.Info("Truncated article to the token budget", "article_id", articleID, "budget", budget, "tokens", news.EstimateTokens(body))
+			body = truncated
+		}
+		contentBuilder.WriteString(fmt.Sprintf("Content: %s", body))
 	} else {
 		// If no content, use the summary as the main content
 		contentBuilder.WriteString(fmt.Sprintf("Article Summary: %s", article.Summary))
@@ -727,32 +728,14 @@ Guidelines:
 
 	userPrompt := fmt.Sprintf("Article to summarize:\n\nSource URL: %s\n\n%s", article.SourceURL, contentBuilder.String())
 
-	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: s.model,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role:    openai.ChatMessageRoleSystem,
-				Content: systemPrompt,
-			},
-			{
-				Role:    openai.ChatMessageRoleUser,
-				Content: userPrompt,
-			},
-		},
-		MaxTokens:   600, // Allow up to 600 tokens for comprehensive summaries
-		Temperature: 0.2, // Lower temperature for more consistent, factual summaries
-	})
-
+	// Allow up to 600 tokens for comprehensive summaries, with a lower
+	// temperature for more consistent, factual summaries
+	summary, err := s.provider.Chat(ctx, chatRequest(s.models.Summary, systemPrompt, userPrompt, 600, 0.2))
 	if err != nil {
 		s.logger.Error("Failed to generate article summary", "error", err, "article_id", articleID)
 		return nil, fmt.Errorf("failed to generate summary: %w", err)
 	}
 
-	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no response from OpenAI")
-	}
-
-	summary := resp.Choices[0].Message.Content
 	processingTime := time.Since(startTime)
 
 	response := &ai.ArticleSummaryResponse{
@@ -0,0 +1,102 @@
+package ai
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+// Chat message roles
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// ErrNoResponse is returned when a model answers with no content
+var ErrNoResponse = errors.New("no response from model")
+
+// LLMProvider is a language model backend: OpenAI, a self-hosted server
+// speaking the OpenAI API, or a scripted fake. Every AI component talks to
+// models through it, naming the model of its task in each request.
+type LLMProvider interface {
+	// Chat returns the model's answer to a chat
+	Chat(ctx context.Context, req ChatRequest) (string, error)
+
+	// ChatStream returns the model's answer to a chat as it is generated.
+	// Cancelling ctx or closing the stream cancels the request.
+	ChatStream(ctx context.Context, req ChatRequest) (ChatStream, error)
+
+	// Embed returns one vector per text, in order
+	Embed(ctx context.Context, model string, texts []string) ([][]float32, error)
+}
+
+// ChatStream is an answer being generated
+type ChatStream interface {
+	// Recv returns the next part of the answer, or io.EOF after the last one
+	Recv() (string, error)
+
+	// Close stops the stream
+	Close() error
+}
+
+// ChatMessage is a message of a chat
+type ChatMessage struct {
+	Role    string
+	Content string
+}
+
+// ChatRequest is a chat for a model to answer
+type ChatRequest struct {
+	Model       string
+	Messages    []ChatMessage
+	MaxTokens   int
+	Temperature float32
+	// Schema constrains the answer to JSON following it
+	Schema *ResponseSchema
+}
+
+// ResponseSchema is the JSON schema of a structured answer
+type ResponseSchema struct {
+	Name       string
+	Definition *jsonschema.Definition
+	Strict     bool
+}
+
+// chatRequest builds a request of a system prompt and a user prompt
+func chatRequest(model, systemPrompt, userPrompt string, maxTokens int, temperature float32) ChatRequest {
+	return ChatRequest{
+		Model: model,
+		Messages: []ChatMessage{
+			{
+				Role:    RoleSystem,
+				Content: systemPrompt,
+			},
+			{
+				Role:    RoleUser,
+				Content: userPrompt,
+			},
+		},
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+	}
+}
+
+// Models names the model of each AI task
+type Models struct {
+	Analysis  string // Analysing questions and classifying, scoring, extracting and reranking articles
+	Answer    string // Answering questions
+	Summary   string // Summarizing articles and conversations
+	Embedding string // Embedding article chunks
+}
+
+// DefaultModels returns the OpenAI models used when none are configured
+func DefaultModels() Models {
+	return Models{
+		Analysis:  "gpt-4o-mini",
+		Answer:    "gpt-4o-mini",
+		Summary:   "gpt-4o-mini",
+		Embedding: "text-embedding-3-small",
+	}
+}
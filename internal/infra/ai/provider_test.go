@@ -0,0 +1,143 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// compatibleServer serves the API of a self-hosted model server, recording
+// the body of each request
+func compatibleServer(t *testing.T, handler func(w http.ResponseWriter, body map[string]interface{})) (string, *[]map[string]interface{}) {
+	t.Helper()
+
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer local-key" {
+			t.Errorf("Expected the API key as a bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Invalid request body: %v", err)
+		}
+		body["path"] = r.URL.Path
+		bodies = append(bodies, body)
+		handler(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL + "/v1", &bodies
+}
+
+func TestCompatibleProviderChat(t *testing.T) {
+	baseURL, bodies := compatibleServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+		w.Write([]byte(`{"choices": [{"index": 0, "message": {"role": "assistant", "content": ` +
+			`"{\"query_type\": \"contracts\", \"company_names\": [\"RTX\"], \"keywords\": [\"radar\"], \"search_terms\": [], \"time_expression\": \"\"}"}}]}`))
+	})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	analyzer := NewOpenAIQueryAnalyzer(NewCompatibleProvider(baseURL+"/", "local-key"), "llama3.1", ai.NewRuleQueryAnalyzer(), logger)
+
+	analysis, err := analyzer.Analyze(context.Background(), "Which radar contracts did RTX win?")
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if analysis.QueryType != ai.QueryTypeContracts || !slices.Equal(analysis.Keywords, []string{"radar"}) {
+		t.Errorf("Expected the model's analysis, got %+v", analysis)
+	}
+
+	body := (*bodies)[0]
+	if body["path"] != "/v1/chat/completions" || body["model"] != "llama3.1" {
+		t.Errorf("Expected a chat completion with the task's model, got %v", body)
+	}
+	if temperature, ok := body["temperature"]; !ok || temperature != 0.0 {
+		t.Errorf("Expected the zero temperature sent, got %v", body["temperature"])
+	}
+	format, _ := body["response_format"].(map[string]interface{})
+	if format["type"] != "json_schema" {
+		t.Errorf("Expected the answer constrained to the schema, got %v", body["response_format"])
+	}
+}
+
+func TestCompatibleProviderErrors(t *testing.T) {
+	baseURL, _ := compatibleServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"message": "model \"llama9\" not found"}}`))
+	})
+	provider := NewCompatibleProvider(baseURL, "local-key")
+
+	_, err := provider.Chat(context.Background(), chatRequest("llama9", "system", "question", 10, 0))
+	if err == nil || !strings.Contains(err.Error(), "404") || !strings.Contains(err.Error(), `model "llama9" not found`) {
+		t.Errorf("Expected the status and message of the API error, got %v", err)
+	}
+
+	if _, err := provider.ChatStream(context.Background(), chatRequest("llama9", "system", "question", 10, 0)); err == nil {
+		t.Error("Expected the stream to fail")
+	}
+}
+
+func TestCompatibleProviderEmbed(t *testing.T) {
+	baseURL, bodies := compatibleServer(t, func(w http.ResponseWriter, body map[string]interface{}) {
+		w.Write([]byte(`{"data": [{"index": 1, "embedding": [0, 1, 0]}, {"index": 0, "embedding": [1, 0, 0]}]}`))
+	})
+	provider := NewCompatibleProvider(baseURL, "local-key")
+
+	vectors, err := NewOpenAIEmbedder(provider, "nomic-embed-text", 3).Embed(context.Background(), []string{"radar", "missile"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Errorf("Expected the vectors in input order, got %v", vectors)
+	}
+	if body := (*bodies)[0]; body["path"] != "/v1/embeddings" || body["model"] != "nomic-embed-text" {
+		t.Errorf("Expected an embedding request with the embedding model, got %v", body)
+	}
+
+	if _, err := NewOpenAIEmbedder(provider, "nomic-embed-text", 768).Embed(context.Background(), []string{"radar", "missile"}); err == nil {
+		t.Error("Expected vectors of another length rejected")
+	}
+}
+
+func TestScriptedProvider(t *testing.T) {
+	provider := NewScriptedProvider(`["contracts"]`)
+	provider.Reply(ScriptedReply{Err: errors.New("model unavailable")})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	classifier := NewOpenAIClassifier(provider, "test-model", news.NewRuleClassifier(), logger)
+	article := &news.Article{Title: "Boeing delivers first aircraft of the year"}
+
+	categories, err := classifier.Classify(context.Background(), article)
+	if err != nil || !slices.Equal(categories, []news.Category{news.CategoryContracts}) {
+		t.Errorf("Expected the scripted categories, got %v (error %v)", categories, err)
+	}
+
+	want, _ := news.NewRuleClassifier().Classify(context.Background(), article)
+	categories, err = classifier.Classify(context.Background(), article)
+	if err != nil || !slices.Equal(categories, want) {
+		t.Errorf("Expected the fallback categories %v on a scripted error, got %v", want, categories)
+	}
+
+	if _, err := provider.Chat(context.Background(), ChatRequest{}); !errors.Is(err, ErrScriptExhausted) {
+		t.Errorf("Expected ErrScriptExhausted, got %v", err)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 3 || requests[0].Model != "test-model" || requests[0].Messages[0].Role != RoleSystem {
+		t.Errorf("Expected the requests recorded, got %+v", requests)
+	}
+
+	vectors, _ := provider.Embed(context.Background(), "test-model", []string{"radar contract", "radar contract"})
+	if !slices.Equal(vectors[0], vectors[1]) {
+		t.Error("Expected equal texts embedded alike")
+	}
+}
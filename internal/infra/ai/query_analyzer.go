@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+// llmAnalysis is the query analysis as returned by the model
+type llmAnalysis struct {
+	QueryType      string   `json:"query_type"`
+	CompanyNames   []string `json:"company_names"`
+	Keywords       []string `json:"keywords"`
+	SearchTerms    []string `json:"search_terms"`
+	TimeExpression string   `json:"time_expression"`
+}
+
+// analysisSchema is the JSON schema the model's answer must follow
+var analysisSchema = jsonschema.Definition{
+	Type: jsonschema.Object,
+	Properties: map[string]jsonschema.Definition{
+		"query_type": {
+			Type:        jsonschema.String,
+			Enum:        []string{string(ai.QueryTypeFinancial), string(ai.QueryTypeContracts), string(ai.QueryTypeGeneral), string(ai.QueryTypeComparison), string(ai.QueryTypeNews)},
+			Description: "The kind of information asked for",
+		},
+		"company_names": {
+			Type:        jsonschema.Array,
+			Items:       &jsonschema.Definition{Type: jsonschema.String},
+			Description: "Companies and government organisations mentioned, as written",
+		},
+		"keywords": {
+			Type:        jsonschema.Array,
+			Items:       &jsonschema.Definition{Type: jsonschema.String},
+			Description: "Single words to search articles for, without filler words",
+		},
+		"search_terms": {
+			Type:        jsonschema.Array,
+			Items:       &jsonschema.Definition{Type: jsonschema.String},
+			Description: "Multi-word names of programs, systems or events to search articles for",
+		},
+		"time_expression": {
+			Type:        jsonschema.String,
+			Description: "The words of the question naming the period it is restricted to, such as \"Q2 2025\" or \"since the Paris Air Show\", or an empty string",
+		},
+	},
+	Required:             []string{"query_type", "company_names", "keywords", "search_terms", "time_expression"},
+	AdditionalProperties: false,
+}
+
+// OpenAIQueryAnalyzer analyses questions with a language model constrained
+// to a JSON schema. When the model fails or answers outside the schema, it
+// falls back to another analyzer.
+type OpenAIQueryAnalyzer struct {
+	provider LLMProvider
+	fallback ai.QueryAnalyzer
+	model    string
+	logger   *slog.Logger
+}
+
+// NewOpenAIQueryAnalyzer creates an LLM query analyzer; fallback is usually
+// the rule-based analyzer
+func NewOpenAIQueryAnalyzer(provider LLMProvider, model string, fallback ai.QueryAnalyzer, logger *slog.Logger) *OpenAIQueryAnalyzer {
+	return &OpenAIQueryAnalyzer{
+		provider: provider,
+		fallback: fallback,
+		model:    model,
+		logger:   logger,
+	}
+}
+
+// Analyze implements ai.QueryAnalyzer
+func (a *OpenAIQueryAnalyzer) Analyze(ctx context.Context, question string) (*ai.QueryAnalysisResult, error) {
+	analysis, err := a.analyze(ctx, question)
+	if err != nil {
+		a.logger.Warn("LLM query analysis failed, using fallback analyzer", "error", err)
+		return a.fallback.Analyze(ctx, question)
+	}
+	return analysis, nil
+}
+
+// analyze asks the model for the analysis and validates the answer
+func (a *OpenAIQueryAnalyzer) analyze(ctx context.Context, question string) (*ai.QueryAnalysisResult, error) {
+	systemPrompt := `You are a query analyzer for a defense industry news system.
+Analyze the user's question: classify it, list the companies and organisations it mentions
+(e.g. RTX, Lockheed Martin, US War Department), the words and names to search articles for,
+and the words naming the period it is restricted to, copied from the question.`
+
+	request := chatRequest(a.model, systemPrompt, question, 300, 0)
+	request.Schema = &ResponseSchema{
+		Name:       "query_analysis",
+		Definition: &analysisSchema,
+		Strict:     true,
+	}
+
+	content, err := a.provider.Chat(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAnalysis(content, question)
+}
+
+// parseAnalysis strictly decodes the model's answer: it must follow the
+// schema exactly, without unknown fields
+func parseAnalysis(content, question string) (*ai.QueryAnalysisResult, error) {
+	var answer llmAnalysis
+	if err := jsonschema.VerifySchemaAndUnmarshal(analysisSchema, []byte(content), &answer); err != nil {
+		return nil, fmt.Errorf("invalid analysis response: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(content)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&answer); err != nil {
+		return nil, fmt.Errorf("invalid analysis response: %w", err)
+	}
+
+	analysis := &ai.QueryAnalysisResult{
+		QueryType:    ai.QueryType(answer.QueryType),
+		CompanyNames: answer.CompanyNames,
+		Keywords:     answer.Keywords,
+		SearchTerms:  answer.SearchTerms,
+	}
+	if answer.TimeExpression != "" {
+		// The service resolves the expression to dates
+		analysis.TimeWindow = &ai.TimeWindow{Expression: answer.TimeExpression}
+	}
+	if err := analysis.Normalize(); err != nil {
+		return nil, err
+	}
+	analysis.Categories = ai.QueryCategories(analysis.QueryType, question)
+
+	return analysis, nil
+}
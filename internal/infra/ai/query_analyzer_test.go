@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+func TestParseAnalysis(t *testing.T) {
+	content := `{"query_type": "contracts", "company_names": ["Raytheon", "raytheon"], "keywords": ["radar", "award"],
+		"search_terms": ["SPY-6"], "time_expression": "this year"}`
+
+	analysis, err := parseAnalysis(content, "Which radar contracts did Raytheon win this year?")
+	if err != nil {
+		t.Fatalf("parseAnalysis() error = %v", err)
+	}
+	if analysis.QueryType != ai.QueryTypeContracts {
+		t.Errorf("QueryType = %s", analysis.QueryType)
+	}
+	if !slices.Equal(analysis.CompanyNames, []string{"Raytheon"}) {
+		t.Errorf("CompanyNames = %q", analysis.CompanyNames)
+	}
+	if analysis.TimeWindow == nil || analysis.TimeWindow.Expression != "this year" {
+		t.Errorf("TimeWindow = %+v", analysis.TimeWindow)
+	}
+	if !slices.Contains(analysis.Categories, news.CategoryContracts) {
+		t.Errorf("Categories = %v", analysis.Categories)
+	}
+
+	none, err := parseAnalysis(`{"query_type": "general", "company_names": [], "keywords": [], "search_terms": [], "time_expression": ""}`, "hello")
+	if err != nil || none.TimeWindow != nil {
+		t.Errorf("Expected no time window, got %+v (error %v)", none, err)
+	}
+}
+
+func TestParseAnalysisRejectsInvalidOutput(t *testing.T) {
+	tests := map[string]string{
+		"not json":      `The question is about contracts.`,
+		"missing field": `{"query_type": "news", "company_names": [], "keywords": [], "time_expression": ""}`,
+		"unknown type":  `{"query_type": "gossip", "company_names": [], "keywords": [], "search_terms": [], "time_expression": ""}`,
+		"unknown field": `{"query_type": "news", "company_names": [], "keywords": [], "search_terms": [], "time_expression": "", "mood": "happy"}`,
+		"wrong type":    `{"query_type": "news", "company_names": "RTX", "keywords": [], "search_terms": [], "time_expression": ""}`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseAnalysis(content, "question"); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
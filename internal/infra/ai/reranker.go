@@ -0,0 +1,106 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+)
+
+// maxRerankSummaryRunes bounds each candidate summary sent to the model
+const maxRerankSummaryRunes = 400
+
+// OpenAIReranker scores retrieval candidates with a language model. When
+// the model fails or answers incompletely, it falls back to another reranker.
+type OpenAIReranker struct {
+	provider LLMProvider
+	fallback ai.Reranker
+	model    string
+	logger   *slog.Logger
+}
+
+// NewOpenAIReranker creates an LLM reranker; fallback is usually the
+// heuristic reranker
+func NewOpenAIReranker(provider LLMProvider, model string, fallback ai.Reranker, logger *slog.Logger) *OpenAIReranker {
+	return &OpenAIReranker{
+		provider: provider,
+		fallback: fallback,
+		model:    model,
+		logger:   logger,
+	}
+}
+
+// Rerank implements ai.Reranker. Rerank scores are the model's 0-10 ratings
+// scaled to 0-1.
+func (r *OpenAIReranker) Rerank(ctx context.Context, question string, analysis *ai.QueryAnalysisResult, candidates []*ai.RetrievalCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if err := r.rerank(ctx, question, candidates); err != nil {
+		r.logger.Warn("LLM reranking failed, using fallback reranker", "error", err)
+		return r.fallback.Rerank(ctx, question, analysis, candidates)
+	}
+	return nil
+}
+
+// rerank asks the model to rate every candidate and applies the ratings
+func (r *OpenAIReranker) rerank(ctx context.Context, question string, candidates []*ai.RetrievalCandidate) error {
+	systemPrompt := `You rank defense and aerospace news articles by how well they answer a question.
+Rate every numbered article from 0 (irrelevant) to 10 (answers the question directly).
+Respond with JSON only: {"scores": [rating of article 1, rating of article 2, ...]}.`
+
+	var prompt strings.Builder
+	prompt.WriteString("Question: " + question + "\n\nArticles:\n")
+	for i, candidate := range candidates {
+		summary := candidate.Article.Summary
+		if runes := []rune(summary); len(runes) > maxRerankSummaryRunes {
+			summary = string(runes[:maxRerankSummaryRunes])
+		}
+		fmt.Fprintf(&prompt, "%d. %s (%s)\n%s\n\n", i+1, candidate.Article.Title, candidate.Article.PublishedDate.Format("2006-01-02"), summary)
+	}
+
+	content, err := r.provider.Chat(ctx, chatRequest(r.model, systemPrompt, prompt.String(), 20+5*len(candidates), 0))
+	if err != nil {
+		return err
+	}
+
+	scores, err := parseRerankScores(content, len(candidates))
+	if err != nil {
+		return err
+	}
+	for i, candidate := range candidates {
+		candidate.Scores.Rerank = scores[i]
+	}
+	return nil
+}
+
+// parseRerankScores decodes the model's ratings, scaled to 0-1
+func parseRerankScores(content string, count int) ([]float64, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.Trim(content, "` \n")
+
+	var answer struct {
+		Scores []float64 `json:"scores"`
+	}
+	if err := json.Unmarshal([]byte(content), &answer); err != nil {
+		return nil, fmt.Errorf("invalid rerank response: %w", err)
+	}
+	if len(answer.Scores) != count {
+		return nil, fmt.Errorf("expected %d rerank scores, got %d", count, len(answer.Scores))
+	}
+
+	scores := make([]float64, count)
+	for i, score := range answer.Scores {
+		if math.IsNaN(score) || score < 0 || score > 10 {
+			return nil, fmt.Errorf("rerank score out of range: %v", score)
+		}
+		scores[i] = score / 10
+	}
+	return scores, nil
+}
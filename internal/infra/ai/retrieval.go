@@ -0,0 +1,309 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LexicalSearcher ranks articles by full-text match, like news.Service
+type LexicalSearcher interface {
+	SearchArticles(ctx context.Context, query *news.SearchQuery) ([]*news.SearchResult, int64, error)
+}
+
+// SemanticSearcher ranks articles by embedding similarity, like embedding.Service
+type SemanticSearcher interface {
+	Search(ctx context.Context, text string, filter *embedding.Filter, limit int) ([]*embedding.ArticleMatch, error)
+}
+
+// ArticleLoader loads the articles found by semantic search in one batch,
+// like news.Service
+type ArticleLoader interface {
+	GetArticlesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*news.Article, error)
+}
+
+// RetrievalConfig tunes the hybrid retrieval pipeline
+type RetrievalConfig struct {
+	// LexicalWeight and SemanticWeight scale each ranking's contribution
+	// to the fusion score; a zero weight disables the retriever
+	LexicalWeight  float64
+	SemanticWeight float64
+	// RRFConstant dampens the advantage of top ranks; 60 is the usual choice
+	RRFConstant float64
+	// Candidates is the number of articles requested from each retriever
+	Candidates int
+	// Limit is the number of sources returned after reranking
+	Limit int
+}
+
+// DefaultRetrievalConfig returns equal weights for both retrievers
+func DefaultRetrievalConfig() RetrievalConfig {
+	return RetrievalConfig{
+		LexicalWeight:  1,
+		SemanticWeight: 1,
+		RRFConstant:    60,
+		Candidates:     30,
+		Limit:          10,
+	}
+}
+
+// withDefaults fills unset fields; weights are kept since zero disables a retriever
+func (c RetrievalConfig) withDefaults() RetrievalConfig {
+	defaults := DefaultRetrievalConfig()
+	if c.RRFConstant <= 0 {
+		c.RRFConstant = defaults.RRFConstant
+	}
+	if c.Candidates <= 0 {
+		c.Candidates = defaults.Candidates
+	}
+	if c.Limit <= 0 {
+		c.Limit = defaults.Limit
+	}
+	return c
+}
+
+// RetrievalQuery is a question with the filters retrieval must respect
+type RetrievalQuery struct {
+	Question  string
+	Analysis  *ai.QueryAnalysisResult
+	Companies []string // Articles must mention any of the companies
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// RetrievalPipeline retrieves articles for a question with full-text and
+// vector search in parallel, fuses both rankings with weighted reciprocal
+// rank fusion and reranks the fused candidates
+type RetrievalPipeline struct {
+	lexical  LexicalSearcher
+	semantic SemanticSearcher
+	articles ArticleLoader
+	reranker ai.Reranker
+	config   RetrievalConfig
+	logger   *slog.Logger
+}
+
+// NewRetrievalPipeline creates a retrieval pipeline.
+// semantic is optional; when nil, only full-text search is used.
+// reranker is optional; when nil, the heuristic reranker is used.
+func NewRetrievalPipeline(lexical LexicalSearcher, semantic SemanticSearcher, articles ArticleLoader, reranker ai.Reranker, config RetrievalConfig, logger *slog.Logger) *RetrievalPipeline {
+	if reranker == nil {
+		reranker = ai.NewHeuristicReranker(ai.DefaultHeuristicWeights())
+	}
+
+	return &RetrievalPipeline{
+		lexical:  lexical,
+		semantic: semantic,
+		articles: articles,
+		reranker: reranker,
+		config:   config.withDefaults(),
+		logger:   logger,
+	}
+}
+
+// Retrieve returns the best sources for the query, best first. It fails only
+// when every enabled retriever fails.
+func (p *RetrievalPipeline) Retrieve(ctx context.Context, query *RetrievalQuery) ([]ai.SourceReference, error) {
+	var (
+		wg                        sync.WaitGroup
+		lexical                   []*news.SearchResult
+		semantic                  []*embedding.ArticleMatch
+		lexicalErr, semanticErr   error
+		lexicalUsed, semanticUsed bool
+	)
+
+	if p.config.LexicalWeight > 0 {
+		lexicalUsed = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lexical, lexicalErr = p.searchLexical(ctx, query)
+		}()
+	}
+	if p.semantic != nil && p.config.SemanticWeight > 0 {
+		semanticUsed = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semantic, semanticErr = p.searchSemantic(ctx, query)
+		}()
+	}
+	wg.Wait()
+
+	if lexicalErr != nil {
+		p.logger.Warn("Full-text retrieval failed", "error", lexicalErr)
+	}
+	if semanticErr != nil {
+		p.logger.Warn("Semantic retrieval failed", "error", semanticErr)
+	}
+	if (!lexicalUsed || lexicalErr != nil) && (!semanticUsed || semanticErr != nil) {
+		return nil, errors.Join(lexicalErr, semanticErr)
+	}
+
+	candidates := p.fuse(ctx, lexical, semantic)
+	return p.rerank(ctx, query, candidates)
+}
+
+// Rank reranks articles retrieved another way, such as the most recent
+// articles when retrieval finds nothing
+func (p *RetrievalPipeline) Rank(ctx context.Context, query *RetrievalQuery, articles []*news.Article) ([]ai.SourceReference, error) {
+	candidates := make([]*ai.RetrievalCandidate, len(articles))
+	for i, article := range articles {
+		candidates[i] = &ai.RetrievalCandidate{Article: article}
+	}
+	return p.rerank(ctx, query, candidates)
+}
+
+// searchLexical runs the full-text search; the question's search terms and
+// keywords are preferred over the raw question when the analysis has them
+func (p *RetrievalPipeline) searchLexical(ctx context.Context, query *RetrievalQuery) ([]*news.SearchResult, error) {
+	text := query.Question
+	if query.Analysis != nil {
+		if terms := strings.Join(append(append([]string{}, query.Analysis.SearchTerms...), query.Analysis.Keywords...), " "); strings.TrimSpace(terms) != "" {
+			text = terms
+		}
+	}
+
+	results, _, err := p.lexical.SearchArticles(ctx, &news.SearchQuery{
+		Text: text,
+		Filter: &news.NewsFilter{
+			Companies: query.Companies,
+			StartDate: query.StartDate,
+			EndDate:   query.EndDate,
+		},
+		Limit: p.config.Candidates,
+	})
+	if errors.Is(err, news.ErrEmptySearchQuery) {
+		return nil, nil
+	}
+	return results, err
+}
+
+// searchSemantic runs the vector search within the same filters
+func (p *RetrievalPipeline) searchSemantic(ctx context.Context, query *RetrievalQuery) ([]*embedding.ArticleMatch, error) {
+	return p.semantic.Search(ctx, query.Question, &embedding.Filter{
+		Companies: query.Companies,
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+	}, p.config.Candidates)
+}
+
+// fuse merges both rankings: each article scores the sum over rankings of
+// weight / (RRFConstant + rank). Articles found only by semantic search are
+// loaded in one batch; those that cannot be loaded are dropped.
+func (p *RetrievalPipeline) fuse(ctx context.Context, lexical []*news.SearchResult, semantic []*embedding.ArticleMatch) []*ai.RetrievalCandidate {
+	var candidates []*ai.RetrievalCandidate
+	byID := make(map[string]*ai.RetrievalCandidate)
+
+	for i, result := range lexical {
+		candidate := &ai.RetrievalCandidate{Article: result.Article}
+		candidate.Scores.Lexical = result.Score
+		candidate.Scores.LexicalRank = i + 1
+		candidate.Scores.Fusion = p.config.LexicalWeight / (p.config.RRFConstant + float64(i+1))
+		byID[result.Article.ID.Hex()] = candidate
+		candidates = append(candidates, candidate)
+	}
+
+	var missing []primitive.ObjectID
+	for _, match := range semantic {
+		if _, ok := byID[match.ArticleID.Hex()]; !ok {
+			missing = append(missing, match.ArticleID)
+		}
+	}
+	loaded := make(map[string]*news.Article, len(missing))
+	if len(missing) > 0 {
+		articles, err := p.articles.GetArticlesByIDs(ctx, missing)
+		if err != nil {
+			p.logger.Warn("Failed to load semantic matches", "error", err, "count", len(missing))
+		}
+		for _, article := range articles {
+			loaded[article.ID.Hex()] = article
+		}
+	}
+
+	for i, match := range semantic {
+		id := match.ArticleID.Hex()
+		candidate, ok := byID[id]
+		if !ok {
+			article, found := loaded[id]
+			if !found {
+				p.logger.Debug("Skipping unavailable article", "id", id)
+				continue
+			}
+			candidate = &ai.RetrievalCandidate{Article: article}
+			byID[id] = candidate
+			candidates = append(candidates, candidate)
+		}
+		candidate.Scores.Semantic = match.Score
+		candidate.Scores.SemanticRank = i + 1
+		candidate.Scores.Fusion += p.config.SemanticWeight / (p.config.RRFConstant + float64(i+1))
+	}
+
+	return candidates
+}
+
+// rerank scores the candidates with the reranker and converts the best to
+// source references. A failing reranker leaves the fusion order.
+func (p *RetrievalPipeline) rerank(ctx context.Context, query *RetrievalQuery, candidates []*ai.RetrievalCandidate) ([]ai.SourceReference, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	if err := p.reranker.Rerank(ctx, query.Question, query.Analysis, candidates); err != nil {
+		p.logger.Warn("Reranking failed, keeping fusion order", "error", err)
+		for _, candidate := range candidates {
+			candidate.Scores.Rerank = candidate.Scores.Fusion
+		}
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].Scores.Rerank != candidates[b].Scores.Rerank {
+			return candidates[a].Scores.Rerank > candidates[b].Scores.Rerank
+		}
+		return candidates[a].Scores.Fusion > candidates[b].Scores.Fusion
+	})
+	if len(candidates) > p.config.Limit {
+		candidates = candidates[:p.config.Limit]
+	}
+
+	sources := make([]ai.SourceReference, len(candidates))
+	for i, candidate := range candidates {
+		sources[i] = toSourceReference(candidate)
+	}
+	return sources, nil
+}
+
+// toSourceReference converts a ranked candidate. Its relevance score is the
+// article's own, between 0 and 1 whichever reranker ran; the rerank score,
+// whose scale depends on the reranker, is only reported in its scores.
+func toSourceReference(candidate *ai.RetrievalCandidate) ai.SourceReference {
+	article := candidate.Article
+
+	// Determine primary company for this article
+	companyName := "Unknown"
+	if len(article.Companies) > 0 {
+		companyName = article.Companies[0]
+	}
+
+	scores := candidate.Scores
+	return ai.SourceReference{
+		ArticleID:      article.ID.Hex(),
+		Title:          article.Title,
+		Summary:        article.Summary,
+		CompanyName:    companyName,
+		PublishedDate:  article.PublishedDate,
+		SourceURL:      article.SourceURL,
+		RelevanceScore: article.RelevanceScore,
+		Scores:         &scores,
+		Article:        article,
+	}
+}
@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// LabelledQuestion is a question with the IDs of the articles that answer it
+type LabelledQuestion struct {
+	Question  string   `json:"question"`
+	Companies []string `json:"companies,omitempty"`
+	Relevant  []string `json:"relevant"`
+}
+
+// QuestionEvaluation holds the retrieval metrics of one question
+type QuestionEvaluation struct {
+	Question string   `json:"question"`
+	Recall   float64  `json:"recall"` // Share of relevant articles in the top k
+	RR       float64  `json:"rr"`     // Reciprocal rank of the first relevant article
+	NDCG     float64  `json:"ndcg"`   // Normalised discounted cumulative gain at k
+	Ranked   []string `json:"ranked"` // Top k article IDs, best first
+}
+
+// RetrievalEvaluation averages the retrieval metrics over a question set
+type RetrievalEvaluation struct {
+	K         int                   `json:"k"`
+	Recall    float64               `json:"recall"`
+	MRR       float64               `json:"mrr"`
+	NDCG      float64               `json:"ndcg"`
+	Questions []*QuestionEvaluation `json:"questions"`
+}
+
+// EvaluateRetrieval runs every labelled question through the pipeline and
+// scores its top k sources against the labels with binary relevance
+func EvaluateRetrieval(ctx context.Context, pipeline *RetrievalPipeline, questions []LabelledQuestion, k int) (*RetrievalEvaluation, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive")
+	}
+
+	evaluation := &RetrievalEvaluation{K: k}
+	for _, question := range questions {
+		sources, err := pipeline.Retrieve(ctx, &RetrievalQuery{
+			Question:  question.Question,
+			Companies: question.Companies,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve %q: %w", question.Question, err)
+		}
+
+		ranked := make([]string, 0, k)
+		for _, source := range sources {
+			if len(ranked) == k {
+				break
+			}
+			ranked = append(ranked, source.ArticleID)
+		}
+
+		result := scoreRanking(question.Question, ranked, question.Relevant, k)
+		evaluation.Questions = append(evaluation.Questions, result)
+		evaluation.Recall += result.Recall
+		evaluation.MRR += result.RR
+		evaluation.NDCG += result.NDCG
+	}
+
+	if n := float64(len(questions)); n > 0 {
+		evaluation.Recall /= n
+		evaluation.MRR /= n
+		evaluation.NDCG /= n
+	}
+	return evaluation, nil
+}
+
+// scoreRanking computes the metrics of a top k ranking
+func scoreRanking(question string, ranked, relevant []string, k int) *QuestionEvaluation {
+	result := &QuestionEvaluation{Question: question, Ranked: ranked}
+	if len(relevant) == 0 {
+		return result
+	}
+
+	found := 0
+	dcg := 0.0
+	for i, id := range ranked {
+		if !slices.Contains(relevant, id) {
+			continue
+		}
+		found++
+		dcg += 1 / math.Log2(float64(i+2))
+		if result.RR == 0 {
+			result.RR = 1 / float64(i+1)
+		}
+	}
+
+	ideal := 0.0
+	for i := 0; i < min(len(relevant), k); i++ {
+		ideal += 1 / math.Log2(float64(i+2))
+	}
+
+	result.Recall = float64(found) / float64(len(relevant))
+	if ideal > 0 {
+		result.NDCG = dcg / ideal
+	}
+	return result
+}
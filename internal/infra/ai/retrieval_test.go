@@ -0,0 +1,328 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bm25Searcher serves full-text search from an in-memory BM25 index
+type bm25Searcher struct {
+	index *news.BM25Index
+}
+
+func (s *bm25Searcher) SearchArticles(ctx context.Context, query *news.SearchQuery) ([]*news.SearchResult, int64, error) {
+	return s.index.Search(ctx, query)
+}
+
+// articleMap loads articles by ID
+type articleMap map[string]*news.Article
+
+func (m articleMap) GetArticlesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*news.Article, error) {
+	var articles []*news.Article
+	for _, id := range ids {
+		if article, ok := m[id.Hex()]; ok {
+			articles = append(articles, article)
+		}
+	}
+	return articles, nil
+}
+
+// chunkStore discards chunks; the tests only search the in-process index
+type chunkStore struct{}
+
+func (chunkStore) ReplaceForArticle(ctx context.Context, articleID primitive.ObjectID, chunks []*embedding.Chunk) error {
+	return nil
+}
+
+func (chunkStore) ForEach(ctx context.Context, model string, since time.Time, fn func(*embedding.Chunk) error) error {
+	return nil
+}
+
+// failingSearcher fails every search
+type failingSearcher struct{}
+
+func (failingSearcher) SearchArticles(ctx context.Context, query *news.SearchQuery) ([]*news.SearchResult, int64, error) {
+	return nil, 0, errors.New("search unavailable")
+}
+
+func (failingSearcher) Search(ctx context.Context, text string, filter *embedding.Filter, limit int) ([]*embedding.ArticleMatch, error) {
+	return nil, errors.New("search unavailable")
+}
+
+// failingReranker fails every reranking
+type failingReranker struct{}
+
+func (failingReranker) Rerank(ctx context.Context, question string, analysis *ai.QueryAnalysisResult, candidates []*ai.RetrievalCandidate) error {
+	return errors.New("reranker unavailable")
+}
+
+// evaluationCorpus returns a small archive of defense news articles with fixed IDs
+func evaluationCorpus() []*news.Article {
+	published := time.Now().AddDate(0, 0, -7)
+	article := func(id, title, summary string, companies ...string) *news.Article {
+		objectID, _ := primitive.ObjectIDFromHex(id)
+		return &news.Article{
+			ID:             objectID,
+			Title:          title,
+			Summary:        summary,
+			Companies:      companies,
+			PublishedDate:  published,
+			RelevanceScore: 0.8,
+		}
+	}
+
+	return []*news.Article{
+		article("000000000000000000000001", "Army awards Lockheed Martin hypersonic missile contract",
+			"The Army awarded Lockheed Martin a contract for long range hypersonic weapon glide bodies.", "Lockheed Martin"),
+		article("000000000000000000000002", "Lockheed Martin reports record quarterly revenue",
+			"Lockheed Martin revenue rose on F-35 deliveries and missile demand, lifting full year guidance.", "Lockheed Martin"),
+		article("000000000000000000000003", "RTX wins Navy radar upgrade award",
+			"Raytheon will upgrade shipboard radar systems for Navy destroyers under a new award.", "Raytheon Technologies"),
+		article("000000000000000000000004", "Boeing delays Starliner crewed flight",
+			"Boeing postponed the Starliner crewed flight test after a helium leak in the service module.", "Boeing"),
+		article("000000000000000000000005", "Northrop Grumman B-21 bomber enters low rate production",
+			"The Air Force approved low rate initial production of the B-21 Raider stealth bomber.", "Northrop Grumman"),
+		article("000000000000000000000006", "Hypersonic glide body test succeeds over the Pacific",
+			"A joint Army and Navy flight test of the common hypersonic glide body hit its target.", "Lockheed Martin"),
+		article("000000000000000000000007", "Raytheon earnings beat estimates on missile demand",
+			"Raytheon quarterly earnings beat analyst estimates as missile and radar revenue grew.", "Raytheon Technologies"),
+		article("000000000000000000000008", "Boeing KC-46 tanker faces new delivery delays",
+			"Boeing warned of further KC-46 tanker delivery delays because of remote vision system fixes.", "Boeing"),
+	}
+}
+
+// labelledQuestions returns questions answered by the evaluation corpus
+func labelledQuestions() []LabelledQuestion {
+	return []LabelledQuestion{
+		{
+			Question: "hypersonic glide body tests and contracts",
+			Relevant: []string{"000000000000000000000001", "000000000000000000000006"},
+		},
+		{
+			Question:  "quarterly revenue and earnings",
+			Companies: []string{"Raytheon Technologies"},
+			Relevant:  []string{"000000000000000000000007"},
+		},
+		{
+			Question: "Boeing program delays",
+			Relevant: []string{"000000000000000000000004", "000000000000000000000008"},
+		},
+		{
+			Question: "stealth bomber production",
+			Relevant: []string{"000000000000000000000005"},
+		},
+		{
+			Question: "Navy radar upgrades",
+			Relevant: []string{"000000000000000000000003"},
+		},
+	}
+}
+
+// newTestPipeline indexes the corpus for full-text and vector search
+func newTestPipeline(t *testing.T, config RetrievalConfig) *RetrievalPipeline {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	articles := evaluationCorpus()
+	loader := make(articleMap, len(articles))
+	embeddings := embedding.NewService(chunkStore{}, nil, nil, logger)
+	for _, article := range articles {
+		loader[article.ID.Hex()] = article
+		if _, err := embeddings.IndexArticle(context.Background(), article); err != nil {
+			t.Fatalf("IndexArticle() error = %v", err)
+		}
+	}
+
+	return NewRetrievalPipeline(&bm25Searcher{index: news.NewBM25Index(articles)}, embeddings, loader, nil, config, logger)
+}
+
+func TestRetrievalEvaluation(t *testing.T) {
+	pipeline := newTestPipeline(t, DefaultRetrievalConfig())
+
+	evaluation, err := EvaluateRetrieval(context.Background(), pipeline, labelledQuestions(), 3)
+	if err != nil {
+		t.Fatalf("EvaluateRetrieval() error = %v", err)
+	}
+
+	for _, question := range evaluation.Questions {
+		t.Logf("%-45q recall=%.2f rr=%.2f ndcg=%.2f", question.Question, question.Recall, question.RR, question.NDCG)
+	}
+	if evaluation.Recall < 0.9 || evaluation.MRR < 0.9 {
+		t.Errorf("Expected recall@3 and MRR of at least 0.9, got %.2f and %.2f", evaluation.Recall, evaluation.MRR)
+	}
+}
+
+func TestRetrieveScoresEachStage(t *testing.T) {
+	pipeline := newTestPipeline(t, DefaultRetrievalConfig())
+
+	sources, err := pipeline.Retrieve(context.Background(), &RetrievalQuery{Question: "hypersonic glide body"})
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if len(sources) == 0 {
+		t.Fatal("Expected sources")
+	}
+
+	top := sources[0]
+	if top.Scores == nil || top.Scores.LexicalRank == 0 || top.Scores.SemanticRank == 0 {
+		t.Fatalf("Expected the top source found by both retrievers, got %+v", top.Scores)
+	}
+	wantFusion := 1/(60+float64(top.Scores.LexicalRank)) + 1/(60+float64(top.Scores.SemanticRank))
+	if math.Abs(top.Scores.Fusion-wantFusion) > 1e-9 {
+		t.Errorf("Expected fusion score %v, got %v", wantFusion, top.Scores.Fusion)
+	}
+	if top.RelevanceScore != top.Article.RelevanceScore {
+		t.Errorf("Expected relevance score %v to be the article's %v", top.RelevanceScore, top.Article.RelevanceScore)
+	}
+	for i := 1; i < len(sources); i++ {
+		if sources[i].Scores.Rerank > sources[i-1].Scores.Rerank {
+			t.Errorf("Sources not ordered by rerank score at %d", i)
+		}
+	}
+	for _, source := range sources {
+		if source.Article == nil || source.Article.ID.Hex() != source.ArticleID {
+			t.Errorf("Expected the retrieved article passed through with source %s", source.ArticleID)
+		}
+	}
+}
+
+func TestRetrieveKeepsFusionOrderWhenRerankingFails(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	healthy := newTestPipeline(t, DefaultRetrievalConfig())
+	pipeline := NewRetrievalPipeline(healthy.lexical, healthy.semantic, healthy.articles, failingReranker{}, DefaultRetrievalConfig(), logger)
+
+	sources, err := pipeline.Retrieve(context.Background(), &RetrievalQuery{Question: "hypersonic glide body"})
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	if len(sources) == 0 {
+		t.Fatal("Expected sources")
+	}
+
+	for i, source := range sources {
+		if source.Scores.Rerank != source.Scores.Fusion {
+			t.Errorf("Expected rerank score %v to fall back to the fusion score %v", source.Scores.Rerank, source.Scores.Fusion)
+		}
+		if source.RelevanceScore != source.Article.RelevanceScore {
+			t.Errorf("Expected relevance score %v to be the article's %v", source.RelevanceScore, source.Article.RelevanceScore)
+		}
+		if i > 0 && source.Scores.Fusion > sources[i-1].Scores.Fusion {
+			t.Errorf("Sources not ordered by fusion score at %d", i)
+		}
+	}
+}
+
+func TestRetrieveWeights(t *testing.T) {
+	config := DefaultRetrievalConfig()
+	config.SemanticWeight = 0
+	pipeline := newTestPipeline(t, config)
+
+	sources, err := pipeline.Retrieve(context.Background(), &RetrievalQuery{Question: "Boeing delays"})
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	for _, source := range sources {
+		if source.Scores.SemanticRank != 0 {
+			t.Errorf("Expected no semantic ranks with a zero semantic weight, got %+v", source.Scores)
+		}
+	}
+}
+
+func TestRetrieveToleratesOneFailingRetriever(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	articles := evaluationCorpus()
+	lexical := &bm25Searcher{index: news.NewBM25Index(articles)}
+
+	pipeline := NewRetrievalPipeline(lexical, failingSearcher{}, articleMap{}, nil, DefaultRetrievalConfig(), logger)
+	sources, err := pipeline.Retrieve(context.Background(), &RetrievalQuery{Question: "Northrop bomber"})
+	if err != nil || len(sources) == 0 {
+		t.Fatalf("Expected full-text sources despite the failing vector search, got %v (error %v)", sources, err)
+	}
+
+	pipeline = NewRetrievalPipeline(failingSearcher{}, failingSearcher{}, articleMap{}, nil, DefaultRetrievalConfig(), logger)
+	if _, err := pipeline.Retrieve(context.Background(), &RetrievalQuery{Question: "Northrop bomber"}); err == nil {
+		t.Error("Expected an error when every retriever fails")
+	}
+}
+
+func TestHeuristicRerankerPrefersCategories(t *testing.T) {
+	articles := evaluationCorpus()
+	articles[1].Categories = []news.Category{news.CategoryEarnings}
+	candidates := []*ai.RetrievalCandidate{
+		{Article: articles[0], Scores: ai.RetrievalScores{Fusion: 0.016}},
+		{Article: articles[1], Scores: ai.RetrievalScores{Fusion: 0.016}},
+	}
+
+	reranker := ai.NewHeuristicReranker(ai.DefaultHeuristicWeights())
+	analysis := &ai.QueryAnalysisResult{Categories: []news.Category{news.CategoryEarnings}}
+	if err := reranker.Rerank(context.Background(), "earnings", analysis, candidates); err != nil {
+		t.Fatalf("Rerank() error = %v", err)
+	}
+	if candidates[1].Scores.Rerank <= candidates[0].Scores.Rerank {
+		t.Errorf("Expected the earnings article ranked higher, got %v and %v", candidates[0].Scores.Rerank, candidates[1].Scores.Rerank)
+	}
+}
+
+func TestHeuristicRerankerFavoursMatch(t *testing.T) {
+	weights := ai.DefaultHeuristicWeights()
+	if weights.Match <= weights.Relevance {
+		t.Fatalf("Expected the match weighted above relevance, got %+v", weights)
+	}
+
+	articles := evaluationCorpus()
+	articles[0].RelevanceScore, articles[1].RelevanceScore = 1, 0.2
+	articles[1].PublishedDate = articles[0].PublishedDate
+	candidates := []*ai.RetrievalCandidate{
+		{Article: articles[0], Scores: ai.RetrievalScores{Fusion: 0.008}},
+		{Article: articles[1], Scores: ai.RetrievalScores{Fusion: 0.016}},
+	}
+
+	if err := ai.NewHeuristicReranker(weights).Rerank(context.Background(), "question", nil, candidates); err != nil {
+		t.Fatalf("Rerank() error = %v", err)
+	}
+	if candidates[1].Scores.Rerank <= candidates[0].Scores.Rerank {
+		t.Errorf("Expected the best match ranked above the most relevant article, got %v and %v", candidates[0].Scores.Rerank, candidates[1].Scores.Rerank)
+	}
+}
+
+func TestScoreRanking(t *testing.T) {
+	result := scoreRanking("q", []string{"a", "b", "c"}, []string{"b", "d"}, 3)
+
+	if result.Recall != 0.5 {
+		t.Errorf("Expected recall 0.5, got %v", result.Recall)
+	}
+	if result.RR != 0.5 {
+		t.Errorf("Expected reciprocal rank 0.5, got %v", result.RR)
+	}
+	wantNDCG := (1 / math.Log2(3)) / (1 + 1/math.Log2(3))
+	if math.Abs(result.NDCG-wantNDCG) > 1e-9 {
+		t.Errorf("Expected nDCG %v, got %v", wantNDCG, result.NDCG)
+	}
+}
+
+func TestParseRerankScores(t *testing.T) {
+	scores, err := parseRerankScores("```json\n{\"scores\": [10, 2.5, 0]}\n```", 3)
+	if err != nil {
+		t.Fatalf("parseRerankScores() error = %v", err)
+	}
+	if scores[0] != 1 || scores[1] != 0.25 || scores[2] != 0 {
+		t.Errorf("Unexpected scores %v", scores)
+	}
+
+	if _, err := parseRerankScores(`{"scores": [5]}`, 2); err == nil {
+		t.Error("Expected an error for a missing score")
+	}
+	if _, err := parseRerankScores(`{"scores": [11]}`, 1); err == nil {
+		t.Error("Expected an error for an out of range score")
+	}
+}
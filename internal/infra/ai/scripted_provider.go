@@ -0,0 +1,120 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+)
+
+// ErrScriptExhausted is returned when a scripted provider has no reply left
+var ErrScriptExhausted = errors.New("scripted provider has no reply left")
+
+// ScriptedReply is the reply of a scripted provider to one chat: an answer,
+// or an error
+type ScriptedReply struct {
+	Content string
+	Err     error
+}
+
+// ScriptedProvider is a deterministic LLMProvider for tests and offline
+// runs. It answers chats with its replies in order and records the requests
+// it was sent. Streamed answers arrive word by word. Texts are embedded by
+// hashing, so equal texts get equal vectors.
+type ScriptedProvider struct {
+	mu       sync.Mutex
+	replies  []ScriptedReply
+	requests []ChatRequest
+	embedder *embedding.HashingEmbedder
+}
+
+// NewScriptedProvider creates a provider answering with the given answers
+// in order
+func NewScriptedProvider(answers ...string) *ScriptedProvider {
+	p := &ScriptedProvider{embedder: embedding.NewHashingEmbedder(0)}
+	for _, answer := range answers {
+		p.Reply(ScriptedReply{Content: answer})
+	}
+	return p
+}
+
+// Reply queues replies after the ones already scripted
+func (p *ScriptedProvider) Reply(replies ...ScriptedReply) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.replies = append(p.replies, replies...)
+}
+
+// Requests returns the chats sent to the provider, in order
+func (p *ScriptedProvider) Requests() []ChatRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]ChatRequest(nil), p.requests...)
+}
+
+// Chat implements LLMProvider
+func (p *ScriptedProvider) Chat(ctx context.Context, req ChatRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return p.next(req)
+}
+
+// ChatStream implements LLMProvider
+func (p *ScriptedProvider) ChatStream(ctx context.Context, req ChatRequest) (ChatStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	answer, err := p.next(req)
+	if err != nil {
+		return nil, err
+	}
+	return &scriptedStream{ctx: ctx, parts: strings.SplitAfter(answer, " ")}, nil
+}
+
+// Embed implements LLMProvider
+func (p *ScriptedProvider) Embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	return p.embedder.Embed(ctx, texts)
+}
+
+// next records a request and takes the next reply
+func (p *ScriptedProvider) next(req ChatRequest) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, req)
+	if len(p.replies) == 0 {
+		return "", ErrScriptExhausted
+	}
+	reply := p.replies[0]
+	p.replies = p.replies[1:]
+	return reply.Content, reply.Err
+}
+
+// scriptedStream returns the parts of a scripted answer
+type scriptedStream struct {
+	ctx   context.Context
+	parts []string
+}
+
+// Recv implements ChatStream
+func (s *scriptedStream) Recv() (string, error) {
+	if err := s.ctx.Err(); err != nil {
+		return "", err
+	}
+	if len(s.parts) == 0 {
+		return "", io.EOF
+	}
+	part := s.parts[0]
+	s.parts = s.parts[1:]
+	return part, nil
+}
+
+// Close implements ChatStream
+func (s *scriptedStream) Close() error {
+	s.parts = nil
+	return nil
+}
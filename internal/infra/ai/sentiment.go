@@ -0,0 +1,137 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// maxSentimentInputRunes bounds the article text sent to the model
+const maxSentimentInputRunes = 4000
+
+// llmSentiment is the sentiment as returned by the model
+type llmSentiment struct {
+	Score     float64            `json:"score"`
+	Companies map[string]float64 `json:"companies"`
+}
+
+// OpenAISentimentAnalyzer scores article sentiment with a language model.
+// When the model fails or answers out of range, it falls back to another analyzer.
+type OpenAISentimentAnalyzer struct {
+	provider LLMProvider
+	fallback news.SentimentAnalyzer
+	model    string
+	logger   *slog.Logger
+}
+
+// NewOpenAISentimentAnalyzer creates an LLM sentiment analyzer; fallback is
+// usually the lexicon analyzer
+func NewOpenAISentimentAnalyzer(provider LLMProvider, model string, fallback news.SentimentAnalyzer, logger *slog.Logger) *OpenAISentimentAnalyzer {
+	return &OpenAISentimentAnalyzer{
+		provider: provider,
+		fallback: fallback,
+		model:    model,
+		logger:   logger,
+	}
+}
+
+// Analyze implements news.SentimentAnalyzer. The model scores the article's
+// tagged companies; companies is only used by the fallback.
+func (a *OpenAISentimentAnalyzer) Analyze(ctx context.Context, article *news.Article, companies *news.EntityMatcher) (*news.Sentiment, error) {
+	sentiment, err := a.analyze(ctx, article)
+	if err != nil {
+		a.logger.Warn("LLM sentiment analysis failed, using fallback analyzer", "error", err, "title", article.Title)
+		return a.fallback.Analyze(ctx, article, companies)
+	}
+	return sentiment, nil
+}
+
+// analyze asks the model for the article's sentiment and validates the answer
+func (a *OpenAISentimentAnalyzer) analyze(ctx context.Context, article *news.Article) (*news.Sentiment, error) {
+	systemPrompt := `You rate the tone of defense and aerospace news articles for investors.
+Score from -1 (very negative) to 1 (very positive), 0 for neutral or factual reporting.
+Respond with JSON only: {"score": overall score, "companies": {"company name": score towards that company}}.
+Only score the companies listed by the user that the article discusses.`
+
+	text := article.Title + "\n\n" + article.BodyText()
+	if runes := []rune(text); len(runes) > maxSentimentInputRunes {
+		text = string(runes[:maxSentimentInputRunes])
+	}
+	userPrompt := "Companies: " + strings.Join(article.Companies, ", ") + "\n\nArticle:\n" + text
+
+	content, err := a.provider.Chat(ctx, chatRequest(a.model, systemPrompt, userPrompt, 150, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	sentiment, err := parseSentiment(content, article.Companies)
+	if err != nil {
+		return nil, err
+	}
+	sentiment.AnalyzedAt = time.Now()
+	return sentiment, nil
+}
+
+// parseSentiment decodes the model's JSON answer, keeping the scores of the
+// known companies in their article order
+func parseSentiment(content string, companies []string) (*news.Sentiment, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.Trim(content, "` \n")
+
+	var answer llmSentiment
+	if err := json.Unmarshal([]byte(content), &answer); err != nil {
+		return nil, fmt.Errorf("invalid sentiment response: %w", err)
+	}
+	if !validSentimentScore(answer.Score) {
+		return nil, fmt.Errorf("sentiment score out of range: %v", answer.Score)
+	}
+
+	sentiment := &news.Sentiment{
+		Score: roundScore(answer.Score),
+		Label: news.LabelForScore(answer.Score),
+	}
+
+	for _, name := range companies {
+		score, ok := lookupCompanyScore(answer.Companies, name)
+		if !ok || !validSentimentScore(score) {
+			continue
+		}
+		sentiment.Companies = append(sentiment.Companies, news.CompanySentiment{
+			CompanyName: name,
+			Score:       roundScore(score),
+			Label:       news.LabelForScore(score),
+		})
+	}
+
+	return sentiment, nil
+}
+
+// lookupCompanyScore finds a company's score, ignoring case
+func lookupCompanyScore(scores map[string]float64, name string) (float64, bool) {
+	if score, ok := scores[name]; ok {
+		return score, true
+	}
+	for key, score := range scores {
+		if strings.EqualFold(key, name) {
+			return score, true
+		}
+	}
+	return 0, false
+}
+
+// validSentimentScore reports whether a score lies between -1 and 1
+func validSentimentScore(score float64) bool {
+	return !math.IsNaN(score) && score >= -1 && score <= 1
+}
+
+// roundScore rounds a score to three decimals like the lexicon analyzer
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Neph-dev/october_backend/pkg/logger"
+	"github.com/sashabaranov/go-openai"
+)
+
+// streamingServer serves chat completions as OpenAI does when streaming:
+// one Server-Sent Event per part, then [DONE]. With hold, it keeps the
+// stream open after the parts and reports on closed when the client goes.
+// It returns the base URL of the API.
+func streamingServer(t *testing.T, parts []string, hold bool, closed chan<- struct{}) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		for _, part := range parts {
+			fmt.Fprintf(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":%q}}]}\n\n", part)
+			w.(http.Flusher).Flush()
+		}
+		if hold {
+			<-r.Context().Done()
+			close(closed)
+			return
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL + "/v1"
+}
+
+// streamingProviders are the providers speaking the OpenAI API
+var streamingProviders = map[string]func(baseURL string) LLMProvider{
+	"openai": func(baseURL string) LLMProvider {
+		config := openai.DefaultConfig("test-key")
+		config.BaseURL = baseURL
+		return NewOpenAIProvider(openai.NewClientWithConfig(config))
+	},
+	"compatible": func(baseURL string) LLMProvider {
+		return NewCompatibleProvider(baseURL, "")
+	},
+}
+
+// newStreamingService creates a service answering with a provider
+func newStreamingService(provider LLMProvider) *OpenAIService {
+	return &OpenAIService{
+		provider: provider,
+		models:   DefaultModels(),
+		logger:   logger.NewLogger(slog.LevelError, io.Discard),
+	}
+}
+
+func TestStreamCompletion(t *testing.T) {
+	for name, newProvider := range streamingProviders {
+		t.Run(name, func(t *testing.T) {
+			service := newStreamingService(newProvider(streamingServer(t, []string{"RTX won ", "the contract ", "[1]."}, false, nil)))
+
+			var deltas []string
+			answer, err := service.streamCompletion(context.Background(), ChatRequest{Model: service.models.Answer}, func(text string) error {
+				deltas = append(deltas, text)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("streamCompletion() error = %v", err)
+			}
+			if answer != "RTX won the contract [1]." || len(deltas) != 3 {
+				t.Errorf("Expected the answer in three parts, got %q from %q", answer, deltas)
+			}
+		})
+	}
+}
+
+func TestStreamCompletionStopsWhenClientGoes(t *testing.T) {
+	for name, newProvider := range streamingProviders {
+		t.Run(name, func(t *testing.T) {
+			closed := make(chan struct{})
+			service := newStreamingService(newProvider(streamingServer(t, []string{"RTX won "}, true, closed)))
+
+			gone := errors.New("client disconnected")
+			_, err := service.streamCompletion(context.Background(), ChatRequest{Model: service.models.Answer}, func(text string) error {
+				return gone
+			})
+			if !errors.Is(err, gone) {
+				t.Fatalf("Expected the send error, got %v", err)
+			}
+
+			select {
+			case <-closed:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected the upstream request closed")
+			}
+		})
+	}
+}
+
+func TestStreamCompletionWithScriptedProvider(t *testing.T) {
+	provider := NewScriptedProvider("RTX won the contract.")
+	service := newStreamingService(provider)
+
+	var deltas []string
+	answer, err := service.streamCompletion(context.Background(), service.directRequest("Who won the contract?"), func(text string) error {
+		deltas = append(deltas, text)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streamCompletion() error = %v", err)
+	}
+	if answer != "RTX won the contract." || len(deltas) != 4 {
+		t.Errorf("Expected the answer word by word, got %q from %q", answer, deltas)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 1 || requests[0].Model != DefaultModels().Answer || requests[0].Messages[1].Content != "Who won the contract?" {
+		t.Errorf("Expected the question sent to the answer model, got %+v", requests)
+	}
+}
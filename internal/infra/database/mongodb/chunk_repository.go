@@ -0,0 +1,102 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const articleChunkCollection = "article_chunks"
+
+// ChunkRepository implements embedding.Repository for MongoDB
+type ChunkRepository struct {
+	collection *mongo.Collection
+}
+
+// NewChunkRepository creates a new MongoDB article chunk repository
+func NewChunkRepository(db *mongo.Database) *ChunkRepository {
+	return &ChunkRepository{
+		collection: db.Collection(articleChunkCollection),
+	}
+}
+
+// ReplaceForArticle replaces the chunks stored for an article
+func (r *ChunkRepository) ReplaceForArticle(ctx context.Context, articleID primitive.ObjectID, chunks []*embedding.Chunk) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"article_id": articleID}); err != nil {
+		return err
+	}
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(chunks))
+	for _, chunk := range chunks {
+		if chunk.ID.IsZero() {
+			chunk.ID = primitive.NewObjectID()
+		}
+		chunk.ArticleID = articleID
+		documents = append(documents, chunk)
+	}
+
+	_, err := r.collection.InsertMany(ctx, documents)
+	return err
+}
+
+// ForEach calls fn with every chunk embedded by the model and stored at or
+// after since, grouped by article
+func (r *ChunkRepository) ForEach(ctx context.Context, model string, since time.Time, fn func(*embedding.Chunk) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "article_id", Value: 1}, {Key: "index", Value: 1}})
+
+	filter := bson.M{"model": model}
+	if !since.IsZero() {
+		filter["created_at"] = bson.M{"$gte": since}
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var chunk embedding.Chunk
+		if err := cursor.Decode(&chunk); err != nil {
+			return err
+		}
+		if err := fn(&chunk); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// CreateIndexes creates necessary indexes for the article chunks collection
+func (r *ChunkRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "model", Value: 1},
+				{Key: "article_id", Value: 1},
+				{Key: "index", Value: 1},
+			},
+		},
+		{
+			Keys: bson.M{"article_id": 1},
+		},
+		{
+			Keys: bson.D{
+				{Key: "model", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -121,7 +122,7 @@ func (r *CompanyRepository) GetByName(ctx context.Context, name string) (*compan
 	
 	filter := bson.M{
 		"name": bson.M{
-			"$regex":   fmt.Sprintf("^%s$", name),
+			"$regex":   fmt.Sprintf("^%s$", regexp.QuoteMeta(name)),
 			"$options": "i", // case-insensitive
 		},
 	}
@@ -187,6 +188,31 @@ func (r *CompanyRepository) Update(ctx context.Context, comp *company.Company) e
 	return nil
 }
 
+// UpdateLastFeedUpdate sets metadata.lastFeedUpdate without rewriting the rest of the document
+func (r *CompanyRepository) UpdateLastFeedUpdate(ctx context.Context, id primitive.ObjectID, updatedAt time.Time) error {
+	if id.IsZero() {
+		return company.ErrInvalidCompanyData
+	}
+	
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{
+		"metadata.lastFeedUpdate": updatedAt,
+		"updatedAt":               time.Now(),
+	}}
+	
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update company feed timestamp", "error", err, "id", id.Hex())
+		return fmt.Errorf("failed to update company: %w", err)
+	}
+	
+	if result.MatchedCount == 0 {
+		return company.ErrCompanyNotFound
+	}
+	
+	return nil
+}
+
 func (r *CompanyRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	if id.IsZero() {
 		return company.ErrInvalidCompanyData
@@ -0,0 +1,207 @@
+package mongodb
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	contractAwardCollection = "contract_awards"
+
+	// maxQuarterlyTotals bounds the groups returned by the quarterly aggregation
+	maxQuarterlyTotals = 1000
+)
+
+// ContractRepository implements contract.Repository for MongoDB
+type ContractRepository struct {
+	collection *mongo.Collection
+}
+
+// NewContractRepository creates a new MongoDB contract award repository
+func NewContractRepository(db *mongo.Database) *ContractRepository {
+	return &ContractRepository{
+		collection: db.Collection(contractAwardCollection),
+	}
+}
+
+// ReplaceForArticle replaces the awards extracted from an article
+func (r *ContractRepository) ReplaceForArticle(ctx context.Context, articleID primitive.ObjectID, awards []*contract.ContractAward) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"article_id": articleID}); err != nil {
+		return err
+	}
+	if len(awards) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(awards))
+	for _, award := range awards {
+		if award.ID.IsZero() {
+			award.ID = primitive.NewObjectID()
+		}
+		award.ArticleID = articleID
+		documents = append(documents, award)
+	}
+
+	_, err := r.collection.InsertMany(ctx, documents)
+	return err
+}
+
+// List retrieves awards matching the filter, newest first
+func (r *ContractRepository) List(ctx context.Context, filter *contract.Filter) ([]*contract.ContractAward, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "announced_date", Value: -1}, {Key: "amount", Value: -1}}).
+		SetLimit(int64(filter.Limit)).
+		SetSkip(int64(filter.Offset))
+
+	cursor, err := r.collection.Find(ctx, r.buildFilter(filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var awards []*contract.ContractAward
+	for cursor.Next(ctx) {
+		var award contract.ContractAward
+		if err := cursor.Decode(&award); err != nil {
+			return nil, err
+		}
+		awards = append(awards, &award)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return awards, nil
+}
+
+// Count returns the number of awards matching the filter
+func (r *ContractRepository) Count(ctx context.Context, filter *contract.Filter) (int64, error) {
+	return r.collection.CountDocuments(ctx, r.buildFilter(filter))
+}
+
+// TotalsByQuarter sums the awards matching the filter per company and
+// calendar quarter of the announcement. Awardees that are not tracked
+// companies are grouped under their own name.
+func (r *ContractRepository) TotalsByQuarter(ctx context.Context, filter *contract.Filter) ([]*contract.QuarterlyTotal, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: r.buildFilter(filter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"company": bson.M{"$ifNull": bson.A{"$company_name", "$awardee"}},
+				"year":    bson.M{"$year": "$announced_date"},
+				"quarter": bson.M{"$ceil": bson.M{"$divide": bson.A{bson.M{"$month": "$announced_date"}, 3}}},
+			},
+			"total": bson.M{"$sum": "$amount"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":     0,
+			"company": "$_id.company",
+			"year":    "$_id.year",
+			"quarter": "$_id.quarter",
+			"total":   1,
+			"count":   1,
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "year", Value: -1},
+			{Key: "quarter", Value: -1},
+			{Key: "total", Value: -1},
+		}}},
+		{{Key: "$limit", Value: maxQuarterlyTotals}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []*contract.QuarterlyTotal
+	for cursor.Next(ctx) {
+		var total contract.QuarterlyTotal
+		if err := cursor.Decode(&total); err != nil {
+			return nil, err
+		}
+		totals = append(totals, &total)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// CreateIndexes creates necessary indexes for the contract award collection
+func (r *ContractRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.M{"article_id": 1},
+		},
+		{
+			Keys: bson.M{"announced_date": -1},
+		},
+		{
+			Keys: bson.D{
+				{Key: "company_name", Value: 1},
+				{Key: "announced_date", Value: -1},
+			},
+		},
+		{
+			Keys: bson.M{"amount": -1},
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// buildFilter constructs a MongoDB filter from a contract filter
+func (r *ContractRepository) buildFilter(filter *contract.Filter) bson.M {
+	mongoFilter := bson.M{}
+	if filter == nil {
+		return mongoFilter
+	}
+
+	if filter.Company != "" {
+		mongoFilter["$or"] = bson.A{
+			bson.M{"company_name": filter.Company},
+			bson.M{"awardee": bson.M{"$regex": regexp.QuoteMeta(filter.Company), "$options": "i"}},
+		}
+	}
+
+	if filter.Agency != "" {
+		mongoFilter["agency"] = bson.M{"$regex": regexp.QuoteMeta(filter.Agency), "$options": "i"}
+	}
+
+	if filter.MinAmount != nil || filter.MaxAmount != nil {
+		amountFilter := bson.M{}
+		if filter.MinAmount != nil {
+			amountFilter["$gte"] = *filter.MinAmount
+		}
+		if filter.MaxAmount != nil {
+			amountFilter["$lte"] = *filter.MaxAmount
+		}
+		mongoFilter["amount"] = amountFilter
+	}
+
+	if filter.StartDate != nil || filter.EndDate != nil {
+		dateFilter := bson.M{}
+		if filter.StartDate != nil {
+			dateFilter["$gte"] = *filter.StartDate
+		}
+		if filter.EndDate != nil {
+			dateFilter["$lte"] = *filter.EndDate
+		}
+		mongoFilter["announced_date"] = dateFilter
+	}
+
+	return mongoFilter
+}
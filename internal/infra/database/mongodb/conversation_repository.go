@@ -0,0 +1,147 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/Neph-dev/october_backend/internal/domain/conversation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const conversationCollection = "conversations"
+
+// ConversationRepository implements conversation.Repository for MongoDB.
+// Messages are embedded in their conversation document.
+type ConversationRepository struct {
+	collection *mongo.Collection
+}
+
+// NewConversationRepository creates a new MongoDB conversation repository
+func NewConversationRepository(db *mongo.Database) *ConversationRepository {
+	return &ConversationRepository{
+		collection: db.Collection(conversationCollection),
+	}
+}
+
+// Create saves a new conversation
+func (r *ConversationRepository) Create(ctx context.Context, c *conversation.Conversation) error {
+	if c.ID.IsZero() {
+		c.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, c)
+	return err
+}
+
+// GetByID retrieves a conversation with its messages
+func (r *ConversationRepository) GetByID(ctx context.Context, id string) (*conversation.Conversation, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, conversation.ErrConversationNotFound
+	}
+
+	var c conversation.Conversation
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&c)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, conversation.ErrConversationNotFound
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// List retrieves conversations without their messages, most recently updated first
+func (r *ConversationRepository) List(ctx context.Context, filter *conversation.Filter) ([]*conversation.Conversation, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"messages": 0}).
+		SetSort(bson.M{"updated_at": -1}).
+		SetLimit(int64(filter.Limit))
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var conversations []*conversation.Conversation
+	for cursor.Next(ctx) {
+		var c conversation.Conversation
+		if err := cursor.Decode(&c); err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, &c)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return conversations, nil
+}
+
+// AppendMessages adds messages to a stored conversation and saves its
+// title, message count, summary and update time
+func (r *ConversationRepository) AppendMessages(ctx context.Context, c *conversation.Conversation, messages []conversation.Message) error {
+	update := bson.M{
+		"$push": bson.M{"messages": bson.M{"$each": messages}},
+		"$inc":  bson.M{"message_count": len(messages)},
+		"$set": bson.M{
+			"title":               c.Title,
+			"summary":             c.Summary,
+			"summarized_messages": c.SummarizedMessages,
+			"updated_at":          c.UpdatedAt,
+		},
+	}
+
+	// Only update the conversation as it was read, so that concurrent turns
+	// cannot overwrite each other's summary
+	filter := bson.M{"_id": c.ID, "message_count": c.MessageCount - len(messages)}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": c.ID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return conversation.ErrConversationNotFound
+		}
+		return conversation.ErrConversationConflict
+	}
+	return nil
+}
+
+// Delete removes a conversation
+func (r *ConversationRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return conversation.ErrConversationNotFound
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return conversation.ErrConversationNotFound
+	}
+	return nil
+}
+
+// CreateIndexes creates necessary indexes for the conversation collection
+func (r *ConversationRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.M{"updated_at": -1},
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
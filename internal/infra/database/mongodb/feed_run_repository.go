@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/Neph-dev/october_backend/internal/domain/feed"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	feedRunCollection = "feed_runs"
+
+	// feedRunRetentionSeconds bounds the size of the run history (90 days)
+	feedRunRetentionSeconds = 90 * 24 * 60 * 60
+)
+
+// FeedRunRepository implements feed.RunRepository for MongoDB
+type FeedRunRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFeedRunRepository creates a new MongoDB feed run repository
+func NewFeedRunRepository(db *mongo.Database) *FeedRunRepository {
+	return &FeedRunRepository{
+		collection: db.Collection(feedRunCollection),
+	}
+}
+
+// Create saves a new feed run
+func (r *FeedRunRepository) Create(ctx context.Context, run *feed.Run) error {
+	if run.ID.IsZero() {
+		run.ID = primitive.NewObjectID()
+	}
+
+	_, err := r.collection.InsertOne(ctx, run)
+	return err
+}
+
+// List retrieves the most recent feed runs matching the filter
+func (r *FeedRunRepository) List(ctx context.Context, filter *feed.RunFilter) ([]*feed.Run, error) {
+	mongoFilter := bson.M{}
+	if filter.CompanyName != "" {
+		mongoFilter["company_name"] = filter.CompanyName
+	}
+	if filter.FeedURL != "" {
+		mongoFilter["feed_url"] = filter.FeedURL
+	}
+	if filter.FailedOnly {
+		mongoFilter["error"] = bson.M{"$exists": true, "$ne": ""}
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"started_at": -1}).
+		SetLimit(int64(filter.Limit))
+
+	cursor, err := r.collection.Find(ctx, mongoFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var runs []*feed.Run
+	for cursor.Next(ctx) {
+		var run feed.Run
+		if err := cursor.Decode(&run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, &run)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// CreateIndexes creates necessary indexes for the feed run collection
+func (r *FeedRunRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.M{"started_at": -1},
+			Options: options.Index().SetExpireAfterSeconds(feedRunRetentionSeconds),
+		},
+		{
+			Keys: bson.D{
+				{Key: "company_name", Value: 1},
+				{Key: "started_at", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "feed_url", Value: 1},
+				{Key: "started_at", Value: -1},
+			},
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -0,0 +1,106 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/feed"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const feedStateCollection = "feed_states"
+
+// FeedStateRepository implements feed.StateRepository for MongoDB
+type FeedStateRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFeedStateRepository creates a new MongoDB feed state repository
+func NewFeedStateRepository(db *mongo.Database) *FeedStateRepository {
+	return &FeedStateRepository{
+		collection: db.Collection(feedStateCollection),
+	}
+}
+
+// GetByURL retrieves the fetch state for a feed URL
+func (r *FeedStateRepository) GetByURL(ctx context.Context, feedURL string) (*feed.FetchState, error) {
+	if feedURL == "" {
+		return nil, feed.ErrInvalidFeedURL
+	}
+
+	var state feed.FetchState
+	err := r.collection.FindOne(ctx, bson.M{"feed_url": feedURL}).Decode(&state)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, feed.ErrFetchStateNotFound
+		}
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// Upsert creates or replaces the fetch state for a feed URL
+func (r *FeedStateRepository) Upsert(ctx context.Context, state *feed.FetchState) error {
+	if err := state.Validate(); err != nil {
+		return err
+	}
+
+	state.UpdatedAt = time.Now()
+
+	filter := bson.M{"feed_url": state.FeedURL}
+	opts := options.Replace().SetUpsert(true)
+
+	result, err := r.collection.ReplaceOne(ctx, filter, state, opts)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.UpsertedID.(primitive.ObjectID); ok {
+		state.ID = oid
+	}
+
+	return nil
+}
+
+// List retrieves the fetch state of every known feed
+func (r *FeedStateRepository) List(ctx context.Context) ([]*feed.FetchState, error) {
+	opts := options.Find().SetSort(bson.M{"feed_url": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var states []*feed.FetchState
+	for cursor.Next(ctx) {
+		var state feed.FetchState
+		if err := cursor.Decode(&state); err != nil {
+			return nil, err
+		}
+		states = append(states, &state)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return states, nil
+}
+
+// CreateIndexes creates necessary indexes for the feed state collection
+func (r *FeedStateRepository) CreateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.M{"feed_url": 1},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 	"go.mongodb.org/mongo-driver/bson"
@@ -49,6 +50,30 @@ func (r *NewsRepository) GetByID(ctx context.Context, id string) (*news.Article,
 	return &article, nil
 }
 
+// GetByIDs retrieves the articles with the given IDs
+func (r *NewsRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*news.Article, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []*news.Article
+	for cursor.Next(ctx) {
+		var article news.Article
+		if err := cursor.Decode(&article); err != nil {
+			return nil, err
+		}
+		articles = append(articles, &article)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
 // GetByGUID retrieves an article by its GUID
 func (r *NewsRepository) GetByGUID(ctx context.Context, guid string) (*news.Article, error) {
 	var article news.Article
@@ -95,6 +120,9 @@ func (r *NewsRepository) GetByCompany(ctx context.Context, companyName string) (
 // List retrieves articles with optional filtering
 func (r *NewsRepository) List(ctx context.Context, filter *news.NewsFilter) ([]*news.Article, error) {
 	mongoFilter := r.buildFilter(filter)
+	if filter != nil {
+		mongoFilter = withCursor(mongoFilter, filter.After)
+	}
 	opts := r.buildOptions(filter)
 
 	cursor, err := r.collection.Find(ctx, mongoFilter, opts)
@@ -119,7 +147,8 @@ func (r *NewsRepository) List(ctx context.Context, filter *news.NewsFilter) ([]*
 	return articles, nil
 }
 
-// Count returns the total number of articles matching the filter
+// Count returns the total number of articles matching the filter,
+// regardless of the page cursor
 func (r *NewsRepository) Count(ctx context.Context, filter *news.NewsFilter) (int64, error) {
 	mongoFilter := r.buildFilter(filter)
 	return r.collection.CountDocuments(ctx, mongoFilter)
@@ -142,6 +171,43 @@ func (r *NewsRepository) Update(ctx context.Context, article *news.Article) erro
 	return nil
 }
 
+// UpdateEnrichment saves the fields derived while enriching an article.
+// Sources are left untouched and companies are added to the stored set, so
+// duplicates merged by AddSources in the meantime are kept.
+func (r *NewsRepository) UpdateEnrichment(ctx context.Context, article *news.Article) error {
+	companies := article.Companies
+	if companies == nil {
+		companies = []string{}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"full_text":       article.FullText,
+			"extraction":      article.Extraction,
+			"mentions":        article.Mentions,
+			"entities":        article.Entities,
+			"categories":      article.Categories,
+			"sentiment":       article.Sentiment,
+			"relevance_score": article.RelevanceScore,
+			"relevance":       article.Relevance,
+		},
+		"$addToSet": bson.M{
+			"companies": bson.M{"$each": companies},
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, article.ID, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return news.ErrArticleNotFound
+	}
+
+	return nil
+}
+
 // Delete removes an article by ID
 func (r *NewsRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
@@ -161,15 +227,129 @@ func (r *NewsRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// ExistsByGUID checks if an article with the given GUID exists
+// ExistsByGUID checks if an article with the given GUID exists, including merged sources
 func (r *NewsRepository) ExistsByGUID(ctx context.Context, guid string) (bool, error) {
-	count, err := r.collection.CountDocuments(ctx, bson.M{"guid": guid})
+	filter := bson.M{"$or": []bson.M{
+		{"guid": guid},
+		{"sources.guid": guid},
+	}}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
+// FindDuplicateCandidates retrieves articles sharing the canonical URL or a
+// fingerprint band published within the query's date range
+func (r *NewsRepository) FindDuplicateCandidates(ctx context.Context, query *news.DuplicateQuery) ([]*news.Article, error) {
+	var clauses []bson.M
+	if query.CanonicalURL != "" {
+		clauses = append(clauses, bson.M{"canonical_url": query.CanonicalURL})
+	}
+	if len(query.FingerprintBands) > 0 {
+		clauses = append(clauses, bson.M{
+			"fingerprint_bands": bson.M{"$in": query.FingerprintBands},
+			"published_date":    bson.M{"$gte": query.From, "$lte": query.To},
+		})
+	}
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+
+	opts := options.Find().
+		SetLimit(int64(query.Limit)).
+		SetProjection(bson.M{"content": 0, "content_html": 0, "full_text": 0})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"$or": clauses}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []*news.Article
+	if err := cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// scoredArticle is an article decoded with its text search score
+type scoredArticle struct {
+	news.Article `bson:",inline"`
+	Score        float64 `bson:"text_score"`
+}
+
+// Search ranks articles with the collection's text index. The query keeps
+// MongoDB's text search syntax: quoted phrases and "-word" exclusions.
+func (r *NewsRepository) Search(ctx context.Context, query *news.SearchQuery) ([]*news.SearchResult, int64, error) {
+	// $text must be a top-level condition
+	mongoFilter := r.buildFilter(query.Filter)
+	mongoFilter["$text"] = bson.M{"$search": query.Text}
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"text_score": score}).
+		SetSort(bson.D{{Key: "text_score", Value: score}, {Key: "_id", Value: -1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+	if query.Offset > 0 {
+		opts.SetSkip(int64(query.Offset))
+	}
+
+	cursor, err := r.collection.Find(ctx, mongoFilter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*news.SearchResult
+	for cursor.Next(ctx) {
+		var scored scoredArticle
+		if err := cursor.Decode(&scored); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, &news.SearchResult{Article: &scored.Article, Score: scored.Score})
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, mongoFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// AddSources merges sources and their companies into an existing article
+func (r *NewsRepository) AddSources(ctx context.Context, id primitive.ObjectID, sources []news.ArticleSource) error {
+	companies := make([]string, 0, len(sources))
+	for _, source := range sources {
+		companies = append(companies, source.CompanyName)
+	}
+
+	update := bson.M{"$addToSet": bson.M{
+		"sources":   bson.M{"$each": sources},
+		"companies": bson.M{"$each": companies},
+	}}
+
+	result, err := r.collection.UpdateByID(ctx, id, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return news.ErrArticleNotFound
+	}
+
+	return nil
+}
+
 // CreateIndexes creates necessary indexes for the news collection
 func (r *NewsRepository) CreateIndexes(ctx context.Context) error {
 	indexes := []mongo.IndexModel{
@@ -195,22 +375,82 @@ func (r *NewsRepository) CreateIndexes(ctx context.Context) error {
 				"published_date": -1,
 			},
 		},
+		{
+			Keys: bson.M{"canonical_url": 1},
+		},
+		{
+			Keys: bson.D{
+				{Key: "fingerprint_bands", Value: 1},
+				{Key: "published_date", Value: -1},
+			},
+		},
+		{
+			Keys: bson.M{"sources.guid": 1},
+		},
+		{
+			Keys: bson.D{
+				{Key: "categories", Value: 1},
+				{Key: "published_date", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "sentiment.score", Value: 1},
+				{Key: "published_date", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "entities.id", Value: 1},
+				{Key: "published_date", Value: -1},
+			},
+		},
+		{
+			// A collection has at most one text index; title matches weigh most
+			Keys: bson.D{
+				{Key: "title", Value: "text"},
+				{Key: "summary", Value: "text"},
+				{Key: "content", Value: "text"},
+				{Key: "full_text", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("article_text").
+				SetDefaultLanguage("english").
+				SetWeights(bson.M{"title": 10, "summary": 5, "content": 1, "full_text": 1}),
+		},
 	}
 
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
 	return err
 }
 
-// buildFilter constructs MongoDB filter from NewsFilter
+// buildFilter compiles a NewsFilter into a single MongoDB filter. Each
+// condition is a separate clause so conditions on the same field combine.
 func (r *NewsRepository) buildFilter(filter *news.NewsFilter) bson.M {
-	mongoFilter := bson.M{}
-
 	if filter == nil {
-		return mongoFilter
+		return bson.M{}
 	}
 
+	var clauses []bson.M
+
 	if filter.Company != "" {
-		mongoFilter["companies"] = bson.M{"$in": []string{filter.Company}}
+		clauses = append(clauses, bson.M{"companies": filter.Company})
+	}
+
+	if len(filter.Companies) > 0 {
+		operator := "$in"
+		if filter.CompanyMatch == news.MatchAllCompany {
+			operator = "$all"
+		}
+		clauses = append(clauses, bson.M{"companies": bson.M{operator: filter.Companies}})
+	}
+
+	if len(filter.ExcludeCompanies) > 0 {
+		clauses = append(clauses, bson.M{"companies": bson.M{"$nin": filter.ExcludeCompanies}})
+	}
+
+	if len(filter.FeedSources) > 0 {
+		clauses = append(clauses, bson.M{"feed_source": bson.M{"$in": filter.FeedSources}})
 	}
 
 	if filter.StartDate != nil || filter.EndDate != nil {
@@ -221,14 +461,115 @@ func (r *NewsRepository) buildFilter(filter *news.NewsFilter) bson.M {
 		if filter.EndDate != nil {
 			dateFilter["$lte"] = *filter.EndDate
 		}
-		mongoFilter["published_date"] = dateFilter
+		clauses = append(clauses, bson.M{"published_date": dateFilter})
 	}
 
 	if filter.MinRelevance != nil {
-		mongoFilter["relevance_score"] = bson.M{"$gte": *filter.MinRelevance}
+		clauses = append(clauses, bson.M{"relevance_score": bson.M{"$gte": *filter.MinRelevance}})
+	}
+
+	if len(filter.Categories) > 0 {
+		clauses = append(clauses, bson.M{"categories": bson.M{"$in": filter.Categories}})
+	}
+
+	if len(filter.ExcludeCategories) > 0 {
+		clauses = append(clauses, bson.M{"categories": bson.M{"$nin": filter.ExcludeCategories}})
+	}
+
+	if filter.Entity != "" {
+		clauses = append(clauses, bson.M{"entities.id": filter.Entity})
+	}
+
+	// Every word or phrase must appear in the title or summary
+	for _, term := range news.QueryTerms(filter.Query) {
+		pattern := bson.M{"$regex": regexp.QuoteMeta(term), "$options": "i"}
+		clauses = append(clauses, bson.M{"$or": []bson.M{
+			{"title": pattern},
+			{"summary": pattern},
+		}})
+	}
+
+	if score, label := sentimentConditions(filter); score != nil || label != "" {
+		// With a single company, match the tone towards that company
+		if company := filter.SentimentCompany(); company != "" {
+			match := bson.M{"company_name": company}
+			if score != nil {
+				match["score"] = score
+			}
+			if label != "" {
+				match["label"] = label
+			}
+			clauses = append(clauses, bson.M{"sentiment.companies": bson.M{"$elemMatch": match}})
+		} else {
+			if score != nil {
+				clauses = append(clauses, bson.M{"sentiment.score": score})
+			}
+			if label != "" {
+				clauses = append(clauses, bson.M{"sentiment.label": label})
+			}
+		}
+	}
+
+	switch len(clauses) {
+	case 0:
+		return bson.M{}
+	case 1:
+		return clauses[0]
+	default:
+		return bson.M{"$and": clauses}
+	}
+}
+
+// sentimentConditions returns the score range and label conditions of the filter
+func sentimentConditions(filter *news.NewsFilter) (bson.M, news.SentimentLabel) {
+	var score bson.M
+	if filter.MinSentiment != nil || filter.MaxSentiment != nil {
+		score = bson.M{}
+		if filter.MinSentiment != nil {
+			score["$gte"] = *filter.MinSentiment
+		}
+		if filter.MaxSentiment != nil {
+			score["$lte"] = *filter.MaxSentiment
+		}
 	}
+	return score, filter.Sentiment
+}
 
-	return mongoFilter
+// sortKey returns the document field of a sort field
+func sortKey(field news.SortField) string {
+	if field == news.SortByRelevance {
+		return "relevance_score"
+	}
+	return "published_date"
+}
+
+// withCursor restricts a filter to the articles after the cursor in its sort
+// order, with the ID breaking ties between equal sort values
+func withCursor(mongoFilter bson.M, cursor *news.Cursor) bson.M {
+	if cursor == nil {
+		return mongoFilter
+	}
+
+	operator := "$lt"
+	if cursor.Order == news.SortAscending {
+		operator = "$gt"
+	}
+
+	key := sortKey(cursor.Sort)
+	var value interface{} = cursor.PublishedDate
+	if cursor.Sort == news.SortByRelevance {
+		value = cursor.Relevance
+	}
+
+	after := bson.M{"$or": []bson.M{
+		{key: bson.M{operator: value}},
+		{key: value, "_id": bson.M{operator: cursor.ID}},
+	}}
+
+	if len(mongoFilter) == 0 {
+		return after
+	}
+	return bson.M{"$and": []bson.M{mongoFilter, after}}
 }
 
 // buildOptions constructs MongoDB options from NewsFilter
@@ -236,13 +577,21 @@ func (r *NewsRepository) buildOptions(filter *news.NewsFilter) *options.FindOpti
 	opts := options.Find()
 
 	if filter == nil {
-		opts.SetSort(bson.M{"published_date": -1})
+		opts.SetSort(bson.D{{Key: "published_date", Value: -1}, {Key: "_id", Value: -1}})
 		opts.SetLimit(50)
 		return opts
 	}
 
-	// Sort by published date (newest first)
-	opts.SetSort(bson.M{"published_date": -1})
+	// Sort by the requested field (newest first by default), with the ID as
+	// tie-breaker so cursors resume at a stable position
+	direction := -1
+	if filter.EffectiveOrder() == news.SortAscending {
+		direction = 1
+	}
+	opts.SetSort(bson.D{
+		{Key: sortKey(filter.EffectiveSort()), Value: direction},
+		{Key: "_id", Value: direction},
+	})
 
 	if filter.Limit > 0 {
 		opts.SetLimit(int64(filter.Limit))
@@ -253,4 +602,4 @@ func (r *NewsRepository) buildOptions(filter *news.NewsFilter) *options.FindOpti
 	}
 
 	return opts
-}
\ No newline at end of file
+}
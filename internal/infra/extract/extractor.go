@@ -0,0 +1,180 @@
+package extract
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/time/rate"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+// Config controls how source pages are fetched
+type Config struct {
+	Timeout       time.Duration // Timeout for a single page request
+	PerHostDelay  time.Duration // Minimum delay between requests to the same host
+	MaxBodyBytes  int64         // Maximum number of bytes read from a page
+	MinTextLength int           // Minimum extracted text length to count as readable
+	UserAgent     string        // User agent sent with requests and matched against robots.txt
+}
+
+// DefaultConfig returns the extractor configuration used when none is provided
+func DefaultConfig() Config {
+	return Config{
+		Timeout:       20 * time.Second,
+		PerHostDelay:  time.Second,
+		MaxBodyBytes:  5 * 1024 * 1024,
+		MinTextLength: 200,
+		UserAgent:     "October-Backend/1.0",
+	}
+}
+
+// withDefaults replaces unset values with the defaults
+func (c Config) withDefaults() Config {
+	defaults := DefaultConfig()
+	if c.Timeout <= 0 {
+		c.Timeout = defaults.Timeout
+	}
+	if c.PerHostDelay < 0 {
+		c.PerHostDelay = defaults.PerHostDelay
+	}
+	if c.MaxBodyBytes <= 0 {
+		c.MaxBodyBytes = defaults.MaxBodyBytes
+	}
+	if c.MinTextLength <= 0 {
+		c.MinTextLength = defaults.MinTextLength
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = defaults.UserAgent
+	}
+	return c
+}
+
+// Extractor fetches article pages and extracts their main readable text.
+// It honours robots.txt and rate limits requests per host.
+type Extractor struct {
+	client   *http.Client
+	config   Config
+	robots   *robotsCache
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	logger   *slog.Logger
+}
+
+// NewExtractor creates a new full-text extractor
+func NewExtractor(config Config, logger *slog.Logger) *Extractor {
+	config = config.withDefaults()
+	e := &Extractor{
+		client:   &http.Client{Timeout: config.Timeout},
+		config:   config,
+		limiters: make(map[string]*rate.Limiter),
+		logger:   logger,
+	}
+	// robots.txt requests share the per-host rate limit with page requests
+	e.robots = newRobotsCache(e.client, config.UserAgent, e.wait)
+
+	return e
+}
+
+// Extract fetches the page at sourceURL and returns its readable text
+func (e *Extractor) Extract(ctx context.Context, sourceURL string) (*news.ExtractedContent, error) {
+	target, err := url.Parse(sourceURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("%w: invalid source URL %q", news.ErrUnsupportedContent, sourceURL)
+	}
+
+	allowed, err := e.robots.Allowed(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, news.ErrRobotsDisallowed
+	}
+
+	if err := e.wait(ctx, target.Host); err != nil {
+		return nil, err
+	}
+
+	resp, err := e.fetch(ctx, target.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode the page to UTF-8 using its declared or sniffed charset
+	body, err := charset.NewReader(io.LimitReader(resp.Body, e.config.MaxBodyBytes), resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page: %w", err)
+	}
+
+	page, err := extractReadable(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	if len(page.text) < e.config.MinTextLength {
+		return nil, news.ErrNoReadableContent
+	}
+
+	e.logger.Debug("Extracted article text", "url", sourceURL, "length", len(page.text))
+
+	return &news.ExtractedContent{
+		Title:     page.title,
+		Text:      page.text,
+		WordCount: len(strings.Fields(page.text)),
+	}, nil
+}
+
+// fetch requests a page and returns the response when it is an HTML document
+func (e *Extractor) fetch(ctx context.Context, pageURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", e.config.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("page request failed with status %d", resp.StatusCode)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", news.ErrUnsupportedContent, resp.Header.Get("Content-Type"))
+	}
+
+	return resp, nil
+}
+
+// wait blocks until a request to the host is allowed by its rate limit
+func (e *Extractor) wait(ctx context.Context, host string) error {
+	if e.config.PerHostDelay == 0 {
+		return nil
+	}
+
+	e.mu.Lock()
+	limiter, ok := e.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Every(e.config.PerHostDelay), 1)
+		e.limiters[host] = limiter
+	}
+	e.mu.Unlock()
+
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,177 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+)
+
+const testRobotsTxt = `User-agent: *
+Disallow: /private/
+
+User-agent: October-Backend
+Disallow: /members/
+Allow: /members/public
+`
+
+const testArticleHTML = `<!DOCTYPE html>
+<html>
+<head>
+	<title>RTX wins radar contract</title>
+	<style>body { color: red; }</style>
+	<script>var tracking = "do not extract";</script>
+</head>
+<body>
+	<header><a href="/">Home</a> <a href="/news">News</a></header>
+	<nav class="main-nav"><ul><li><a href="/a">Products</a></li><li><a href="/b">Investors</a></li></ul></nav>
+	<div class="layout">
+		<div class="sidebar"><a href="/related">Related stories you may like</a></div>
+		<div class="story-body">
+			<h1>RTX wins radar contract</h1>
+			<p>RTX&#39;s Raytheon business was awarded a $1.2 billion contract by the U.S. Army to produce the Lower Tier Air and Missile Defense Sensor, the company said on Tuesday.</p>
+			<p>The radar provides 360-degree coverage, and production will take place in Andover, Massachusetts, with deliveries expected through 2028.</p>
+			<p>&ldquo;This award reflects the Army&rsquo;s confidence in the program,&rdquo; said a company spokesperson, adding that testing has met every milestone.</p>
+		</div>
+		<div id="comments">Leave a comment with your thoughts on this story</div>
+	</div>
+	<div class="cookie-banner">We use cookies to improve your experience on this site.</div>
+	<footer>Copyright RTX. All rights reserved.</footer>
+</body>
+</html>`
+
+func newTestExtractor() *Extractor {
+	return NewExtractor(Config{PerHostDelay: time.Millisecond}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testRobotsTxt))
+	})
+	page := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(testArticleHTML))
+	}
+	mux.HandleFunc("/news/radar", page)
+	mux.HandleFunc("/members/article", page)
+	mux.HandleFunc("/members/public/article", page)
+	mux.HandleFunc("/report.pdf", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4"))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><nav>Menu</nav><p>Short.</p></body></html>`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExtractMainContent(t *testing.T) {
+	server := newTestServer(t)
+
+	content, err := newTestExtractor().Extract(context.Background(), server.URL+"/news/radar")
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	if content.Title != "RTX wins radar contract" {
+		t.Errorf("Expected title from <title>, got %q", content.Title)
+	}
+
+	wantContains := []string{
+		"RTX's Raytheon business was awarded a $1.2 billion contract",
+		"“This award reflects the Army’s confidence in the program,”",
+		"deliveries expected through 2028.",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(content.Text, want) {
+			t.Errorf("Expected text to contain %q, got:\n%s", want, content.Text)
+		}
+	}
+
+	wantExcluded := []string{"tracking", "color: red", "Products", "Investors", "Related stories", "Leave a comment", "cookies", "Copyright"}
+	for _, unwanted := range wantExcluded {
+		if strings.Contains(content.Text, unwanted) {
+			t.Errorf("Expected boilerplate %q to be removed, got:\n%s", unwanted, content.Text)
+		}
+	}
+
+	if paragraphs := strings.Split(content.Text, "\n\n"); len(paragraphs) != 4 {
+		t.Errorf("Expected heading and 3 paragraphs, got %d: %q", len(paragraphs), paragraphs)
+	}
+	if content.WordCount == 0 {
+		t.Error("Expected word count to be set")
+	}
+}
+
+func TestExtractRespectsRobots(t *testing.T) {
+	server := newTestServer(t)
+	extractor := newTestExtractor()
+	ctx := context.Background()
+
+	if _, err := extractor.Extract(ctx, server.URL+"/members/article"); !errors.Is(err, news.ErrRobotsDisallowed) {
+		t.Errorf("Expected ErrRobotsDisallowed for disallowed path, got %v", err)
+	}
+
+	if _, err := extractor.Extract(ctx, server.URL+"/members/public/article"); err != nil {
+		t.Errorf("Expected longer Allow rule to win, got %v", err)
+	}
+}
+
+func TestExtractSkipsUnreadablePages(t *testing.T) {
+	server := newTestServer(t)
+	extractor := newTestExtractor()
+	ctx := context.Background()
+
+	if _, err := extractor.Extract(ctx, server.URL+"/report.pdf"); !errors.Is(err, news.ErrUnsupportedContent) {
+		t.Errorf("Expected ErrUnsupportedContent for PDF, got %v", err)
+	}
+
+	if _, err := extractor.Extract(ctx, server.URL+"/empty"); !errors.Is(err, news.ErrNoReadableContent) {
+		t.Errorf("Expected ErrNoReadableContent for empty page, got %v", err)
+	}
+
+	if _, err := extractor.Extract(ctx, "ftp://example.com/file"); !errors.Is(err, news.ErrUnsupportedContent) {
+		t.Errorf("Expected ErrUnsupportedContent for non-HTTP URL, got %v", err)
+	}
+}
+
+func TestExtractRateLimitsPerHost(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	const delay = 50 * time.Millisecond
+	extractor := NewExtractor(Config{PerHostDelay: delay}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		extractor.Extract(context.Background(), server.URL+"/missing")
+	}
+	elapsed := time.Since(start)
+
+	// One robots.txt request plus three page requests, spaced by the delay
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Expected 4 requests, got %d", got)
+	}
+	if elapsed < 3*delay {
+		t.Errorf("Expected requests spaced by %s, finished in %s", delay, elapsed)
+	}
+}
@@ -0,0 +1,217 @@
+package extract
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/Neph-dev/october_backend/internal/infra/htmlutil"
+)
+
+const (
+	// minContainerText is the text length a semantic container (<article>,
+	// <main>) needs before it is trusted as the main content
+	minContainerText = 200
+	// minParagraphText ignores short paragraphs such as captions and bylines when scoring
+	minParagraphText = 25
+)
+
+// boilerplateElements never contain article text
+var boilerplateElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Iframe:   true,
+	atom.Svg:      true,
+	atom.Nav:      true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Aside:    true,
+	atom.Form:     true,
+	atom.Button:   true,
+	atom.Template: true,
+	atom.Select:   true,
+	atom.Object:   true,
+	atom.Embed:    true,
+}
+
+// boilerplateRoles are ARIA landmark roles of page chrome
+var boilerplateRoles = map[string]bool{
+	"navigation":    true,
+	"banner":        true,
+	"contentinfo":   true,
+	"complementary": true,
+	"search":        true,
+	"dialog":        true,
+}
+
+// boilerplateTokens are class or id tokens of page chrome
+var boilerplateTokens = map[string]bool{
+	"nav": true, "navbar": true, "navigation": true, "menu": true,
+	"footer": true, "sidebar": true, "breadcrumb": true, "breadcrumbs": true,
+	"comment": true, "comments": true, "share": true, "sharing": true, "social": true,
+	"cookie": true, "cookies": true, "consent": true, "banner": true,
+	"subscribe": true, "newsletter": true, "related": true, "recommended": true,
+	"promo": true, "ad": true, "ads": true, "advert": true, "advertisement": true,
+	"sponsored": true, "popup": true, "modal": true, "skip": true,
+}
+
+// readable is the main content of a page
+type readable struct {
+	title string
+	text  string
+}
+
+// extractReadable parses an HTML document and returns its title and main
+// readable text with boilerplate, navigation and scripts removed
+func extractReadable(r io.Reader) (*readable, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	title := htmlutil.CollapseWhitespace(htmlutil.TextOf(htmlutil.FindFirst(doc, atom.Title)))
+	removeBoilerplate(doc)
+
+	root := findMainContent(doc)
+	if root == nil {
+		return &readable{title: title}, nil
+	}
+
+	return &readable{title: title, text: htmlutil.BlockText(root)}, nil
+}
+
+// removeBoilerplate detaches navigation, scripts and other page chrome
+func removeBoilerplate(doc *html.Node) {
+	var remove []*html.Node
+	htmlutil.Walk(doc, func(n *html.Node) bool {
+		if n.Type == html.CommentNode || (n.Type == html.ElementNode && isBoilerplate(n)) {
+			remove = append(remove, n)
+			return false
+		}
+		return true
+	}, nil)
+
+	for _, n := range remove {
+		n.Parent.RemoveChild(n)
+	}
+}
+
+// isBoilerplate reports whether an element is page chrome rather than content
+func isBoilerplate(n *html.Node) bool {
+	if boilerplateElements[n.DataAtom] {
+		return true
+	}
+
+	// Never drop the containers that hold the content itself
+	switch n.DataAtom {
+	case atom.Html, atom.Body, atom.Main, atom.Article:
+		return false
+	}
+
+	for _, attr := range n.Attr {
+		switch attr.Key {
+		case "hidden":
+			return true
+		case "aria-hidden":
+			if attr.Val == "true" {
+				return true
+			}
+		case "role":
+			if boilerplateRoles[strings.ToLower(attr.Val)] {
+				return true
+			}
+		case "class", "id":
+			if hasBoilerplateToken(attr.Val) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasBoilerplateToken splits a class or id value into words and checks them
+func hasBoilerplateToken(value string) bool {
+	tokens := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '\t' || r == '\n'
+	})
+	for _, token := range tokens {
+		if boilerplateTokens[token] {
+			return true
+		}
+	}
+	return false
+}
+
+// findMainContent picks the element holding the article text: a
+// substantial <article> or <main>, otherwise the best scoring container
+func findMainContent(doc *html.Node) *html.Node {
+	for _, a := range []atom.Atom{atom.Article, atom.Main} {
+		if n := htmlutil.FindFirst(doc, a); n != nil && len(htmlutil.CollapseWhitespace(htmlutil.TextOf(n))) >= minContainerText {
+			return n
+		}
+	}
+
+	scores := scoreParagraphs(doc)
+
+	var best *html.Node
+	bestScore := 0.0
+	for node, score := range scores {
+		score *= 1 - linkDensity(node)
+		if score > bestScore {
+			best, bestScore = node, score
+		}
+	}
+
+	if best != nil {
+		return best
+	}
+	return htmlutil.FindFirst(doc, atom.Body)
+}
+
+// scoreParagraphs credits each paragraph's text length to its parent and,
+// at half weight, its grandparent, so the container of most prose wins
+func scoreParagraphs(doc *html.Node) map[*html.Node]float64 {
+	scores := make(map[*html.Node]float64)
+
+	htmlutil.Walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || (n.DataAtom != atom.P && n.DataAtom != atom.Pre && n.DataAtom != atom.Blockquote) {
+			return true
+		}
+
+		text := htmlutil.TextOf(n)
+		length := len(htmlutil.CollapseWhitespace(text))
+		if length >= minParagraphText && n.Parent != nil {
+			score := 1 + float64(length)/100 + float64(strings.Count(text, ","))
+			scores[n.Parent] += score
+			if n.Parent.Parent != nil {
+				scores[n.Parent.Parent] += score / 2
+			}
+		}
+		return false
+	}, nil)
+
+	return scores
+}
+
+// linkDensity is the share of an element's text that sits inside links
+func linkDensity(n *html.Node) float64 {
+	total := len(htmlutil.CollapseWhitespace(htmlutil.TextOf(n)))
+	if total == 0 {
+		return 0
+	}
+
+	linked := 0
+	htmlutil.Walk(n, func(node *html.Node) bool {
+		if node.Type == html.ElementNode && node.DataAtom == atom.A {
+			linked += len(htmlutil.CollapseWhitespace(htmlutil.TextOf(node)))
+			return false
+		}
+		return true
+	}, nil)
+
+	return float64(linked) / float64(total)
+}
@@ -0,0 +1,248 @@
+package extract
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// robotsTTL is how long a fetched robots.txt is trusted
+	robotsTTL = 24 * time.Hour
+	// robotsErrorTTL is how long a server error on robots.txt blocks a host
+	robotsErrorTTL = time.Hour
+	// maxRobotsBytes bounds the size of a parsed robots.txt (Google uses 500 KiB)
+	maxRobotsBytes = 500 * 1024
+)
+
+// robotsRule is a single Allow or Disallow line
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// robotsRules are the rules of robots.txt that apply to our user agent
+type robotsRules struct {
+	rules      []robotsRule
+	disallowed bool // Set when the whole host must be treated as disallowed
+}
+
+// allowAll is the rule set used when a host has no robots.txt
+var allowAll = &robotsRules{}
+
+// Allowed reports whether the path (including query) may be fetched.
+// The longest matching pattern wins; on a tie Allow wins.
+func (r *robotsRules) Allowed(path string) bool {
+	if r.disallowed {
+		return false
+	}
+
+	allowed := true
+	matchLength := -1
+	for _, rule := range r.rules {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+		length := len(rule.pattern)
+		if length > matchLength || (length == matchLength && rule.allow) {
+			allowed = rule.allow
+			matchLength = length
+		}
+	}
+
+	return allowed
+}
+
+// parseRobots parses robots.txt and keeps the rules of the most specific
+// group matching the user agent, falling back to the "*" group
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	agent := strings.ToLower(productToken(userAgent))
+
+	type group struct {
+		agents []string
+		rules  []robotsRule
+	}
+
+	var groups []*group
+	var current *group
+	lastWasAgent := false
+
+	scanner := bufio.NewScanner(io.LimitReader(r, maxRobotsBytes))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// Consecutive user-agent lines share one group
+			if current == nil || !lastWasAgent {
+				current = &group{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			lastWasAgent = true
+		case "allow", "disallow":
+			lastWasAgent = false
+			if current == nil || value == "" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{allow: key == "allow", pattern: value})
+		default:
+			lastWasAgent = false
+		}
+	}
+
+	var best *group
+	bestLength := -1
+	for _, g := range groups {
+		for _, name := range g.agents {
+			switch {
+			case name == "*" && bestLength < 0:
+				best, bestLength = g, 0
+			case name != "*" && strings.Contains(agent, name) && len(name) > bestLength:
+				best, bestLength = g, len(name)
+			}
+		}
+	}
+
+	if best == nil {
+		return allowAll
+	}
+	return &robotsRules{rules: best.rules}
+}
+
+// matchRobotsPattern matches a robots.txt path pattern supporting the "*"
+// wildcard and the "$" end anchor
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = strings.TrimSuffix(pattern, "$")
+	}
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+
+	for i := 1; i < len(parts); i++ {
+		part := parts[i]
+		if i == len(parts)-1 && anchored {
+			return strings.HasSuffix(rest, part)
+		}
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+
+	return !anchored || rest == ""
+}
+
+// productToken returns the product name of a user agent ("October-Backend/1.0" -> "October-Backend")
+func productToken(userAgent string) string {
+	token, _, _ := strings.Cut(userAgent, "/")
+	return strings.TrimSpace(token)
+}
+
+// robotsEntry is a cached robots.txt rule set
+type robotsEntry struct {
+	rules     *robotsRules
+	expiresAt time.Time
+}
+
+// robotsCache fetches and caches robots.txt per host
+type robotsCache struct {
+	mu        sync.Mutex
+	entries   map[string]robotsEntry
+	client    *http.Client
+	userAgent string
+	wait      func(ctx context.Context, host string) error // Rate limits robots.txt requests
+}
+
+// newRobotsCache creates a new robots.txt cache
+func newRobotsCache(client *http.Client, userAgent string, wait func(ctx context.Context, host string) error) *robotsCache {
+	return &robotsCache{
+		entries:   make(map[string]robotsEntry),
+		client:    client,
+		userAgent: userAgent,
+		wait:      wait,
+	}
+}
+
+// Allowed reports whether robots.txt of the URL's host allows fetching it
+func (c *robotsCache) Allowed(ctx context.Context, target *url.URL) (bool, error) {
+	rules, err := c.rulesFor(ctx, target)
+	if err != nil {
+		return false, err
+	}
+	return rules.Allowed(target.RequestURI()), nil
+}
+
+// rulesFor returns the cached rules of the URL's host, fetching them when missing or expired
+func (c *robotsCache) rulesFor(ctx context.Context, target *url.URL) (*robotsRules, error) {
+	key := target.Scheme + "://" + target.Host
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.rules, nil
+	}
+
+	if err := c.wait(ctx, target.Host); err != nil {
+		return nil, err
+	}
+
+	rules, ttl, err := c.fetch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = robotsEntry{rules: rules, expiresAt: time.Now().Add(ttl)}
+	c.mu.Unlock()
+
+	return rules, nil
+}
+
+// fetch downloads and parses robots.txt. A missing robots.txt (4xx) allows
+// everything; a server error disallows the host for a shorter period.
+func (c *robotsCache) fetch(ctx context.Context, origin string) (*robotsRules, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create robots.txt request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch robots.txt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return parseRobots(resp.Body, c.userAgent), robotsTTL, nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return allowAll, robotsTTL, nil
+	default:
+		return &robotsRules{disallowed: true}, robotsErrorTTL, nil
+	}
+}
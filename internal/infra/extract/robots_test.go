@@ -0,0 +1,86 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRobotsGroupSelection(t *testing.T) {
+	robots := `# comment
+User-agent: Googlebot
+Disallow: /
+
+User-agent: *
+Disallow: /admin
+Disallow: /*.pdf$
+Allow: /admin/press
+`
+
+	rules := parseRobots(strings.NewReader(robots), "October-Backend/1.0")
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/news/story", true},
+		{"/admin", false},
+		{"/admin/settings", false},
+		{"/admin/press/release-1", true},
+		{"/files/report.pdf", false},
+		{"/files/report.pdf?download=1", true},
+	}
+
+	for _, tt := range tests {
+		if got := rules.Allowed(tt.path); got != tt.allowed {
+			t.Errorf("Allowed(%q) = %v, want %v", tt.path, got, tt.allowed)
+		}
+	}
+}
+
+func TestParseRobotsSpecificAgent(t *testing.T) {
+	robots := `User-agent: *
+Disallow: /
+
+User-agent: other-bot
+User-agent: october-backend
+Disallow: /private
+`
+
+	rules := parseRobots(strings.NewReader(robots), "October-Backend/1.0")
+
+	if !rules.Allowed("/news") {
+		t.Error("Expected specific group to override the wildcard group")
+	}
+	if rules.Allowed("/private/page") {
+		t.Error("Expected /private to be disallowed by the specific group")
+	}
+}
+
+func TestParseRobotsEmpty(t *testing.T) {
+	rules := parseRobots(strings.NewReader("User-agent: *\nDisallow:\n"), "October-Backend/1.0")
+	if !rules.Allowed("/anything") {
+		t.Error("Expected empty Disallow to allow everything")
+	}
+}
+
+func TestMatchRobotsPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"/news", "/news/today", true},
+		{"/news", "/about", false},
+		{"/*/print", "/2024/story/print", true},
+		{"/*.php$", "/index.php", true},
+		{"/*.php$", "/index.php?x=1", false},
+		{"/exact$", "/exact", true},
+		{"/exact$", "/exactly", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchRobotsPattern(tt.pattern, tt.path); got != tt.match {
+			t.Errorf("matchRobotsPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.match)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package feed
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"github.com/Neph-dev/october_backend/internal/infra/htmlutil"
+	"github.com/mmcdole/gofeed"
+)
+
+// maxSummaryRunes bounds the length of article summaries
+const maxSummaryRunes = 300
+
+// normalizedContent is the cleaned text and media of a feed item
+type normalizedContent struct {
+	title       string
+	summary     string
+	content     string
+	contentHTML string
+	media       []news.Media
+}
+
+// normalizeItem sanitises a feed item's HTML, derives plain-text versions
+// and collects its images and enclosures as media attachments
+func normalizeItem(item *gofeed.Item) normalizedContent {
+	rawContent := item.Content
+	if rawContent == "" {
+		rawContent = item.Description
+	}
+
+	contentHTML, images := htmlutil.Sanitize(rawContent)
+	content := htmlutil.PlainText(rawContent)
+
+	// Prefer the description for the summary, falling back to the content
+	summary := htmlutil.PlainText(item.Description)
+	if summary == "" {
+		summary = content
+	}
+
+	media := newMediaSet()
+	if item.Image != nil {
+		media.add(news.Media{URL: item.Image.URL, Type: news.MediaImage, Title: item.Image.Title})
+	}
+	for _, enclosure := range item.Enclosures {
+		if enclosure == nil {
+			continue
+		}
+		length, _ := strconv.ParseInt(enclosure.Length, 10, 64)
+		media.add(news.Media{
+			URL:      enclosure.URL,
+			Type:     mediaTypeOf(enclosure.Type, ""),
+			MIMEType: enclosure.Type,
+			Length:   length,
+		})
+	}
+	for _, m := range mediaRSSAttachments(item) {
+		media.add(m)
+	}
+	for _, image := range images {
+		media.add(news.Media{URL: image.URL, Type: news.MediaImage, Title: image.Alt, Width: image.Width, Height: image.Height})
+	}
+
+	return normalizedContent{
+		title:       htmlutil.PlainText(item.Title),
+		summary:     htmlutil.Truncate(summary, maxSummaryRunes),
+		content:     content,
+		contentHTML: contentHTML,
+		media:       media.items,
+	}
+}
+
+// mediaRSSAttachments reads Media RSS <media:content> and <media:thumbnail> elements
+func mediaRSSAttachments(item *gofeed.Item) []news.Media {
+	extension, ok := item.Extensions["media"]
+	if !ok {
+		return nil
+	}
+
+	var media []news.Media
+	for _, name := range []string{"content", "thumbnail"} {
+		for _, element := range extension[name] {
+			attrs := element.Attrs
+			width, _ := strconv.Atoi(attrs["width"])
+			height, _ := strconv.Atoi(attrs["height"])
+			length, _ := strconv.ParseInt(attrs["fileSize"], 10, 64)
+
+			mediaType := mediaTypeOf(attrs["type"], attrs["medium"])
+			if name == "thumbnail" {
+				mediaType = news.MediaImage
+			}
+
+			media = append(media, news.Media{
+				URL:      attrs["url"],
+				Type:     mediaType,
+				MIMEType: attrs["type"],
+				Width:    width,
+				Height:   height,
+				Length:   length,
+			})
+		}
+	}
+	return media
+}
+
+// mediaTypeOf classifies media from its MIME type or Media RSS medium
+func mediaTypeOf(mimeType, medium string) news.MediaType {
+	switch medium {
+	case "image":
+		return news.MediaImage
+	case "video":
+		return news.MediaVideo
+	case "audio":
+		return news.MediaAudio
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(mimeType)
+	switch {
+	case strings.HasPrefix(mediaType, "image/"):
+		return news.MediaImage
+	case strings.HasPrefix(mediaType, "video/"):
+		return news.MediaVideo
+	case strings.HasPrefix(mediaType, "audio/"):
+		return news.MediaAudio
+	default:
+		return news.MediaFile
+	}
+}
+
+// mediaSet collects media attachments without duplicates or tracking pixels
+type mediaSet struct {
+	seen  map[string]bool
+	items []news.Media
+}
+
+// newMediaSet creates an empty media set
+func newMediaSet() *mediaSet {
+	return &mediaSet{seen: make(map[string]bool)}
+}
+
+// add appends an attachment unless its URL is empty, seen or a tracker
+func (s *mediaSet) add(m news.Media) {
+	m.URL = strings.TrimSpace(m.URL)
+	if m.URL == "" || s.seen[m.URL] || htmlutil.IsTrackingURL(m.URL) {
+		return
+	}
+	s.seen[m.URL] = true
+	s.items = append(s.items, m)
+}
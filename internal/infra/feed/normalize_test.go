@@ -0,0 +1,75 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"github.com/mmcdole/gofeed"
+)
+
+const testRichFeedXML = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+<channel>
+	<title>Test Feed</title>
+	<item>
+		<title>RTX &amp; Army sign LTAMDS deal</title>
+		<link>https://example.com/news/2</link>
+		<guid>news-2</guid>
+		<description><![CDATA[<p>RTX&#8217;s Raytheon won a <b>$1.2&nbsp;billion</b> Army contract.</p> <img src="https://feeds.feedburner.com/~r/rtx/~4/xyz" width="1" height="1"/>]]></description>
+		<content:encoded><![CDATA[<p>Full <em>story</em> text.</p><script>track()</script><img src="https://example.com/radar.jpg" alt="Radar"/>]]></content:encoded>
+		<enclosure url="https://example.com/briefing.mp3" length="12345" type="audio/mpeg"/>
+		<media:content url="https://example.com/launch.mp4" type="video/mp4" width="1280" height="720"/>
+		<media:thumbnail url="https://example.com/radar.jpg"/>
+	</item>
+</channel>
+</rss>`
+
+func TestConvertToRSSFeedItemNormalizes(t *testing.T) {
+	parsed, err := gofeed.NewParser().ParseString(testRichFeedXML)
+	if err != nil {
+		t.Fatalf("ParseString() failed: %v", err)
+	}
+
+	item := newTestRSSService().convertToRSSFeedItem(parsed.Items[0])
+
+	if item.Title != "RTX & Army sign LTAMDS deal" {
+		t.Errorf("Unexpected title %q", item.Title)
+	}
+	if item.Summary != "RTX’s Raytheon won a $1.2 billion Army contract." {
+		t.Errorf("Unexpected summary %q", item.Summary)
+	}
+	if item.Content != "Full story text." {
+		t.Errorf("Unexpected content %q", item.Content)
+	}
+	if strings.Contains(item.ContentHTML, "script") || !strings.Contains(item.ContentHTML, "<em>story</em>") {
+		t.Errorf("Unexpected sanitized HTML %q", item.ContentHTML)
+	}
+
+	expected := map[string]news.MediaType{
+		"https://example.com/briefing.mp3": news.MediaAudio,
+		"https://example.com/launch.mp4":   news.MediaVideo,
+		"https://example.com/radar.jpg":    news.MediaImage,
+	}
+	if len(item.Media) != len(expected) {
+		t.Fatalf("Expected %d media attachments, got %d: %+v", len(expected), len(item.Media), item.Media)
+	}
+	for _, m := range item.Media {
+		if want, ok := expected[m.URL]; !ok || m.Type != want {
+			t.Errorf("Unexpected media %+v", m)
+		}
+	}
+}
+
+func TestConvertToRSSFeedItemSummaryTruncation(t *testing.T) {
+	long := strings.Repeat("The radar programme continues to expand ahead of schedule. ", 20)
+
+	item := newTestRSSService().convertToRSSFeedItem(&gofeed.Item{Title: "Long", Content: long, Link: "https://example.com/3"})
+
+	if len([]rune(item.Summary)) > maxSummaryRunes {
+		t.Errorf("Expected summary of at most %d runes, got %d", maxSummaryRunes, len([]rune(item.Summary)))
+	}
+	if !strings.HasSuffix(item.Summary, "schedule.") {
+		t.Errorf("Expected summary to end on a sentence boundary, got %q", item.Summary)
+	}
+}
@@ -0,0 +1,127 @@
+package feed
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PoolConfig controls how feeds are processed concurrently
+type PoolConfig struct {
+	Workers            int           // Number of feeds processed at the same time
+	PerHostConcurrency int           // Maximum concurrent requests to the same host
+	PerHostDelay       time.Duration // Minimum delay between requests to the same host
+	FeedTimeout        time.Duration // Timeout applied to each feed individually
+}
+
+// DefaultPoolConfig returns the pool configuration used when none is provided
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		Workers:            4,
+		PerHostConcurrency: 1,
+		PerHostDelay:       2 * time.Second,
+		FeedTimeout:        2 * time.Minute,
+	}
+}
+
+// withDefaults replaces unset values with the defaults
+func (c PoolConfig) withDefaults() PoolConfig {
+	defaults := DefaultPoolConfig()
+	if c.Workers <= 0 {
+		c.Workers = defaults.Workers
+	}
+	if c.PerHostConcurrency <= 0 {
+		c.PerHostConcurrency = defaults.PerHostConcurrency
+	}
+	if c.PerHostDelay < 0 {
+		c.PerHostDelay = defaults.PerHostDelay
+	}
+	if c.FeedTimeout <= 0 {
+		c.FeedTimeout = defaults.FeedTimeout
+	}
+	return c
+}
+
+// hostLimiter enforces per-host concurrency and a minimum delay between
+// consecutive requests to the same host
+type hostLimiter struct {
+	mu          sync.Mutex
+	concurrency int
+	delay       time.Duration
+	hosts       map[string]*hostSlot
+}
+
+type hostSlot struct {
+	slots       chan struct{}
+	nextAllowed time.Time
+}
+
+// newHostLimiter creates a limiter for the given per-host settings
+func newHostLimiter(concurrency int, delay time.Duration) *hostLimiter {
+	return &hostLimiter{
+		concurrency: concurrency,
+		delay:       delay,
+		hosts:       make(map[string]*hostSlot),
+	}
+}
+
+// acquire blocks until a request to host may start. The returned function
+// must be called to release the slot.
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	slot := l.slot(host)
+
+	select {
+	case slot.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	release := func() { <-slot.slots }
+
+	// Reserve the next start time for this host under the lock, then wait outside it
+	l.mu.Lock()
+	now := time.Now()
+	start := slot.nextAllowed
+	if start.Before(now) {
+		start = now
+	}
+	slot.nextAllowed = start.Add(l.delay)
+	l.mu.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// slot returns the slot for a host, creating it on first use
+func (l *hostLimiter) slot(host string) *hostSlot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slot, exists := l.hosts[host]
+	if !exists {
+		slot = &hostSlot{slots: make(chan struct{}, l.concurrency)}
+		l.hosts[host] = slot
+	}
+	return slot
+}
+
+// hostOf extracts the lower-cased host of a feed URL
+func hostOf(feedURL string) string {
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Host == "" {
+		return feedURL
+	}
+	return strings.ToLower(parsed.Host)
+}
@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHostLimiterDelay(t *testing.T) {
+	const delay = 50 * time.Millisecond
+
+	limiter := newHostLimiter(2, delay)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		release, err := limiter.acquire(ctx, "example.com")
+		if err != nil {
+			t.Fatalf("acquire() failed: %v", err)
+		}
+		release()
+	}
+
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("Expected second request to wait at least %v, waited %v", delay, elapsed)
+	}
+
+	// A different host is not delayed by the first one
+	other := time.Now()
+	release, err := limiter.acquire(ctx, "other.example.com")
+	if err != nil {
+		t.Fatalf("acquire() failed: %v", err)
+	}
+	release()
+	if elapsed := time.Since(other); elapsed >= delay {
+		t.Errorf("Expected other host to start immediately, waited %v", elapsed)
+	}
+}
+
+func TestHostLimiterConcurrency(t *testing.T) {
+	limiter := newHostLimiter(1, 0)
+
+	release, err := limiter.acquire(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("acquire() failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.acquire(ctx, "example.com"); err == nil {
+		t.Error("Expected second acquire to block until the context expired")
+	}
+}
+
+func TestHostOf(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.RTX.com/rss-feeds/news", "www.rtx.com"},
+		{"http://example.com:8080/feed", "example.com:8080"},
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := hostOf(tt.url); got != tt.want {
+			t.Errorf("hostOf(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -2,129 +2,556 @@ package feed
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+	"github.com/Neph-dev/october_backend/internal/domain/feed"
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 )
 
 // ProcessorService handles RSS feed processing and article creation
 type ProcessorService struct {
-	rssService     *RSSService
-	newsService    *news.Service
-	companyService company.Service
-	logger         *slog.Logger
+	rssService      *RSSService
+	newsService     *news.Service
+	companyService  company.Service
+	stateRepo       feed.StateRepository
+	feedService     *feed.Service
+	poolConfig      PoolConfig
+	schedule        feed.SchedulePolicy
+	extractor       news.Extractor
+	contractService *contract.Service
+	embeddings      *embedding.Service
+	hostLimiter     *hostLimiter
+	logger          *slog.Logger
 }
 
-// NewProcessorService creates a new feed processor service
+const (
+	// companyPageSize and maxCompanyPages bound listing every company
+	companyPageSize = 100
+	maxCompanyPages = 1000
+)
+
+// feedJob is a single feed queued for processing
+type feedJob struct {
+	companyName string
+	industry    company.Industry
+	feed        company.Feed
+}
+
+// NewProcessorService creates a new feed processor service.
+// extractor is optional; when nil, articles are stored without full text.
+// contractService is optional; when nil, contract awards are not extracted.
+// embeddings is optional; when nil, articles are not embedded for semantic retrieval.
 func NewProcessorService(
 	rssService *RSSService,
 	newsService *news.Service,
 	companyService company.Service,
+	stateRepo feed.StateRepository,
+	feedService *feed.Service,
+	poolConfig PoolConfig,
+	schedule feed.SchedulePolicy,
+	extractor news.Extractor,
+	contractService *contract.Service,
+	embeddings *embedding.Service,
 	logger *slog.Logger,
 ) *ProcessorService {
+	poolConfig = poolConfig.withDefaults()
+
 	return &ProcessorService{
-		rssService:     rssService,
-		newsService:    newsService,
-		companyService: companyService,
-		logger:         logger,
+		rssService:      rssService,
+		newsService:     newsService,
+		companyService:  companyService,
+		stateRepo:       stateRepo,
+		feedService:     feedService,
+		poolConfig:      poolConfig,
+		schedule:        schedule.WithDefaults(),
+		extractor:       extractor,
+		contractService: contractService,
+		embeddings:      embeddings,
+		hostLimiter:     newHostLimiter(poolConfig.PerHostConcurrency, poolConfig.PerHostDelay),
+		logger:          logger,
 	}
 }
 
-// ProcessCompanyFeed fetches and processes RSS feed for a specific company
-func (s *ProcessorService) ProcessCompanyFeed(ctx context.Context, companyName string) error {
-	s.logger.Info("Processing RSS feed for company", "company", companyName)
+// ProcessCompanyFeed fetches and processes every enabled feed of a specific company
+func (s *ProcessorService) ProcessCompanyFeed(ctx context.Context, companyName string) (*feed.RunReport, error) {
+	s.logger.Info("Processing RSS feeds for company", "company", companyName)
 
 	// Get company information
 	compResp, err := s.companyService.GetCompanyByName(ctx, companyName)
 	if err != nil {
 		s.logger.Error("Failed to get company", "error", err, "company", companyName)
-		return fmt.Errorf("failed to get company %s: %w", companyName, err)
+		return nil, fmt.Errorf("failed to get company %s: %w", companyName, err)
+	}
+
+	jobs := companyJobs(compResp)
+	if len(jobs) == 0 {
+		s.logger.Warn("Company has no enabled feeds", "company", companyName)
+		return nil, fmt.Errorf("company %s has no enabled feeds", companyName)
+	}
+
+	report := s.runJobs(ctx, jobs)
+
+	var errs []error
+	for _, result := range report.Feeds {
+		if result.Failed() {
+			errs = append(errs, fmt.Errorf("feed %s: %s", result.FeedLabel, result.Error))
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// ProcessAllCompanyFeeds processes RSS feeds for all companies concurrently
+func (s *ProcessorService) ProcessAllCompanyFeeds(ctx context.Context) (*feed.RunReport, error) {
+	s.logger.Info("Processing RSS feeds for all companies")
+
+	// Get all companies
+	companies, err := s.listAllCompanies(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list companies", "error", err)
+		return nil, fmt.Errorf("failed to list companies: %w", err)
+	}
+
+	var jobs []feedJob
+	for _, comp := range companies {
+		companyFeeds := companyJobs(comp)
+		if len(companyFeeds) == 0 {
+			s.logger.Debug("Skipping company with no enabled feeds", "company", comp.Name)
+			continue
+		}
+		jobs = append(jobs, companyFeeds...)
+	}
+
+	report := s.runJobs(ctx, jobs)
+
+	s.logger.Info("Completed processing all company feeds",
+		"feeds", len(report.Feeds),
+		"failed_feeds", report.FailedFeeds,
+		"items", report.TotalItems,
+		"new", report.TotalNew,
+		"duplicates", report.TotalDuplicates,
+		"merged", report.TotalMerged,
+		"duration", report.Duration,
+		"total_companies", len(companies))
+
+	return report, nil
+}
+
+// ProcessDueFeeds processes only the feeds whose scheduled next fetch time has
+// passed. Feeds without stored state are always due. It returns a nil report
+// when no feed is due.
+func (s *ProcessorService) ProcessDueFeeds(ctx context.Context, now time.Time) (*feed.RunReport, error) {
+	companies, err := s.listAllCompanies(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list companies", "error", err)
+		return nil, fmt.Errorf("failed to list companies: %w", err)
+	}
+
+	states, err := s.stateRepo.List(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list feed fetch states", "error", err)
+		return nil, fmt.Errorf("failed to list feed states: %w", err)
+	}
+
+	stateByURL := make(map[string]*feed.FetchState, len(states))
+	for _, state := range states {
+		stateByURL[state.FeedURL] = state
+	}
+
+	var jobs []feedJob
+	for _, comp := range companies {
+		for _, job := range companyJobs(comp) {
+			if state, ok := stateByURL[job.feed.URL]; ok && !state.IsDue(now) {
+				continue
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	s.logger.Info("Processing due RSS feeds", "feeds", len(jobs))
+
+	return s.runJobs(ctx, jobs), nil
+}
+
+// relevanceInput returns the context articles of the job's feed are scored in
+func (j feedJob) relevanceInput() news.RelevanceInput {
+	return news.RelevanceInput{
+		Industry:    string(j.industry),
+		SourceTrust: j.feed.TrustWeight,
+		Now:         time.Now(),
+	}
+}
+
+// companyJobs builds one job per enabled feed of a company
+func companyJobs(comp *company.CompanyResponse) []feedJob {
+	feeds := comp.EnabledFeeds()
+	jobs := make([]feedJob, 0, len(feeds))
+	for _, companyFeed := range feeds {
+		jobs = append(jobs, feedJob{companyName: comp.Name, industry: comp.Industry, feed: companyFeed})
+	}
+	return jobs
+}
+
+// runJobs processes the jobs on a bounded worker pool and builds the run report.
+// Results keep the order of the jobs regardless of completion order.
+func (s *ProcessorService) runJobs(ctx context.Context, jobs []feedJob) *feed.RunReport {
+	startedAt := time.Now()
+	results := make([]feed.FeedResult, len(jobs))
+	matcher := s.loadMatchers(ctx)
+
+	workers := s.poolConfig.Workers
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = s.runJob(ctx, jobs[i], matcher)
+			}
+		}()
+	}
+
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	s.recordCompanyUpdates(ctx, results)
+
+	return feed.NewRunReport(startedAt, time.Now(), results)
+}
+
+// articleMatchers tag articles with the companies and named entities they mention
+type articleMatchers struct {
+	companies *news.EntityMatcher
+	entities  *news.NamedEntityMatcher
+}
+
+// tag records the companies and named entities mentioned in the article
+func (m articleMatchers) tag(article *news.Article) {
+	article.TagCompanies(m.companies)
+	article.ExtractEntities(m.entities)
+}
+
+// loadMatchers builds the matchers used to tag articles with every known
+// company and with the people, programs, agencies and countries they
+// mention. When companies cannot be listed, articles are tagged with their
+// feed's company only and key people are not extracted.
+func (s *ProcessorService) loadMatchers(ctx context.Context) articleMatchers {
+	gazetteer := news.DefaultGazetteer()
+
+	companies, err := s.listAllCompanies(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to list companies for entity tagging", "error", err)
+		return articleMatchers{entities: news.NewNamedEntityMatcher(gazetteer)}
 	}
 
-	if compResp.FeedURL == "" {
-		s.logger.Warn("Company has no feed URL", "company", companyName)
-		return fmt.Errorf("company %s has no feed URL", companyName)
+	entities := make([]news.CompanyEntity, 0, len(companies))
+	for _, comp := range companies {
+		people := make([]string, 0, len(comp.KeyPeople))
+		for _, person := range comp.KeyPeople {
+			people = append(people, person.FullName)
+			gazetteer = append(gazetteer, news.NamedEntity{Type: news.EntityPerson, Name: person.FullName})
+		}
+		aliases := make([]string, 0, len(comp.Aliases)+len(comp.FormerNames))
+		aliases = append(aliases, comp.Aliases...)
+		aliases = append(aliases, comp.FormerNames...)
+
+		entities = append(entities, news.CompanyEntity{
+			Name:    comp.Name,
+			Ticker:  comp.Ticker,
+			Aliases: aliases,
+			People:  people,
+		})
+	}
+
+	return articleMatchers{
+		companies: news.NewEntityMatcher(entities),
+		entities:  news.NewNamedEntityMatcher(gazetteer),
 	}
+}
 
-	// Fetch RSS feed
-	items, err := s.rssService.FetchFeed(ctx, compResp.FeedURL)
+// listAllCompanies pages through every company
+func (s *ProcessorService) listAllCompanies(ctx context.Context) ([]*company.CompanyResponse, error) {
+	var companies []*company.CompanyResponse
+	for page := 0; page < maxCompanyPages; page++ {
+		batch, err := s.companyService.ListCompanies(ctx, companyPageSize, page*companyPageSize)
+		if err != nil {
+			return nil, err
+		}
+		companies = append(companies, batch...)
+		if len(batch) < companyPageSize {
+			break
+		}
+	}
+	return companies, nil
+}
+
+// runJob waits for the feed's host to be available and processes the feed
+// under its own timeout
+func (s *ProcessorService) runJob(ctx context.Context, job feedJob, matcher articleMatchers) feed.FeedResult {
+	result := feed.FeedResult{
+		CompanyName: job.companyName,
+		FeedLabel:   job.feed.Label,
+		FeedURL:     job.feed.URL,
+		StartedAt:   time.Now(),
+	}
+
+	release, err := s.hostLimiter.acquire(ctx, hostOf(job.feed.URL))
 	if err != nil {
-		s.logger.Error("Failed to fetch RSS feed", "error", err, "company", companyName, "url", compResp.FeedURL)
-		return fmt.Errorf("failed to fetch RSS feed for %s: %w", companyName, err)
+		result.Error = err.Error()
+		result.Duration = time.Since(result.StartedAt)
+		return result
+	}
+	defer release()
+
+	feedCtx, cancel := context.WithTimeout(ctx, s.poolConfig.FeedTimeout)
+	defer cancel()
+
+	result.StartedAt = time.Now()
+	s.processFeed(feedCtx, job, matcher, &result)
+	result.Duration = time.Since(result.StartedAt)
+
+	// Use the parent context so a feed timeout does not prevent recording the run
+	if err := s.feedService.RecordRun(ctx, result); err != nil {
+		s.logger.Warn("Failed to record feed run", "error", err, "url", job.feed.URL)
+	}
+
+	return result
+}
+
+// recordCompanyUpdates stores the feed update time of every company with at
+// least one successfully fetched feed
+func (s *ProcessorService) recordCompanyUpdates(ctx context.Context, results []feed.FeedResult) {
+	updated := make(map[string]bool)
+	for _, result := range results {
+		if result.Failed() || updated[result.CompanyName] {
+			continue
+		}
+		updated[result.CompanyName] = true
+
+		if err := s.companyService.RecordFeedUpdate(ctx, result.CompanyName, time.Now()); err != nil {
+			s.logger.Warn("Failed to record company feed update", "error", err, "company", result.CompanyName)
+		}
+	}
+}
+
+// processFeed fetches a single company feed and stores its new articles
+// tagged with the companies and entities they mention and scored for
+// sentiment and relevance, recording counts and errors in result
+func (s *ProcessorService) processFeed(ctx context.Context, job feedJob, matcher articleMatchers, result *feed.FeedResult) {
+	companyName, companyFeed := job.companyName, job.feed
+	state := s.loadFetchState(ctx, companyFeed, companyName)
+
+	// Fetch RSS feed conditionally using the stored validators
+	fetched, err := s.rssService.FetchFeedConditional(ctx, companyFeed.URL, state)
+	if fetched != nil {
+		result.StatusCode = fetched.StatusCode
+	}
+	if err != nil {
+		now := time.Now()
+		state.RecordFailure(result.StatusCode, err, now)
+		s.schedule.Schedule(state, feed.FetchOutcome{Failed: true, FixedInterval: companyFeed.PollInterval()}, now)
+		s.saveFetchState(ctx, state)
+
+		s.logger.Error("Failed to fetch RSS feed", "error", err, "company", companyName, "feed", companyFeed.Label, "url", companyFeed.URL)
+		result.Error = err.Error()
+		return
+	}
+
+	previousETag, previousLastModified := state.ETag, state.LastModified
+	state.RecordSuccess(fetched.StatusCode, fetched.ETag, fetched.LastModified, len(fetched.Items), time.Now())
+
+	// The schedule depends on how many items are new, so the state is saved
+	// once processing completes, including on early return
+	defer func() {
+		// Items that failed to be stored must be fetched again, which the new
+		// validators would prevent by turning the next poll into a 304
+		if result.Errors > 0 {
+			state.ETag, state.LastModified = previousETag, previousLastModified
+		}
+		s.schedule.Schedule(state, feed.FetchOutcome{
+			NewItems:      result.New,
+			PublishTimes:  publishTimes(fetched.Items),
+			FixedInterval: companyFeed.PollInterval(),
+		}, time.Now())
+		s.saveFetchState(ctx, state)
+	}()
+
+	if fetched.NotModified {
+		s.logger.Info("RSS feed unchanged since last fetch", "company", companyName, "feed", companyFeed.Label)
+		result.NotModified = true
+		return
 	}
 
-	s.logger.Info("Fetched RSS items", "company", companyName, "items", len(items))
+	items := fetched.Items
+	result.Items = len(items)
+	s.logger.Info("Fetched RSS items", "company", companyName, "feed", companyFeed.Label, "items", len(items))
 
 	// Process each item
-	processed := 0
-	skipped := 0
+	var created []*news.Article
 	for _, item := range items {
-		article, err := s.newsService.ProcessRSSFeedItem(ctx, item, companyName, compResp.FeedURL)
+		article, err := s.newsService.ProcessRSSFeedItem(ctx, item, companyName, companyFeed.Label)
 		if err != nil {
+			result.Errors++
 			s.logger.Error("Failed to process RSS item", "error", err, "title", item.Title)
 			continue
 		}
+		matcher.tag(article)
+		s.newsService.ScoreRelevance(article, job.relevanceInput())
 
 		// Create article
 		err = s.newsService.CreateArticle(ctx, article)
 		if err != nil {
-			if err == news.ErrDuplicateArticle {
-				skipped++
+			if errors.Is(err, news.ErrDuplicateArticle) {
+				result.Duplicates++
 				s.logger.Debug("Skipping duplicate article", "title", item.Title, "guid", item.GUID)
 				continue
 			}
+			if errors.Is(err, news.ErrArticleMerged) {
+				result.Merged++
+				s.logger.Debug("Merged near-duplicate article", "title", item.Title, "guid", item.GUID)
+				continue
+			}
+			result.Errors++
 			s.logger.Error("Failed to create article", "error", err, "title", item.Title)
 			continue
 		}
 
-		processed++
+		result.New++
+		created = append(created, article)
 		s.logger.Debug("Created article", "title", article.Title, "id", article.ID.Hex())
 	}
 
-	s.logger.Info("Completed RSS feed processing", 
-		"company", companyName, 
-		"processed", processed, 
-		"skipped", skipped, 
+	s.enrichArticles(ctx, created, matcher, job.relevanceInput())
+	s.extractContracts(ctx, created)
+	s.embedArticles(ctx, created)
+
+	s.logger.Info("Completed RSS feed processing",
+		"company", companyName,
+		"feed", companyFeed.Label,
+		"processed", result.New,
+		"skipped", result.Duplicates,
+		"merged", result.Merged,
+		"errors", result.Errors,
 		"total", len(items))
+}
+
+// enrichArticles fetches the source page of each new article and stores its
+// full text and extraction status, re-tagging companies and entities and
+// re-scoring relevance against the full text. Every new article is then
+// classified and its sentiment analyzed once, on the most complete text
+// available, so duplicates are never sent to the classifier or the sentiment
+// analyzer. Articles left when the context expires keep no extraction
+// status, categories or sentiment.
+func (s *ProcessorService) enrichArticles(ctx context.Context, articles []*news.Article, matcher articleMatchers, relevance news.RelevanceInput) {
+	for i, article := range articles {
+		if ctx.Err() != nil {
+			s.logger.Warn("Stopping article enrichment", "error", ctx.Err(), "remaining", len(articles)-i)
+			return
+		}
+
+		if s.extractor != nil {
+			content, err := s.extractor.Extract(ctx, article.SourceURL)
+			article.ApplyExtraction(content, err, time.Now())
+			if err != nil {
+				s.logger.Debug("Full-text extraction did not succeed", "error", err, "url", article.SourceURL, "status", article.Extraction.Status)
+			} else {
+				matcher.tag(article)
+				s.newsService.ScoreRelevance(article, relevance)
+			}
+		}
 
-	return nil
+		s.newsService.ClassifyArticle(ctx, article)
+		s.newsService.AnalyzeSentiment(ctx, article, matcher.companies)
+
+		if err := s.newsService.UpdateArticleEnrichment(ctx, article); err != nil {
+			s.logger.Warn("Failed to store article enrichment", "error", err, "id", article.ID.Hex())
+		}
+	}
 }
 
-// ProcessAllCompanyFeeds processes RSS feeds for all companies
-func (s *ProcessorService) ProcessAllCompanyFeeds(ctx context.Context) error {
-	s.logger.Info("Processing RSS feeds for all companies")
+// extractContracts stores the contract awards announced in new articles
+// classified as contract news
+func (s *ProcessorService) extractContracts(ctx context.Context, articles []*news.Article) {
+	if s.contractService == nil {
+		return
+	}
 
-	// Get all companies with a reasonable limit
-	companies, err := s.companyService.ListCompanies(ctx, 100, 0)
-	if err != nil {
-		s.logger.Error("Failed to list companies", "error", err)
-		return fmt.Errorf("failed to list companies: %w", err)
+	for _, article := range articles {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := s.contractService.ExtractFromArticle(ctx, article); err != nil {
+			s.logger.Warn("Failed to extract contract awards", "error", err, "id", article.ID.Hex())
+		}
 	}
+}
 
-	totalProcessed := 0
-	totalErrors := 0
+// embedArticles indexes the chunks of new articles, after extraction so
+// that the full text is embedded
+func (s *ProcessorService) embedArticles(ctx context.Context, articles []*news.Article) {
+	if s.embeddings == nil {
+		return
+	}
 
-	for _, comp := range companies {
-		if comp.FeedURL == "" {
-			s.logger.Debug("Skipping company with no feed URL", "company", comp.Name)
-			continue
+	for _, article := range articles {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := s.embeddings.IndexArticle(ctx, article); err != nil {
+			s.logger.Warn("Failed to embed article", "error", err, "id", article.ID.Hex())
 		}
+	}
+}
 
-		err := s.ProcessCompanyFeed(ctx, comp.Name)
-		if err != nil {
-			totalErrors++
-			s.logger.Error("Failed to process company feed", "error", err, "company", comp.Name)
-			continue
+// publishTimes returns the publication times of the fetched items
+func publishTimes(items []*news.RSSFeedItem) []time.Time {
+	times := make([]time.Time, 0, len(items))
+	for _, item := range items {
+		times = append(times, item.PublishDate)
+	}
+	return times
+}
+
+// loadFetchState returns the stored fetch state for a feed, or a fresh one
+func (s *ProcessorService) loadFetchState(ctx context.Context, companyFeed company.Feed, companyName string) *feed.FetchState {
+	state, err := s.stateRepo.GetByURL(ctx, companyFeed.URL)
+	if err != nil {
+		if !errors.Is(err, feed.ErrFetchStateNotFound) {
+			s.logger.Warn("Failed to load feed fetch state", "error", err, "url", companyFeed.URL)
 		}
-		totalProcessed++
+		state = &feed.FetchState{FeedURL: companyFeed.URL}
 	}
+	state.CompanyName = companyName
+	state.FeedLabel = companyFeed.Label
+	return state
+}
 
-	s.logger.Info("Completed processing all company feeds", 
-		"processed", totalProcessed, 
-		"errors", totalErrors, 
-		"total_companies", len(companies))
+// saveFetchState persists the fetch state, logging instead of failing the run.
+// The state is saved even when the feed's own context has timed out.
+func (s *ProcessorService) saveFetchState(ctx context.Context, state *feed.FetchState) {
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
 
-	return nil
-}
\ No newline at end of file
+	if err := s.stateRepo.Upsert(saveCtx, state); err != nil {
+		s.logger.Warn("Failed to save feed fetch state", "error", err, "url", state.FeedURL)
+	}
+}
@@ -4,50 +4,115 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
 
+	"github.com/Neph-dev/october_backend/internal/domain/feed"
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 	"github.com/mmcdole/gofeed"
 )
 
 // RSSService handles RSS feed operations
 type RSSService struct {
-	parser *gofeed.Parser
-	logger *slog.Logger
+	parser     *gofeed.Parser
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// FetchResult represents the outcome of a conditional feed fetch
+type FetchResult struct {
+	Items        []*news.RSSFeedItem
+	StatusCode   int
+	ETag         string
+	LastModified string
+	NotModified  bool
 }
 
 // NewRSSService creates a new RSS service
 func NewRSSService(logger *slog.Logger) *RSSService {
 	return &RSSService{
 		parser: gofeed.NewParser(),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
 		logger: logger,
 	}
 }
 
 // FetchFeed fetches and parses an RSS feed from the given URL
 func (s *RSSService) FetchFeed(ctx context.Context, feedURL string) ([]*news.RSSFeedItem, error) {
+	result, err := s.FetchFeedConditional(ctx, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// FetchFeedConditional fetches a feed using the validators stored in state.
+// When the server answers 304 Not Modified the body is not parsed and the
+// result carries no items.
+func (s *RSSService) FetchFeedConditional(ctx context.Context, feedURL string, state *feed.FetchState) (*FetchResult, error) {
 	s.logger.Info("Fetching RSS feed", "url", feedURL)
 
-	feed, err := s.parser.ParseURLWithContext(feedURL, ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", feedURL, err)
+	}
+
+	req.Header.Set("User-Agent", "October-Backend/1.0")
+	if state != nil {
+		if state.ETag != "" {
+			req.Header.Set("If-None-Match", state.ETag)
+		}
+		if state.LastModified != "" {
+			req.Header.Set("If-Modified-Since", state.LastModified)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		s.logger.Error("Failed to fetch RSS feed", "error", err, "url", feedURL)
+		return nil, fmt.Errorf("failed to fetch RSS feed from %s: %w", feedURL, err)
+	}
+	defer resp.Body.Close()
+
+	result := &FetchResult{
+		StatusCode:   resp.StatusCode,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		s.logger.Info("RSS feed not modified", "url", feedURL)
+		result.NotModified = true
+		return result, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		s.logger.Error("RSS feed returned unexpected status", "status", resp.StatusCode, "url", feedURL)
+		return result, fmt.Errorf("RSS feed %s returned status %d", feedURL, resp.StatusCode)
+	}
+
+	parsed, err := s.parser.Parse(resp.Body)
 	if err != nil {
 		s.logger.Error("Failed to parse RSS feed", "error", err, "url", feedURL)
-		return nil, fmt.Errorf("failed to parse RSS feed from %s: %w", feedURL, err)
+		return result, fmt.Errorf("failed to parse RSS feed from %s: %w", feedURL, err)
 	}
 
 	s.logger.Info("Successfully parsed RSS feed", 
 		"url", feedURL, 
-		"title", feed.Title, 
-		"items", len(feed.Items))
+		"title", parsed.Title, 
+		"items", len(parsed.Items))
 
-	items := make([]*news.RSSFeedItem, 0, len(feed.Items))
-	for _, item := range feed.Items {
+	result.Items = make([]*news.RSSFeedItem, 0, len(parsed.Items))
+	for _, item := range parsed.Items {
 		rssItem := s.convertToRSSFeedItem(item)
 		if rssItem != nil {
-			items = append(items, rssItem)
+			result.Items = append(result.Items, rssItem)
 		}
 	}
 
-	return items, nil
+	return result, nil
 }
 
 // convertToRSSFeedItem converts a gofeed.Item to our RSSFeedItem
@@ -66,32 +131,23 @@ func (s *RSSService) convertToRSSFeedItem(item *gofeed.Item) *news.RSSFeedItem {
 		publishDate = time.Now()
 	}
 
-	// Extract content
-	content := ""
-	if item.Content != "" {
-		content = item.Content
-	} else if item.Description != "" {
-		content = item.Description
-	}
-
 	// Use GUID or link as unique identifier
 	guid := item.GUID
 	if guid == "" {
 		guid = item.Link
 	}
 
-	// Extract summary (prefer description over content for summary)
-	summary := item.Description
-	if summary == "" && len(content) > 200 {
-		summary = content[:200] + "..."
-	}
+	// Sanitise HTML and derive plain-text title, summary and content
+	normalized := normalizeItem(item)
 
 	return &news.RSSFeedItem{
-		Title:       item.Title,
-		Summary:     summary,
+		Title:       normalized.title,
+		Summary:     normalized.summary,
 		Link:        item.Link,
 		PublishDate: publishDate,
 		GUID:        guid,
-		Content:     content,
+		Content:     normalized.content,
+		ContentHTML: normalized.contentHTML,
+		Media:       normalized.media,
 	}
 }
\ No newline at end of file
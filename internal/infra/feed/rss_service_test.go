@@ -0,0 +1,98 @@
+package feed
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/internal/domain/feed"
+)
+
+const testFeedXML = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+<channel>
+	<title>Test Feed</title>
+	<item>
+		<title>RTX wins radar contract</title>
+		<link>https://example.com/news/1</link>
+		<guid>news-1</guid>
+		<description>RTX was awarded a contract.</description>
+	</item>
+</channel>
+</rss>`
+
+func newTestRSSService() *RSSService {
+	return NewRSSService(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestFetchFeedConditional(t *testing.T) {
+	const etag = `"v1"`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Write([]byte(testFeedXML))
+	}))
+	defer server.Close()
+
+	service := newTestRSSService()
+	ctx := context.Background()
+
+	first, err := service.FetchFeedConditional(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("FetchFeedConditional() failed: %v", err)
+	}
+	if first.NotModified {
+		t.Fatal("Expected first fetch to be modified")
+	}
+	if len(first.Items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(first.Items))
+	}
+	if first.ETag != etag {
+		t.Errorf("Expected ETag %s, got %s", etag, first.ETag)
+	}
+
+	state := &feed.FetchState{FeedURL: server.URL}
+	state.RecordSuccess(first.StatusCode, first.ETag, first.LastModified, len(first.Items), first.Items[0].PublishDate)
+
+	second, err := service.FetchFeedConditional(ctx, server.URL, state)
+	if err != nil {
+		t.Fatalf("FetchFeedConditional() failed: %v", err)
+	}
+	if !second.NotModified {
+		t.Error("Expected second fetch to be not modified")
+	}
+	if len(second.Items) != 0 {
+		t.Errorf("Expected no items on 304, got %d", len(second.Items))
+	}
+
+	state.RecordSuccess(second.StatusCode, second.ETag, second.LastModified, len(second.Items), first.Items[0].PublishDate)
+	if state.LastItemCount != 1 {
+		t.Errorf("Expected 304 to keep last item count 1, got %d", state.LastItemCount)
+	}
+	if state.ETag != etag {
+		t.Errorf("Expected 304 to keep ETag %s, got %s", etag, state.ETag)
+	}
+}
+
+func TestFetchFeedConditionalErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	result, err := newTestRSSService().FetchFeedConditional(context.Background(), server.URL, nil)
+	if err == nil {
+		t.Fatal("Expected error for 503 response")
+	}
+	if result == nil || result.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected result with status 503, got %+v", result)
+	}
+}
@@ -0,0 +1,81 @@
+package feed
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// DefaultSchedulerTick is how often the scheduler looks for due feeds
+	DefaultSchedulerTick = time.Minute
+
+	// schedulerRunTimeout bounds a single pass over the due feeds
+	schedulerRunTimeout = 30 * time.Minute
+)
+
+// Scheduler periodically processes the feeds that are due. Next-due times are
+// stored with each feed's fetch state, so a restart resumes the existing
+// schedule instead of refetching every feed.
+type Scheduler struct {
+	processor *ProcessorService
+	tick      time.Duration
+	logger    *slog.Logger
+}
+
+// NewScheduler creates a new feed scheduler
+func NewScheduler(processor *ProcessorService, tick time.Duration, logger *slog.Logger) *Scheduler {
+	if tick <= 0 {
+		tick = DefaultSchedulerTick
+	}
+
+	return &Scheduler{
+		processor: processor,
+		tick:      tick,
+		logger:    logger,
+	}
+}
+
+// Run processes due feeds immediately and then on every tick until ctx is
+// cancelled. Passes never overlap: ticks missed during a long pass are dropped.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.logger.Info("Starting RSS feed scheduler", "tick", s.tick)
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	s.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("RSS feed scheduler stopped")
+			return
+		case <-ticker.C:
+			s.runOnce(ctx)
+		}
+	}
+}
+
+// runOnce processes the feeds that are due now
+func (s *Scheduler) runOnce(ctx context.Context) {
+	runCtx, cancel := context.WithTimeout(ctx, schedulerRunTimeout)
+	defer cancel()
+
+	report, err := s.processor.ProcessDueFeeds(runCtx, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to process due RSS feeds", "error", err)
+		return
+	}
+	if report == nil {
+		s.logger.Debug("No RSS feeds due")
+		return
+	}
+
+	s.logger.Info("Completed scheduled RSS feed processing",
+		"feeds", len(report.Feeds),
+		"failed_feeds", report.FailedFeeds,
+		"new", report.TotalNew,
+		"duration", report.Duration,
+	)
+}
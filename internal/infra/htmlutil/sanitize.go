@@ -0,0 +1,208 @@
+package htmlutil
+
+import (
+	"bytes"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedElements are kept by Sanitize with the listed attributes.
+// Other elements are unwrapped: their children are kept.
+var allowedElements = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil, atom.Div: nil, atom.Span: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.U: nil,
+	atom.Sub: nil, atom.Sup: nil, atom.Small: nil,
+	atom.Ul: nil, atom.Ol: nil, atom.Li: nil, atom.Dl: nil, atom.Dt: nil, atom.Dd: nil,
+	atom.Blockquote: nil, atom.Pre: nil, atom.Code: nil, atom.Q: nil, atom.Cite: nil,
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tr: nil, atom.Th: nil, atom.Td: nil,
+	atom.Figure: nil, atom.Figcaption: nil,
+	atom.A:   {"href", "title"},
+	atom.Img: {"src", "alt", "title", "width", "height"},
+}
+
+// droppedElements are removed together with their content
+var droppedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Frame: true, atom.Frameset: true, atom.Object: true,
+	atom.Embed: true, atom.Applet: true, atom.Svg: true, atom.Math: true,
+	atom.Form: true, atom.Input: true, atom.Button: true, atom.Select: true,
+	atom.Textarea: true, atom.Link: true, atom.Meta: true, atom.Base: true,
+	atom.Head: true, atom.Title: true,
+}
+
+// trackingHosts serve tracking pixels and feed analytics beacons
+var trackingHosts = []string{
+	"feeds.feedburner.com", "feedproxy.google.com", "pixel.wp.com", "stats.wordpress.com",
+	"doubleclick.net", "google-analytics.com", "googletagmanager.com", "facebook.com/tr",
+	"pixel.quantserve.com", "sb.scorecardresearch.com", "feedsportal.com",
+}
+
+// Image is an image referenced by an HTML fragment
+type Image struct {
+	URL    string
+	Alt    string
+	Width  int
+	Height int
+}
+
+// Sanitize returns a safe version of an HTML fragment. Only an allowlist of
+// formatting elements and attributes is kept, links and images are limited
+// to http(s) URLs, and tracking pixels are removed. The images kept are
+// returned alongside.
+func Sanitize(fragment string) (string, []Image) {
+	root, err := ParseFragment(fragment)
+	if err != nil {
+		return html.EscapeString(PlainText(fragment)), nil
+	}
+
+	var images []Image
+	var remove, unwrap []*html.Node
+
+	Walk(root, func(n *html.Node) bool {
+		if n == root {
+			return true
+		}
+
+		switch n.Type {
+		case html.TextNode:
+			return false
+		case html.ElementNode:
+		default:
+			remove = append(remove, n)
+			return false
+		}
+
+		if droppedElements[n.DataAtom] {
+			remove = append(remove, n)
+			return false
+		}
+
+		attrs, allowed := allowedElements[n.DataAtom]
+		if !allowed {
+			unwrap = append(unwrap, n)
+			return true
+		}
+		n.Attr = filterAttrs(n.Attr, attrs)
+
+		switch n.DataAtom {
+		case atom.A:
+			if href := safeURL(Attr(n, "href")); href != "" {
+				setAttr(n, "href", href)
+				n.Attr = append(n.Attr, html.Attribute{Key: "rel", Val: "nofollow noopener noreferrer"})
+			} else {
+				unwrap = append(unwrap, n)
+			}
+		case atom.Img:
+			image, ok := imageOf(n)
+			if !ok {
+				remove = append(remove, n)
+				return false
+			}
+			setAttr(n, "src", image.URL)
+			images = append(images, image)
+		}
+		return true
+	}, nil)
+
+	for _, n := range remove {
+		n.Parent.RemoveChild(n)
+	}
+	for _, n := range unwrap {
+		unwrapNode(n)
+	}
+
+	var buf bytes.Buffer
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		if err := html.Render(&buf, child); err != nil {
+			return html.EscapeString(PlainText(fragment)), nil
+		}
+	}
+
+	return strings.TrimSpace(buf.String()), images
+}
+
+// IsTrackingURL reports whether a URL points at a known tracking service
+func IsTrackingURL(rawURL string) bool {
+	lower := strings.ToLower(rawURL)
+	for _, host := range trackingHosts {
+		if strings.Contains(lower, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// imageOf converts an <img> element into an Image, rejecting unsafe
+// sources and tracking pixels
+func imageOf(n *html.Node) (Image, bool) {
+	src := safeURL(Attr(n, "src"))
+	if src == "" || IsTrackingURL(src) {
+		return Image{}, false
+	}
+
+	width, _ := strconv.Atoi(strings.TrimSuffix(Attr(n, "width"), "px"))
+	height, _ := strconv.Atoi(strings.TrimSuffix(Attr(n, "height"), "px"))
+
+	// 1x1 (or smaller) images are tracking pixels
+	if (Attr(n, "width") != "" && width <= 1) || (Attr(n, "height") != "" && height <= 1) {
+		return Image{}, false
+	}
+
+	return Image{URL: src, Alt: CollapseWhitespace(Attr(n, "alt")), Width: width, Height: height}, true
+}
+
+// safeURL returns the URL when it is an absolute http(s) URL, else an empty string
+func safeURL(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return ""
+	}
+	return parsed.String()
+}
+
+// filterAttrs keeps only the allowed attributes
+func filterAttrs(attrs []html.Attribute, allowed []string) []html.Attribute {
+	var kept []html.Attribute
+	for _, attr := range attrs {
+		for _, key := range allowed {
+			if attr.Namespace == "" && attr.Key == key {
+				kept = append(kept, attr)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// setAttr replaces the value of an existing attribute
+func setAttr(n *html.Node, key, value string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr[i].Val = value
+			return
+		}
+	}
+}
+
+// unwrapNode replaces an element with its children
+func unwrapNode(n *html.Node) {
+	parent := n.Parent
+	if parent == nil {
+		return
+	}
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		n.RemoveChild(child)
+		parent.InsertBefore(child, n)
+		child = next
+	}
+	parent.RemoveChild(n)
+}
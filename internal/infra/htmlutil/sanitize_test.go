@@ -0,0 +1,60 @@
+package htmlutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	input := `<div class="feed" onclick="steal()">
+<p style="color:red">RTX <strong>wins</strong> &amp; <a href="https://rtx.com/news" onmouseover="x()">expands</a>.</p>
+<script>document.cookie</script>
+<iframe src="https://ads.example.com"></iframe>
+<a href="javascript:alert(1)">bad link</a>
+<img src="https://rtx.com/radar.jpg" alt="LTAMDS radar" width="640" height="480">
+<img src="https://feeds.feedburner.com/~r/rtx/~4/abc" width="1" height="1">
+<img src="https://example.com/pixel.gif" width="1" height="1">
+<custom-tag>kept text</custom-tag>
+</div>`
+
+	sanitized, images := Sanitize(input)
+
+	for _, unwanted := range []string{"onclick", "style=", "script", "document.cookie", "iframe", "javascript:", "feedburner", "pixel.gif", "custom-tag", "class="} {
+		if strings.Contains(sanitized, unwanted) {
+			t.Errorf("Expected %q to be removed, got:\n%s", unwanted, sanitized)
+		}
+	}
+
+	for _, wanted := range []string{
+		"<strong>wins</strong>",
+		"&amp;",
+		`<a href="https://rtx.com/news" rel="nofollow noopener noreferrer">expands</a>`,
+		"bad link",
+		`<img src="https://rtx.com/radar.jpg" alt="LTAMDS radar" width="640" height="480"/>`,
+		"kept text",
+	} {
+		if !strings.Contains(sanitized, wanted) {
+			t.Errorf("Expected %q in sanitized HTML, got:\n%s", wanted, sanitized)
+		}
+	}
+
+	if len(images) != 1 {
+		t.Fatalf("Expected 1 image, got %d: %+v", len(images), images)
+	}
+	if images[0].URL != "https://rtx.com/radar.jpg" || images[0].Alt != "LTAMDS radar" || images[0].Width != 640 {
+		t.Errorf("Unexpected image: %+v", images[0])
+	}
+}
+
+func TestSanitizePlainText(t *testing.T) {
+	sanitized, images := Sanitize("Plain <teaser> text & more")
+	if strings.Contains(sanitized, "<teaser>") {
+		t.Errorf("Expected unknown tag to be removed, got %q", sanitized)
+	}
+	if !strings.Contains(sanitized, "&amp;") {
+		t.Errorf("Expected ampersand to be escaped, got %q", sanitized)
+	}
+	if len(images) != 0 {
+		t.Errorf("Expected no images, got %d", len(images))
+	}
+}
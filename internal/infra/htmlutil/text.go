@@ -0,0 +1,176 @@
+package htmlutil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Ellipsis marks text cut by Truncate
+const Ellipsis = "..."
+
+// blockElements start a new paragraph in rendered text
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Blockquote: true, atom.Pre: true,
+	atom.Table: true, atom.Tr: true, atom.Br: true, atom.Hr: true, atom.Figcaption: true,
+	atom.Dl: true, atom.Dt: true, atom.Dd: true,
+}
+
+// invisibleElements hold no readable text
+var invisibleElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Svg: true, atom.Object: true, atom.Embed: true,
+}
+
+// BlockText renders an element as paragraphs separated by blank lines.
+// Text inside scripts, styles and other invisible elements is skipped.
+func BlockText(root *html.Node) string {
+	var paragraphs []string
+	var current strings.Builder
+
+	flush := func() {
+		if text := CollapseWhitespace(current.String()); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+		current.Reset()
+	}
+
+	// Block elements end the current paragraph; inline elements keep the
+	// document's own whitespace, as a browser would render it
+	boundary := func(n *html.Node) {
+		if n.Type == html.ElementNode && blockElements[n.DataAtom] {
+			flush()
+		}
+	}
+
+	Walk(root, func(n *html.Node) bool {
+		switch n.Type {
+		case html.TextNode:
+			current.WriteString(n.Data)
+		case html.ElementNode:
+			if invisibleElements[n.DataAtom] {
+				return false
+			}
+		case html.CommentNode:
+			return false
+		}
+		boundary(n)
+		return true
+	}, boundary)
+	flush()
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// PlainText converts an HTML fragment to plain text: tags are removed,
+// entities decoded and whitespace collapsed, keeping paragraph breaks
+func PlainText(fragment string) string {
+	if !strings.ContainsAny(fragment, "<&") {
+		return collapseParagraphs(fragment)
+	}
+
+	root, err := ParseFragment(fragment)
+	if err != nil {
+		return CollapseWhitespace(html.UnescapeString(fragment))
+	}
+
+	return BlockText(root)
+}
+
+// ParseFragment parses an HTML fragment in the context of a <body> element
+// and returns a <div> holding the parsed nodes
+func ParseFragment(fragment string) (*html.Node, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), body)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	for _, n := range nodes {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+		root.AppendChild(n)
+	}
+	return root, nil
+}
+
+// CollapseWhitespace trims and collapses runs of whitespace into single spaces
+func CollapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// collapseParagraphs collapses whitespace within paragraphs and keeps a
+// single blank line between them
+func collapseParagraphs(s string) string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(s, "\n\n") {
+		if text := CollapseWhitespace(paragraph); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// Truncate shortens text to at most maxRunes runes (plus an ellipsis).
+// It cuts at the last sentence end when one falls in the second half of the
+// allowed length, otherwise at the last word boundary, and never splits a rune.
+func Truncate(text string, maxRunes int) string {
+	text = CollapseWhitespace(text)
+	if maxRunes <= 0 || utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+
+	// Byte offset of the first rune past the limit
+	cut := len(text)
+	count := 0
+	for i := range text {
+		if count == maxRunes {
+			cut = i
+			break
+		}
+		count++
+	}
+	head := text[:cut]
+
+	if end := lastSentenceEnd(head); end > 0 && utf8.RuneCountInString(head[:end]) >= maxRunes/2 {
+		return head[:end]
+	}
+
+	// Drop the partial last word unless the cut falls on a word boundary
+	next, _ := utf8.DecodeRuneInString(text[cut:])
+	if !unicode.IsSpace(next) {
+		if space := strings.LastIndexFunc(head, unicode.IsSpace); space > 0 {
+			head = head[:space]
+		}
+	}
+	return strings.TrimRightFunc(head, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + Ellipsis
+}
+
+// lastSentenceEnd returns the byte offset just past the last sentence
+// terminator followed by whitespace, or -1 when there is none
+func lastSentenceEnd(s string) int {
+	end := -1
+	for i, r := range s {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		next := i + utf8.RuneLen(r)
+		if next < len(s) {
+			following, _ := utf8.DecodeRuneInString(s[next:])
+			if !unicode.IsSpace(following) {
+				continue
+			}
+		}
+		end = next
+	}
+	return end
+}
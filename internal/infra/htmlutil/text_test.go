@@ -0,0 +1,66 @@
+package htmlutil
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "  RTX   wins\ncontract ", "RTX wins contract"},
+		{"entities", "Raytheon &amp; Lockheed&#8217;s &quot;joint&quot; bid&nbsp;won", "Raytheon & Lockheed’s \"joint\" bid won"},
+		{"tags removed", "<p>First <b>bold</b> paragraph.</p><p>Second<br>line</p>", "First bold paragraph.\n\nSecond\n\nline"},
+		{"scripts dropped", "<script>alert(1)</script><style>p{}</style>Visible", "Visible"},
+		{"inline siblings", "Awarded <a href=\"#\">$1.2B</a> contract", "Awarded $1.2B contract"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(tt.input); got != tt.expected {
+				t.Errorf("PlainText() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		max      int
+		expected string
+	}{
+		{"short text unchanged", "Short text.", 50, "Short text."},
+		{"sentence boundary", "RTX won a contract. The Army will deploy the radar in 2026 across bases.", 36, "RTX won a contract."},
+		{"word boundary", "Lockheed Martin delivered the first aircraft to the customer", 30, "Lockheed Martin delivered the..."},
+		{"early sentence ignored", "Yes. Lockheed Martin delivered the first aircraft to the customer", 40, "Yes. Lockheed Martin delivered the first..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.input, tt.max); got != tt.expected {
+				t.Errorf("Truncate() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateMultiByte(t *testing.T) {
+	input := strings.Repeat("Überschall—Flugzeug ", 30)
+
+	for max := 1; max < 60; max++ {
+		got := Truncate(input, max)
+		if !utf8.ValidString(got) {
+			t.Fatalf("Truncate(%d) produced invalid UTF-8: %q", max, got)
+		}
+		if n := utf8.RuneCountInString(strings.TrimSuffix(got, Ellipsis)); n > max {
+			t.Fatalf("Truncate(%d) kept %d runes", max, n)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package htmlutil provides HTML traversal, sanitisation and plain-text
+// rendering shared by feed normalisation and full-text extraction.
+package htmlutil
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// MaxNodes bounds every traversal of a document
+const MaxNodes = 200000
+
+// Walk visits the nodes below root in document order without recursion.
+// enter is called before a node's children and returns whether to descend;
+// leave, when set, is called after them. At most MaxNodes nodes are visited.
+func Walk(root *html.Node, enter func(*html.Node) bool, leave func(*html.Node)) {
+	if root == nil {
+		return
+	}
+
+	n := root
+	for visited := 0; visited < MaxNodes; visited++ {
+		if enter(n) && n.FirstChild != nil {
+			n = n.FirstChild
+			continue
+		}
+
+		// Climb until a node with an unvisited sibling is found
+		for {
+			if leave != nil {
+				leave(n)
+			}
+			if n == root {
+				return
+			}
+			if n.NextSibling != nil {
+				n = n.NextSibling
+				break
+			}
+			n = n.Parent
+		}
+	}
+}
+
+// FindFirst returns the first element of the given type in document order
+func FindFirst(root *html.Node, a atom.Atom) *html.Node {
+	var found *html.Node
+	Walk(root, func(n *html.Node) bool {
+		if found != nil {
+			return false
+		}
+		if n.Type == html.ElementNode && n.DataAtom == a {
+			found = n
+			return false
+		}
+		return true
+	}, nil)
+	return found
+}
+
+// TextOf concatenates all text below a node, separating text nodes with spaces
+func TextOf(n *html.Node) string {
+	var b strings.Builder
+	Walk(n, func(node *html.Node) bool {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+			b.WriteByte(' ')
+		}
+		return true
+	}, nil)
+	return b.String()
+}
+
+// Attr returns the value of an attribute, or an empty string
+func Attr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/Neph-dev/october_backend/internal/domain/contract"
+
+// ContractListResponse represents the API response for a contract award list
+type ContractListResponse struct {
+	Contracts []*contract.ContractAward `json:"contracts"`
+	Total     int64                     `json:"total"`
+	Limit     int                       `json:"limit"`
+	Offset    int                       `json:"offset"`
+}
+
+// QuarterlyTotalsResponse represents the API response for contract award totals
+type QuarterlyTotalsResponse struct {
+	Totals []*contract.QuarterlyTotal `json:"totals"`
+	Count  int                        `json:"count"`
+}
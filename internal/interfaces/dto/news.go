@@ -10,15 +10,33 @@ import (
 
 // ArticleResponse represents the API response for an article
 type ArticleResponse struct {
-	ID             string    `json:"id"`
-	Title          string    `json:"title"`
-	Summary        string    `json:"summary"`
-	SourceURL      string    `json:"source_url"`
-	Companies      []string  `json:"companies"`
-	PublishedDate  time.Time `json:"published_date"`
-	RelevanceScore float64   `json:"relevance_score"`
-	ProcessedDate  time.Time `json:"processed_date"`
-	FeedSource     string    `json:"feed_source"`
+	ID             string       `json:"id"`
+	Title          string       `json:"title"`
+	Summary        string       `json:"summary"`
+	SourceURL      string       `json:"source_url"`
+	Companies      []string     `json:"companies"`
+	PublishedDate  time.Time    `json:"published_date"`
+	RelevanceScore float64      `json:"relevance_score"`
+	ProcessedDate  time.Time    `json:"processed_date"`
+	FeedSource     string       `json:"feed_source"`
+	Media          []news.Media `json:"media,omitempty"`
+	// Sources lists every feed item merged into this article
+	Sources []news.ArticleSource `json:"sources,omitempty"`
+	// Mentions counts where each tagged company appears in the article
+	Mentions []news.CompanyMention `json:"mentions,omitempty"`
+	// Relevance breaks RelevanceScore down by factor
+	Relevance *news.Relevance `json:"relevance,omitempty"`
+	// Categories are the taxonomy topics the article covers
+	Categories []news.Category `json:"categories,omitempty"`
+	// Sentiment is the tone overall and towards each mentioned company
+	Sentiment *news.Sentiment `json:"sentiment,omitempty"`
+	// Entities are the people, programs, agencies and countries mentioned
+	Entities []news.EntityMention `json:"entities,omitempty"`
+	// ExtractionStatus is the outcome of full-text extraction, empty when not attempted
+	ExtractionStatus string `json:"extraction_status,omitempty"`
+	// SearchScore and Highlights are set on full-text search results
+	SearchScore float64          `json:"search_score,omitempty"`
+	Highlights  []news.Highlight `json:"highlights,omitempty"`
 }
 
 // NewsListResponse represents the API response for news list
@@ -27,6 +45,34 @@ type NewsListResponse struct {
 	Total    int64              `json:"total"`
 	Limit    int                `json:"limit"`
 	Offset   int                `json:"offset"`
+	// NextCursor fetches the following page; omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewsSearchRequest is a news query, sent as the JSON body of
+// POST /news/search or built from the query parameters of GET /news and
+// GET /news/search. Query filters the articles, unless Sort is "match",
+// which ranks them by full-text match with Query instead.
+type NewsSearchRequest struct {
+	Companies         []string `json:"companies,omitempty"`
+	CompanyMatch      string   `json:"company_match,omitempty"` // "any" (default) or "all"
+	ExcludeCompanies  []string `json:"exclude_companies,omitempty"`
+	FeedSources       []string `json:"feed_sources,omitempty"`
+	Categories        []string `json:"categories,omitempty"`
+	ExcludeCategories []string `json:"exclude_categories,omitempty"`
+	Entity            string   `json:"entity,omitempty"`
+	Query             string   `json:"query,omitempty"`
+	StartDate         string   `json:"start_date,omitempty"` // YYYY-MM-DD
+	EndDate           string   `json:"end_date,omitempty"`   // YYYY-MM-DD, inclusive
+	MinRelevance      *float64 `json:"min_relevance,omitempty"`
+	Sentiment         string   `json:"sentiment,omitempty"`
+	MinSentiment      *float64 `json:"min_sentiment,omitempty"`
+	MaxSentiment      *float64 `json:"max_sentiment,omitempty"`
+	Sort              string   `json:"sort,omitempty"`  // "date" (default), "relevance" or "match"
+	Order             string   `json:"order,omitempty"` // "desc" (default) or "asc"
+	Cursor            string   `json:"cursor,omitempty"`
+	Limit             int      `json:"limit,omitempty"`
+	Offset            int      `json:"offset,omitempty"`
 }
 
 // ErrorResponse represents an error response
@@ -37,7 +83,7 @@ type ErrorResponse struct {
 
 // ToArticleResponse converts a domain Article to ArticleResponse
 func ToArticleResponse(article *news.Article) *ArticleResponse {
-	return &ArticleResponse{
+	response := &ArticleResponse{
 		ID:             article.ID.Hex(),
 		Title:          article.Title,
 		Summary:        article.Summary,
@@ -47,7 +93,27 @@ func ToArticleResponse(article *news.Article) *ArticleResponse {
 		RelevanceScore: article.RelevanceScore,
 		ProcessedDate:  article.ProcessedDate,
 		FeedSource:     article.FeedSource,
+		Media:          article.Media,
+		Sources:        article.Sources,
+		Mentions:       article.Mentions,
+		Relevance:      article.Relevance,
+		Categories:     article.Categories,
+		Entities:       article.Entities,
+		Sentiment:      article.Sentiment,
+	}
+	if article.Extraction != nil {
+		response.ExtractionStatus = string(article.Extraction.Status)
 	}
+	return response
+}
+
+// ToSearchResultResponse converts a search result to an ArticleResponse
+// carrying its score and highlights
+func ToSearchResultResponse(result *news.SearchResult) *ArticleResponse {
+	response := ToArticleResponse(result.Article)
+	response.SearchScore = result.Score
+	response.Highlights = result.Highlights
+	return response
 }
 
 // WriteJSONResponse writes a JSON response
@@ -64,4 +130,4 @@ func WriteErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 		Message: message,
 	}
 	WriteJSONResponse(w, statusCode, response)
-}
\ No newline at end of file
+}
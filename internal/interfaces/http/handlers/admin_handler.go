@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/domain/feed"
+	"github.com/Neph-dev/october_backend/internal/interfaces/dto"
+	"github.com/gorilla/mux"
+)
+
+// manualRefreshTimeout bounds a manually triggered feed refresh
+const manualRefreshTimeout = 10 * time.Minute
+
+// FeedRefresher processes the feeds of a single company on demand
+type FeedRefresher interface {
+	ProcessCompanyFeed(ctx context.Context, companyName string) (*feed.RunReport, error)
+}
+
+// AdminHandler handles HTTP requests for feed ingestion administration
+type AdminHandler struct {
+	feedService    *feed.Service
+	companyService company.Service
+	refresher      FeedRefresher
+	logger         *slog.Logger
+
+	// refreshing holds the companies whose manual refresh is running
+	mu         sync.Mutex
+	refreshing map[string]bool
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(feedService *feed.Service, companyService company.Service, refresher FeedRefresher, logger *slog.Logger) *AdminHandler {
+	return &AdminHandler{
+		feedService:    feedService,
+		companyService: companyService,
+		refresher:      refresher,
+		logger:         logger,
+		refreshing:     make(map[string]bool),
+	}
+}
+
+// ListFeedRuns handles GET /admin/feeds/runs requests
+func (h *AdminHandler) ListFeedRuns(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := &feed.RunFilter{
+		CompanyName: query.Get("company"),
+		FeedURL:     query.Get("feed_url"),
+		FailedOnly:  query.Get("failed") == "true",
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid limit: "+err.Error())
+			return
+		}
+		filter.Limit = limit
+	}
+
+	runs, err := h.feedService.ListRecentRuns(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, feed.ErrInvalidRunFilter) {
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters")
+			return
+		}
+		h.logger.Error("Failed to list feed runs", "error", err)
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to retrieve feed runs")
+		return
+	}
+
+	dto.WriteJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"runs":  runs,
+		"count": len(runs),
+	})
+}
+
+// GetFeedHealth handles GET /admin/feeds/health requests
+func (h *AdminHandler) GetFeedHealth(w http.ResponseWriter, r *http.Request) {
+	health, err := h.feedService.GetHealth(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get feed health", "error", err)
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to retrieve feed health")
+		return
+	}
+
+	unhealthy := 0
+	for _, feedHealth := range health {
+		if !feedHealth.Healthy {
+			unhealthy++
+		}
+	}
+
+	dto.WriteJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"feeds":     health,
+		"total":     len(health),
+		"unhealthy": unhealthy,
+		"timestamp": time.Now().UTC(),
+	})
+}
+
+// RefreshCompanyFeeds handles POST /admin/feeds/{company}/refresh requests.
+// The refresh runs in the background; its outcome is recorded in the feed run history.
+// A company has at most one manual refresh running; further requests get 409.
+func (h *AdminHandler) RefreshCompanyFeeds(w http.ResponseWriter, r *http.Request) {
+	companyName := strings.TrimSpace(mux.Vars(r)["company"])
+	if companyName == "" {
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Company name is required")
+		return
+	}
+
+	compResp, err := h.companyService.GetCompanyByName(r.Context(), companyName)
+	if err != nil {
+		if errors.Is(err, company.ErrCompanyNotFound) {
+			dto.WriteErrorResponse(w, http.StatusNotFound, "Company not found")
+			return
+		}
+		h.logger.Error("Failed to get company for refresh", "error", err, "company", companyName)
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to retrieve company")
+		return
+	}
+
+	if !h.beginRefresh(compResp.Name) {
+		dto.WriteErrorResponse(w, http.StatusConflict, "A refresh of this company is already running")
+		return
+	}
+	go h.refresh(compResp.Name)
+
+	h.logger.Info("Manual feed refresh triggered", "company", compResp.Name)
+
+	dto.WriteJSONResponse(w, http.StatusAccepted, map[string]interface{}{
+		"company": compResp.Name,
+		"feeds":   len(compResp.EnabledFeeds()),
+		"status":  "refresh started",
+	})
+}
+
+// refresh processes a company's feeds detached from the triggering request
+func (h *AdminHandler) refresh(companyName string) {
+	defer h.endRefresh(companyName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), manualRefreshTimeout)
+	defer cancel()
+
+	report, err := h.refresher.ProcessCompanyFeed(ctx, companyName)
+	if err != nil {
+		h.logger.Error("Manual feed refresh failed", "error", err, "company", companyName)
+		return
+	}
+
+	h.logger.Info("Manual feed refresh completed",
+		"company", companyName,
+		"feeds", len(report.Feeds),
+		"new", report.TotalNew,
+		"duration", report.Duration)
+}
+
+// beginRefresh marks a company's refresh as running. It reports false when
+// one is already running.
+func (h *AdminHandler) beginRefresh(companyName string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.refreshing[companyName] {
+		return false
+	}
+	h.refreshing[companyName] = true
+	return true
+}
+
+// endRefresh marks a company's refresh as finished
+func (h *AdminHandler) endRefresh(companyName string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.refreshing, companyName)
+}
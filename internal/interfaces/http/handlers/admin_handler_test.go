@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestAdminHandlerRefreshGuard(t *testing.T) {
+	h := NewAdminHandler(nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if !h.beginRefresh("RTX") {
+		t.Fatal("Expected the first refresh to start")
+	}
+	if h.beginRefresh("RTX") {
+		t.Error("Expected a second refresh of the same company refused while the first runs")
+	}
+	if !h.beginRefresh("US War Department") {
+		t.Error("Expected another company's refresh to start")
+	}
+
+	h.endRefresh("RTX")
+	if !h.beginRefresh("RTX") {
+		t.Error("Expected a refresh to start again once the previous one finished")
+	}
+}
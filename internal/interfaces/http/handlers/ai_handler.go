@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -27,49 +29,126 @@ func NewAIHandler(aiService ai.Service, logger *slog.Logger) *AIHandler {
 
 // QueryHandler handles POST /ai/query requests
 func (h *AIHandler) QueryHandler(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeQueryRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Processing AI query", "question", req.Question, "company_context", req.CompanyContext)
+
+	response, err := h.aiService.ProcessQuery(r.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to process AI query", "error", err, "question", req.Question)
+		h.writeQueryError(w, err)
+		return
+	}
+
+	h.logger.Info("AI query processed successfully", 
+		"processing_time", response.ProcessingTime,
+		"confidence", response.Confidence,
+		"sources_count", len(response.Sources))
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// StreamQueryHandler handles POST /ai/query/stream requests, streaming the
+// answer as Server-Sent Events: a sources event, delta events with the parts
+// of the answer, and a done event. Errors before the sources event are
+// returned as JSON like /ai/query; later errors end the stream with an
+// error event.
+func (h *AIHandler) StreamQueryHandler(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeQueryRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Streaming AI query", "question", req.Question, "company_context", req.CompanyContext)
+
+	// Streams may outlast the server's write timeout
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("Failed to clear write deadline of AI query stream", "error", err)
+	}
+
+	started := false
+	send := func(event ai.StreamEvent) error {
+		if !started {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		return writeEvent(w, controller, event)
+	}
+
+	// The request context is cancelled when the client disconnects, which
+	// cancels the request to the model
+	err := h.aiService.StreamQuery(r.Context(), req, send)
+	switch {
+	case err == nil:
+	case r.Context().Err() != nil:
+		h.logger.Info("AI query stream cancelled by client", "question", req.Question)
+	case !started:
+		h.logger.Error("Failed to stream AI query", "error", err, "question", req.Question)
+		h.writeQueryError(w, err)
+	default:
+		h.logger.Error("AI query stream failed", "error", err, "question", req.Question)
+		if err := send(ai.StreamEvent{Type: ai.StreamEventError, Data: ai.StreamError{Message: "failed to generate response"}}); err != nil {
+			h.logger.Debug("Failed to send stream error event", "error", err)
+		}
+	}
+}
+
+// decodeQueryRequest decodes and validates a query request, writing the
+// error response when it is invalid
+func (h *AIHandler) decodeQueryRequest(w http.ResponseWriter, r *http.Request) (*ai.QueryRequest, bool) {
 	var req ai.QueryRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.Warn("Invalid JSON in AI query request", "error", err)
 		h.writeErrorResponse(w, http.StatusBadRequest, "invalid JSON format")
-		return
+		return nil, false
 	}
 
 	if strings.TrimSpace(req.Question) == "" {
 		h.writeErrorResponse(w, http.StatusBadRequest, "question is required")
-		return
+		return nil, false
 	}
 
 	if len(req.Question) > 1000 {
 		h.writeErrorResponse(w, http.StatusBadRequest, "question too long (max 1000 characters)")
-		return
+		return nil, false
 	}
 
-	h.logger.Info("Processing AI query", "question", req.Question, "company_context", req.CompanyContext)
+	return &req, true
+}
 
-	response, err := h.aiService.ProcessQuery(r.Context(), &req)
-	if err != nil {
-		h.logger.Error("Failed to process AI query", "error", err, "question", req.Question)
-		
-		if err == ai.ErrInvalidQuery {
-			h.writeErrorResponse(w, http.StatusBadRequest, "invalid query: "+err.Error())
-			return
-		}
-		if err == ai.ErrNoResults {
-			h.writeErrorResponse(w, http.StatusNotFound, "no relevant information found")
-			return
-		}
-		
-		h.writeErrorResponse(w, http.StatusInternalServerError, "failed to process query")
+// writeQueryError writes the error response of a failed query
+func (h *AIHandler) writeQueryError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ai.ErrInvalidQuery) {
+		h.writeErrorResponse(w, http.StatusBadRequest, "invalid query: "+err.Error())
+		return
+	}
+	if errors.Is(err, ai.ErrNoResults) {
+		h.writeErrorResponse(w, http.StatusNotFound, "no relevant information found")
 		return
 	}
 
-	h.logger.Info("AI query processed successfully", 
-		"processing_time", response.ProcessingTime,
-		"confidence", response.Confidence,
-		"sources_count", len(response.Sources))
+	h.writeErrorResponse(w, http.StatusInternalServerError, "failed to process query")
+}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+// writeEvent writes a Server-Sent Event with JSON data and flushes it to the client
+func writeEvent(w http.ResponseWriter, controller *http.ResponseController, event ai.StreamEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return err
+	}
+	return controller.Flush()
 }
 
 // AnalyzeQueryHandler handles POST /ai/analyze requests for query analysis only
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,14 +13,16 @@ import (
 )
 
 type CompanyHandler struct {
-	service company.Service
-	logger  logger.Logger
+	service  company.Service
+	resolver company.Resolver
+	logger   logger.Logger
 }
 
-func NewCompanyHandler(service company.Service, logger logger.Logger) *CompanyHandler {
+func NewCompanyHandler(service company.Service, resolver company.Resolver, logger logger.Logger) *CompanyHandler {
 	return &CompanyHandler{
-		service: service,
-		logger:  logger,
+		service:  service,
+		resolver: resolver,
+		logger:   logger,
 	}
 }
 
@@ -41,10 +44,10 @@ func (h *CompanyHandler) GetCompanyByName(w http.ResponseWriter, r *http.Request
 
 	h.logger.Info("Getting company by name", "name", companyName, "client_ip", utils.GetClientIP(r))
 
-	// Get company from service
-	companyResp, err := h.service.GetCompanyByName(r.Context(), companyName)
+	// Resolve names, aliases, tickers and misspellings to the stored company
+	resolution, err := h.resolver.Resolve(r.Context(), companyName)
 	if err != nil {
-		if err == company.ErrCompanyNotFound {
+		if errors.Is(err, company.ErrCompanyNotFound) {
 			h.logger.Info("Company not found", "name", companyName)
 			h.writeErrorResponse(w, http.StatusNotFound, "company not found")
 			return
@@ -55,7 +58,12 @@ func (h *CompanyHandler) GetCompanyByName(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, companyResp)
+	if resolution.MatchType != company.MatchExact {
+		h.logger.Info("Resolved company name", "name", companyName, "company", resolution.Company.Name,
+			"match", resolution.MatchType, "score", resolution.Score)
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, resolution.Company)
 }
 
 // GET /companies - Get all companies with optional pagination
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"github.com/Neph-dev/october_backend/internal/interfaces/dto"
+)
+
+// ContractHandler handles HTTP requests for contract awards
+type ContractHandler struct {
+	contractService *contract.Service
+	companyResolver company.Resolver
+	logger          *slog.Logger
+}
+
+// NewContractHandler creates a new contract handler
+func NewContractHandler(contractService *contract.Service, companyResolver company.Resolver, logger *slog.Logger) *ContractHandler {
+	return &ContractHandler{
+		contractService: contractService,
+		companyResolver: companyResolver,
+		logger:          logger,
+	}
+}
+
+// GetContracts handles GET /contracts requests
+func (h *ContractHandler) GetContracts(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.parseContractFilter(r)
+	if err != nil {
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters: "+err.Error())
+		return
+	}
+
+	awards, total, err := h.contractService.ListAwards(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, contract.ErrInvalidFilter) {
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters")
+			return
+		}
+		h.logger.Error("Failed to list contract awards", "error", err)
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to retrieve contract awards")
+		return
+	}
+
+	if awards == nil {
+		awards = []*contract.ContractAward{}
+	}
+
+	dto.WriteJSONResponse(w, http.StatusOK, dto.ContractListResponse{
+		Contracts: awards,
+		Total:     total,
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+	})
+}
+
+// GetQuarterlyTotals handles GET /contracts/totals requests
+func (h *ContractHandler) GetQuarterlyTotals(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.parseContractFilter(r)
+	if err != nil {
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters: "+err.Error())
+		return
+	}
+
+	totals, err := h.contractService.QuarterlyTotals(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, contract.ErrInvalidFilter) {
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters")
+			return
+		}
+		h.logger.Error("Failed to aggregate contract awards", "error", err)
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to aggregate contract awards")
+		return
+	}
+
+	if totals == nil {
+		totals = []*contract.QuarterlyTotal{}
+	}
+
+	dto.WriteJSONResponse(w, http.StatusOK, dto.QuarterlyTotalsResponse{
+		Totals: totals,
+		Count:  len(totals),
+	})
+}
+
+// parseContractFilter parses query parameters into a contract filter
+func (h *ContractHandler) parseContractFilter(r *http.Request) (*contract.Filter, error) {
+	query := r.URL.Query()
+	filter := &contract.Filter{
+		Agency: query.Get("agency"),
+	}
+
+	// Awards are stored under the canonical company name; names that do not
+	// resolve to a known company are matched against the awardee as given
+	if name := query.Get("company"); name != "" {
+		filter.Company = name
+		resolution, err := h.companyResolver.Resolve(r.Context(), name)
+		switch {
+		case err == nil:
+			filter.Company = resolution.Company.Name
+		case !errors.Is(err, company.ErrCompanyNotFound):
+			h.logger.Warn("Failed to resolve company name", "error", err, "company", name)
+		}
+	}
+
+	if minStr := query.Get("min_amount"); minStr != "" {
+		minAmount, err := strconv.ParseFloat(minStr, 64)
+		if err != nil {
+			return nil, err
+		}
+		filter.MinAmount = &minAmount
+	}
+
+	if maxStr := query.Get("max_amount"); maxStr != "" {
+		maxAmount, err := strconv.ParseFloat(maxStr, 64)
+		if err != nil {
+			return nil, err
+		}
+		filter.MaxAmount = &maxAmount
+	}
+
+	if startDateStr := query.Get("start_date"); startDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return nil, err
+		}
+		filter.StartDate = &startDate
+	}
+
+	if endDateStr := query.Get("end_date"); endDateStr != "" {
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return nil, err
+		}
+		// Set to end of day
+		endDate = endDate.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+		filter.EndDate = &endDate
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/conversation"
+	"github.com/Neph-dev/october_backend/internal/interfaces/dto"
+	"github.com/gorilla/mux"
+)
+
+// ConversationHandler handles HTTP requests for multi-turn AI conversations
+type ConversationHandler struct {
+	conversationService *conversation.Service
+	logger              *slog.Logger
+}
+
+// NewConversationHandler creates a new conversation handler
+func NewConversationHandler(conversationService *conversation.Service, logger *slog.Logger) *ConversationHandler {
+	return &ConversationHandler{
+		conversationService: conversationService,
+		logger:              logger,
+	}
+}
+
+// CreateConversation handles POST /ai/conversations requests
+func (h *ConversationHandler) CreateConversation(w http.ResponseWriter, r *http.Request) {
+	var req conversation.CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("Invalid JSON in create conversation request", "error", err)
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	created, err := h.conversationService.Create(r.Context(), &req)
+	if err != nil {
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create conversation")
+		return
+	}
+
+	dto.WriteJSONResponse(w, http.StatusCreated, created)
+}
+
+// ListConversations handles GET /ai/conversations requests
+func (h *ConversationHandler) ListConversations(w http.ResponseWriter, r *http.Request) {
+	filter := &conversation.Filter{}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid limit: "+err.Error())
+			return
+		}
+		filter.Limit = limit
+	}
+
+	conversations, err := h.conversationService.List(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, conversation.ErrInvalidFilter) {
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters")
+			return
+		}
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to retrieve conversations")
+		return
+	}
+
+	dto.WriteJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"conversations": conversations,
+		"count":         len(conversations),
+	})
+}
+
+// GetConversation handles GET /ai/conversations/{id} requests
+func (h *ConversationHandler) GetConversation(w http.ResponseWriter, r *http.Request) {
+	found, err := h.conversationService.Get(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		h.writeConversationError(w, err, "Failed to retrieve conversation")
+		return
+	}
+
+	dto.WriteJSONResponse(w, http.StatusOK, found)
+}
+
+// AskQuestion handles POST /ai/conversations/{id}/messages requests,
+// answering a question in the context of the conversation
+func (h *ConversationHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var request struct {
+		Question string `json:"question"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.logger.Warn("Invalid JSON in conversation message request", "error", err)
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	h.logger.Info("Processing conversation question", "conversation_id", id, "question", request.Question)
+
+	turn, err := h.conversationService.Ask(r.Context(), id, request.Question)
+	if err != nil {
+		h.logger.Error("Failed to answer conversation question", "error", err, "conversation_id", id)
+		h.writeConversationError(w, err, "Failed to process question")
+		return
+	}
+
+	dto.WriteJSONResponse(w, http.StatusOK, turn)
+}
+
+// DeleteConversation handles DELETE /ai/conversations/{id} requests
+func (h *ConversationHandler) DeleteConversation(w http.ResponseWriter, r *http.Request) {
+	if err := h.conversationService.Delete(r.Context(), mux.Vars(r)["id"]); err != nil {
+		h.writeConversationError(w, err, "Failed to delete conversation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeConversationError writes the error response of a failed conversation request
+func (h *ConversationHandler) writeConversationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, conversation.ErrConversationNotFound):
+		dto.WriteErrorResponse(w, http.StatusNotFound, "Conversation not found")
+	case errors.Is(err, conversation.ErrInvalidQuestion), errors.Is(err, ai.ErrInvalidQuery):
+		dto.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, conversation.ErrConversationFull):
+		dto.WriteErrorResponse(w, http.StatusConflict, "Conversation has reached its message limit; start a new one")
+	case errors.Is(err, conversation.ErrConversationConflict):
+		dto.WriteErrorResponse(w, http.StatusConflict, "Conversation is being updated by another question; try again")
+	default:
+		dto.WriteErrorResponse(w, http.StatusInternalServerError, message)
+	}
+}
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Neph-dev/october_backend/internal/domain/company"
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 	"github.com/Neph-dev/october_backend/internal/interfaces/dto"
 	"github.com/gorilla/mux"
@@ -13,22 +18,22 @@ import (
 
 // NewsHandler handles HTTP requests for news operations
 type NewsHandler struct {
-	newsService *news.Service
-	logger      *slog.Logger
+	newsService     *news.Service
+	companyResolver company.Resolver
+	logger          *slog.Logger
 }
 
 // NewNewsHandler creates a new news handler
-func NewNewsHandler(newsService *news.Service, logger *slog.Logger) *NewsHandler {
+func NewNewsHandler(newsService *news.Service, companyResolver company.Resolver, logger *slog.Logger) *NewsHandler {
 	return &NewsHandler{
-		newsService: newsService,
-		logger:      logger,
+		newsService:     newsService,
+		companyResolver: companyResolver,
+		logger:          logger,
 	}
 }
 
 // GetNews handles GET /news requests
 func (h *NewsHandler) GetNews(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	// Parse query parameters
 	filter, err := h.parseNewsFilter(r)
 	if err != nil {
@@ -37,9 +42,95 @@ func (h *NewsHandler) GetNews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get articles
-	articles, total, err := h.newsService.ListArticles(ctx, filter)
+	h.writeNews(w, r, filter)
+}
+
+// SearchNews handles GET and POST /news/search requests. GET takes the
+// query parameters of GET /news and POST the same query as a JSON body.
+func (h *NewsHandler) SearchNews(w http.ResponseWriter, r *http.Request) {
+	var filter *news.NewsFilter
+	var err error
+	if r.Method == http.MethodGet {
+		filter, err = h.parseNewsFilter(r)
+	} else {
+		var req dto.NewsSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.logger.Warn("Invalid JSON in news search request", "error", err)
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON format")
+			return
+		}
+		filter, err = buildNewsFilter(&req)
+	}
+	if err != nil {
+		h.logger.Error("Invalid search parameters", "error", err)
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid search parameters: "+err.Error())
+		return
+	}
+
+	h.writeNews(w, r, filter)
+}
+
+// writeNews writes the articles matching the filter. Sorting by match is
+// ranked full-text search: the query text ranks the results instead of
+// filtering them.
+func (h *NewsHandler) writeNews(w http.ResponseWriter, r *http.Request, filter *news.NewsFilter) {
+	if filter.Sort != news.SortByMatch {
+		h.writeArticles(w, r, filter)
+		return
+	}
+
+	query := &news.SearchQuery{Text: filter.Query, Filter: filter, Limit: filter.Limit, Offset: filter.Offset}
+	filter.Query = ""
+	h.writeSearchResults(w, r, query)
+}
+
+// writeSearchResults runs a full-text search and writes the ranked page
+func (h *NewsHandler) writeSearchResults(w http.ResponseWriter, r *http.Request, query *news.SearchQuery) {
+	results, total, err := h.newsService.SearchArticles(r.Context(), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, news.ErrEmptySearchQuery):
+			dto.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, news.ErrInvalidFilter):
+			dto.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.Error("Failed to search articles", "error", err)
+			dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to search articles")
+		}
+		return
+	}
+
+	articleDTOs := make([]*dto.ArticleResponse, len(results))
+	for i, result := range results {
+		articleDTOs[i] = dto.ToSearchResultResponse(result)
+	}
+
+	response := dto.NewsListResponse{
+		Articles: articleDTOs,
+		Total:    total,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	}
+	if cursor := query.NextCursor(results); cursor != nil {
+		response.NextCursor = cursor.Encode()
+	}
+
+	h.logger.Info("Successfully searched articles",
+		"count", len(results),
+		"total", total,
+		"query", query.Text)
+
+	dto.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// writeArticles lists the articles matching the filter and writes the page
+func (h *NewsHandler) writeArticles(w http.ResponseWriter, r *http.Request, filter *news.NewsFilter) {
+	articles, total, err := h.newsService.ListArticles(r.Context(), filter)
 	if err != nil {
+		if errors.Is(err, news.ErrInvalidFilter) {
+			dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters")
+			return
+		}
 		h.logger.Error("Failed to list articles", "error", err)
 		dto.WriteErrorResponse(w, http.StatusInternalServerError, "Failed to retrieve articles")
 		return
@@ -57,11 +148,15 @@ func (h *NewsHandler) GetNews(w http.ResponseWriter, r *http.Request) {
 		Limit:    filter.Limit,
 		Offset:   filter.Offset,
 	}
+	if cursor := filter.NextCursor(articles); cursor != nil {
+		response.NextCursor = cursor.Encode()
+	}
 
 	h.logger.Info("Successfully retrieved articles", 
 		"count", len(articles), 
 		"total", total,
-		"company", filter.Company)
+		"companies", filter.Companies,
+		"entity", filter.Entity)
 
 	dto.WriteJSONResponse(w, http.StatusOK, response)
 }
@@ -92,26 +187,115 @@ func (h *NewsHandler) GetNewsById(w http.ResponseWriter, r *http.Request) {
 	dto.WriteJSONResponse(w, http.StatusOK, response)
 }
 
-// parseNewsFilter parses query parameters into a NewsFilter
+// GetNewsByEntity handles GET /entities/{id}/news requests
+func (h *NewsHandler) GetNewsByEntity(w http.ResponseWriter, r *http.Request) {
+	entityID := mux.Vars(r)["id"]
+
+	if _, err := news.ParseEntityID(entityID); err != nil {
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid entity ID: expected <type>:<name>, e.g. program:f-35")
+		return
+	}
+
+	filter, err := h.parseNewsFilter(r)
+	if err != nil {
+		h.logger.Error("Invalid filter parameters", "error", err)
+		dto.WriteErrorResponse(w, http.StatusBadRequest, "Invalid filter parameters: "+err.Error())
+		return
+	}
+	filter.Entity = entityID
+
+	h.writeArticles(w, r, filter)
+}
+
+// parseNewsFilter parses query parameters into a NewsFilter. List parameters
+// may be repeated or comma-separated.
 func (h *NewsHandler) parseNewsFilter(r *http.Request) (*news.NewsFilter, error) {
-	filter := &news.NewsFilter{}
+	query := r.URL.Query()
+	req := &dto.NewsSearchRequest{
+		Companies:         listParam(query, "company"),
+		CompanyMatch:      query.Get("company_match"),
+		ExcludeCompanies:  listParam(query, "exclude_company"),
+		FeedSources:       listParam(query, "feed_source"),
+		Categories:        listParam(query, "category"),
+		ExcludeCategories: listParam(query, "exclude_category"),
+		Entity:            query.Get("entity"),
+		Query:             query.Get("q"),
+		StartDate:         query.Get("start_date"),
+		EndDate:           query.Get("end_date"),
+		Sentiment:         query.Get("sentiment"),
+		Sort:              query.Get("sort"),
+		Order:             query.Get("order"),
+		Cursor:            query.Get("cursor"),
+	}
 
-	// Company filter
-	if company := r.URL.Query().Get("company"); company != "" {
-		filter.Company = company
+	var err error
+	if req.MinRelevance, err = floatParam(query, "min_relevance"); err != nil {
+		return nil, err
+	}
+	if req.MinSentiment, err = floatParam(query, "min_sentiment"); err != nil {
+		return nil, err
+	}
+	if req.MaxSentiment, err = floatParam(query, "max_sentiment"); err != nil {
+		return nil, err
+	}
+
+	// Pagination
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if req.Limit, err = strconv.Atoi(limitStr); err != nil {
+			return nil, err
+		}
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if req.Offset, err = strconv.Atoi(offsetStr); err != nil {
+			return nil, err
+		}
+	}
+
+	return buildNewsFilter(req)
+}
+
+// buildNewsFilter converts a news search request into a NewsFilter
+func buildNewsFilter(req *dto.NewsSearchRequest) (*news.NewsFilter, error) {
+	filter := &news.NewsFilter{
+		Companies:        req.Companies,
+		ExcludeCompanies: req.ExcludeCompanies,
+		FeedSources:      req.FeedSources,
+		Query:            strings.TrimSpace(req.Query),
+		MinRelevance:     req.MinRelevance,
+		MinSentiment:     req.MinSentiment,
+		MaxSentiment:     req.MaxSentiment,
+		Limit:            req.Limit,
+		Offset:           req.Offset,
+	}
+
+	var err error
+	if filter.CompanyMatch, err = news.ParseCompanyMatch(req.CompanyMatch); err != nil {
+		return nil, err
+	}
+	if filter.Sort, err = news.ParseSortField(req.Sort); err != nil {
+		return nil, err
+	}
+	if filter.Order, err = news.ParseSortOrder(req.Order); err != nil {
+		return nil, err
+	}
+	if filter.Categories, err = parseCategories(req.Categories); err != nil {
+		return nil, err
+	}
+	if filter.ExcludeCategories, err = parseCategories(req.ExcludeCategories); err != nil {
+		return nil, err
 	}
 
 	// Date filters
-	if startDateStr := r.URL.Query().Get("start_date"); startDateStr != "" {
-		startDate, err := time.Parse("2006-01-02", startDateStr)
+	if req.StartDate != "" {
+		startDate, err := time.Parse("2006-01-02", req.StartDate)
 		if err != nil {
 			return nil, err
 		}
 		filter.StartDate = &startDate
 	}
 
-	if endDateStr := r.URL.Query().Get("end_date"); endDateStr != "" {
-		endDate, err := time.Parse("2006-01-02", endDateStr)
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
 		if err != nil {
 			return nil, err
 		}
@@ -120,37 +304,73 @@ func (h *NewsHandler) parseNewsFilter(r *http.Request) (*news.NewsFilter, error)
 		filter.EndDate = &endDate
 	}
 
-	// Relevance filter
-	if relevanceStr := r.URL.Query().Get("min_relevance"); relevanceStr != "" {
-		relevance, err := strconv.ParseFloat(relevanceStr, 64)
-		if err != nil {
+	// Entity filter
+	if req.Entity != "" {
+		if _, err := news.ParseEntityID(req.Entity); err != nil {
 			return nil, err
 		}
-		filter.MinRelevance = &relevance
+		filter.Entity = req.Entity
 	}
 
-	// Pagination
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		limit, err := strconv.Atoi(limitStr)
-		if err != nil {
+	if req.Sentiment != "" {
+		if filter.Sentiment, err = news.ParseSentimentLabel(req.Sentiment); err != nil {
 			return nil, err
 		}
-		filter.Limit = limit
-	} else {
+	}
+
+	// Pagination
+	if filter.Limit == 0 {
 		filter.Limit = 50 // Default limit
 	}
 
-	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
-		offset, err := strconv.Atoi(offsetStr)
-		if err != nil {
+	if req.Cursor != "" {
+		if filter.After, err = news.DecodeCursor(req.Cursor); err != nil {
 			return nil, err
 		}
-		filter.Offset = offset
 	}
 
 	return filter, nil
 }
 
+// parseCategories converts category names into taxonomy categories
+func parseCategories(values []string) ([]news.Category, error) {
+	categories := make([]news.Category, 0, len(values))
+	for _, value := range values {
+		category, err := news.ParseCategory(value)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+// listParam returns the values of a repeated or comma-separated query parameter
+func listParam(query url.Values, key string) []string {
+	var values []string
+	for _, param := range query[key] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// floatParam parses an optional float query parameter
+func floatParam(query url.Values, key string) (*float64, error) {
+	param := query.Get(key)
+	if param == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
 // GetNewsByCompany handles GET /news/company/{name} requests
 func (h *NewsHandler) GetNewsByCompany(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -162,6 +382,16 @@ func (h *NewsHandler) GetNewsByCompany(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Articles are stored under the canonical company name; names that do
+	// not resolve to a known company are used as given
+	resolution, err := h.companyResolver.Resolve(ctx, companyName)
+	switch {
+	case err == nil:
+		companyName = resolution.Company.Name
+	case !errors.Is(err, company.ErrCompanyNotFound):
+		h.logger.Warn("Failed to resolve company name", "error", err, "company", companyName)
+	}
+
 	articles, err := h.newsService.GetArticlesByCompany(ctx, companyName)
 	if err != nil {
 		h.logger.Error("Failed to get articles by company", "error", err, "company", companyName)
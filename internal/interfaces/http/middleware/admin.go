@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/Neph-dev/october_backend/internal/interfaces/http/utils"
+	"github.com/Neph-dev/october_backend/pkg/logger"
+)
+
+// AdminKeyHeader is the header carrying the admin API key
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminAuth protects admin endpoints with a static API key. It fails
+// closed: when apiKey is empty, every admin request is refused with 503.
+func AdminAuth(apiKey string, logger logger.Logger) func(http.Handler) http.Handler {
+	if apiKey == "" {
+		logger.Warn("ADMIN_API_KEY is not set; admin endpoints are disabled")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey == "" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"error": "admin_disabled", "message": "admin endpoints are disabled until an admin key is configured"}`))
+				return
+			}
+
+			provided := r.Header.Get(AdminKeyHeader)
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+				logger.Warn("Unauthorized admin request",
+					"client_ip", utils.GetClientIP(r),
+					"path", r.URL.Path,
+					"method", r.Method,
+				)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": "unauthorized", "message": "invalid or missing admin key"}`))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Neph-dev/october_backend/pkg/logger"
+)
+
+func TestAdminAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiKey     string
+		header     string
+		wantStatus int
+	}{
+		{name: "missing key", apiKey: "secret", header: "", wantStatus: http.StatusUnauthorized},
+		{name: "wrong key", apiKey: "secret", header: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "right key", apiKey: "secret", header: "secret", wantStatus: http.StatusOK},
+		{name: "unset admin key", apiKey: "", header: "", wantStatus: http.StatusServiceUnavailable},
+		{name: "unset admin key with a header", apiKey: "", header: "anything", wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := AdminAuth(tt.apiKey, logger.NewLogger(slog.LevelError, io.Discard))(next)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/feeds/RTX/refresh", nil)
+			if tt.header != "" {
+				req.Header.Set(AdminKeyHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if called != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("Expected the admin handler called only with the right key, called = %v", called)
+			}
+		})
+	}
+}
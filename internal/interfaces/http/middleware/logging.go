@@ -80,4 +80,10 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	size, err := rw.ResponseWriter.Write(b)
 	rw.size += size
 	return size, err
-}
\ No newline at end of file
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController to flush
+// streamed responses and adjust their deadlines
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
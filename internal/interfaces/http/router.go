@@ -6,6 +6,9 @@ import (
 
 	"github.com/Neph-dev/october_backend/internal/domain/ai"
 	"github.com/Neph-dev/october_backend/internal/domain/company"
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"github.com/Neph-dev/october_backend/internal/domain/conversation"
+	"github.com/Neph-dev/october_backend/internal/domain/feed"
 	"github.com/Neph-dev/october_backend/internal/domain/news"
 	"github.com/Neph-dev/october_backend/internal/interfaces/http/handlers"
 	"github.com/Neph-dev/october_backend/internal/interfaces/http/middleware"
@@ -15,25 +18,44 @@ import (
 
 // Router handles HTTP routing for the application
 type Router struct {
-	logger         logger.Logger
-	router         *mux.Router
-	companyHandler *handlers.CompanyHandler
-	newsHandler    *handlers.NewsHandler
-	aiHandler      *handlers.AIHandler
-	rateLimiter    *middleware.RateLimiter
+	logger              logger.Logger
+	router              *mux.Router
+	companyHandler      *handlers.CompanyHandler
+	newsHandler         *handlers.NewsHandler
+	contractHandler     *handlers.ContractHandler
+	aiHandler           *handlers.AIHandler
+	conversationHandler *handlers.ConversationHandler
+	adminHandler        *handlers.AdminHandler
+	rateLimiter         *middleware.RateLimiter
+	adminAuth           func(http.Handler) http.Handler
 }
 
-func NewRouter(logger logger.Logger, companyService company.Service, newsService *news.Service, aiService ai.Service) *Router {
+func NewRouter(
+	logger logger.Logger,
+	adminAPIKey string,
+	companyService company.Service,
+	companyResolver company.Resolver,
+	newsService *news.Service,
+	contractService *contract.Service,
+	aiService ai.Service,
+	conversationService *conversation.Service,
+	feedService *feed.Service,
+	feedRefresher handlers.FeedRefresher,
+) *Router {
 	// Create rate limiter: 10 requests per second, burst of 20
 	rateLimiter := middleware.NewRateLimiter(10.0, 20, logger)
 	
 	return &Router{
-		logger:         logger,
-		router:         mux.NewRouter(),
-		companyHandler: handlers.NewCompanyHandler(companyService, logger),
-		newsHandler:    handlers.NewNewsHandler(newsService, logger.Unwrap()),
-		aiHandler:      handlers.NewAIHandler(aiService, logger.Unwrap()),
-		rateLimiter:    rateLimiter,
+		logger:              logger,
+		router:              mux.NewRouter(),
+		companyHandler:      handlers.NewCompanyHandler(companyService, companyResolver, logger),
+		newsHandler:         handlers.NewNewsHandler(newsService, companyResolver, logger.Unwrap()),
+		contractHandler:     handlers.NewContractHandler(contractService, companyResolver, logger.Unwrap()),
+		aiHandler:           handlers.NewAIHandler(aiService, logger.Unwrap()),
+		conversationHandler: handlers.NewConversationHandler(conversationService, logger.Unwrap()),
+		adminHandler:        handlers.NewAdminHandler(feedService, companyService, feedRefresher, logger.Unwrap()),
+		rateLimiter:         rateLimiter,
+		adminAuth:           middleware.AdminAuth(adminAPIKey, logger),
 	}
 }
 
@@ -49,15 +71,34 @@ func (r *Router) SetupRoutes() {
 	
 	// News API routes with rate limiting
 	r.router.HandleFunc("/news", r.handleNews).Methods("GET")
+	r.router.HandleFunc("/news/search", r.handleNewsSearch).Methods("GET", "POST")
 	r.router.HandleFunc("/news/{id}", r.handleNewsById).Methods("GET")
 	r.router.HandleFunc("/news/company/{name}", r.handleNewsByCompany).Methods("GET")
+	r.router.HandleFunc("/entities/{id}/news", r.handleNewsByEntity).Methods("GET")
+
+	// Contract award API routes with rate limiting
+	r.router.HandleFunc("/contracts", r.handleContracts).Methods("GET")
+	r.router.HandleFunc("/contracts/totals", r.handleContractTotals).Methods("GET")
 	
 	// AI/RAG API routes with rate limiting
 	r.router.HandleFunc("/ai/query", r.handleAIQuery).Methods("POST")
+	r.router.HandleFunc("/ai/query/stream", r.handleAIQueryStream).Methods("POST")
 	r.router.HandleFunc("/ai/analyze", r.handleAIAnalyze).Methods("POST")
 	r.router.HandleFunc("/ai/web-search", r.handleAIWebSearch).Methods("POST")
 	r.router.HandleFunc("/ai/summarise/{articleId}", r.handleAISummarizeArticle).Methods("GET")
 	r.router.HandleFunc("/ai/cache/stats", r.handleAICacheStats).Methods("GET")
+
+	// AI conversation routes with rate limiting
+	r.router.HandleFunc("/ai/conversations", r.handleCreateConversation).Methods("POST")
+	r.router.HandleFunc("/ai/conversations", r.handleListConversations).Methods("GET")
+	r.router.HandleFunc("/ai/conversations/{id}", r.handleGetConversation).Methods("GET")
+	r.router.HandleFunc("/ai/conversations/{id}", r.handleDeleteConversation).Methods("DELETE")
+	r.router.HandleFunc("/ai/conversations/{id}/messages", r.handleAskConversation).Methods("POST")
+
+	// Admin API routes for feed ingestion, protected by the admin key
+	r.router.HandleFunc("/admin/feeds/runs", r.handleAdminFeedRuns).Methods("GET")
+	r.router.HandleFunc("/admin/feeds/health", r.handleAdminFeedHealth).Methods("GET")
+	r.router.HandleFunc("/admin/feeds/{company}/refresh", r.handleAdminFeedRefresh).Methods("POST")
 }
 
 // ServeHTTP implements http.Handler interface with middleware chain
@@ -117,6 +158,34 @@ func (r *Router) handleNewsByCompany(w http.ResponseWriter, req *http.Request) {
 	rateLimitedHandler.ServeHTTP(w, req)
 }
 
+// handleNewsSearch handles GET and POST /news/search with rate limiting
+func (r *Router) handleNewsSearch(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.newsHandler.SearchNews))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleNewsByEntity handles GET /entities/{id}/news with rate limiting
+func (r *Router) handleNewsByEntity(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.newsHandler.GetNewsByEntity))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleContracts handles GET /contracts with rate limiting
+func (r *Router) handleContracts(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.contractHandler.GetContracts))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleContractTotals handles GET /contracts/totals with rate limiting
+func (r *Router) handleContractTotals(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.contractHandler.GetQuarterlyTotals))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
 // handleAIQuery handles POST /ai/query with rate limiting
 func (r *Router) handleAIQuery(w http.ResponseWriter, req *http.Request) {
 	// Apply rate limiting (stricter for AI endpoints due to cost)
@@ -124,6 +193,13 @@ func (r *Router) handleAIQuery(w http.ResponseWriter, req *http.Request) {
 	rateLimitedHandler.ServeHTTP(w, req)
 }
 
+// handleAIQueryStream handles POST /ai/query/stream with rate limiting
+func (r *Router) handleAIQueryStream(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting (stricter for AI endpoints due to cost)
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.aiHandler.StreamQueryHandler))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
 // handleAIAnalyze handles POST /ai/analyze with rate limiting
 func (r *Router) handleAIAnalyze(w http.ResponseWriter, req *http.Request) {
 	// Apply rate limiting
@@ -151,3 +227,56 @@ func (r *Router) handleAICacheStats(w http.ResponseWriter, req *http.Request) {
 	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.aiHandler.CacheStatsHandler))
 	rateLimitedHandler.ServeHTTP(w, req)
 }
+
+// handleCreateConversation handles POST /ai/conversations with rate limiting
+func (r *Router) handleCreateConversation(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.conversationHandler.CreateConversation))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleListConversations handles GET /ai/conversations with rate limiting
+func (r *Router) handleListConversations(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.conversationHandler.ListConversations))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleGetConversation handles GET /ai/conversations/{id} with rate limiting
+func (r *Router) handleGetConversation(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.conversationHandler.GetConversation))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleDeleteConversation handles DELETE /ai/conversations/{id} with rate limiting
+func (r *Router) handleDeleteConversation(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.conversationHandler.DeleteConversation))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleAskConversation handles POST /ai/conversations/{id}/messages with rate limiting
+func (r *Router) handleAskConversation(w http.ResponseWriter, req *http.Request) {
+	// Apply rate limiting (stricter for AI endpoints due to cost)
+	rateLimitedHandler := r.rateLimiter.Middleware()(http.HandlerFunc(r.conversationHandler.AskQuestion))
+	rateLimitedHandler.ServeHTTP(w, req)
+}
+
+// handleAdminFeedRuns handles GET /admin/feeds/runs with admin authentication and rate limiting
+func (r *Router) handleAdminFeedRuns(w http.ResponseWriter, req *http.Request) {
+	handler := r.adminAuth(r.rateLimiter.Middleware()(http.HandlerFunc(r.adminHandler.ListFeedRuns)))
+	handler.ServeHTTP(w, req)
+}
+
+// handleAdminFeedHealth handles GET /admin/feeds/health with admin authentication and rate limiting
+func (r *Router) handleAdminFeedHealth(w http.ResponseWriter, req *http.Request) {
+	handler := r.adminAuth(r.rateLimiter.Middleware()(http.HandlerFunc(r.adminHandler.GetFeedHealth)))
+	handler.ServeHTTP(w, req)
+}
+
+// handleAdminFeedRefresh handles POST /admin/feeds/{company}/refresh with admin authentication and rate limiting
+func (r *Router) handleAdminFeedRefresh(w http.ResponseWriter, req *http.Request) {
+	handler := r.adminAuth(r.rateLimiter.Middleware()(http.HandlerFunc(r.adminHandler.RefreshCompanyFeeds)))
+	handler.ServeHTTP(w, req)
+}
@@ -0,0 +1,120 @@
+package wiring
+
+import (
+	"log/slog"
+
+	"github.com/Neph-dev/october_backend/config"
+	"github.com/Neph-dev/october_backend/internal/domain/ai"
+	"github.com/Neph-dev/october_backend/internal/domain/contract"
+	"github.com/Neph-dev/october_backend/internal/domain/conversation"
+	"github.com/Neph-dev/october_backend/internal/domain/embedding"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	aiInfra "github.com/Neph-dev/october_backend/internal/infra/ai"
+	"github.com/sashabaranov/go-openai"
+)
+
+// NewLLMProvider returns the configured language model provider
+func NewLLMProvider(cfg config.AIConfig) aiInfra.LLMProvider {
+	if cfg.Provider == config.ProviderCompatible {
+		return aiInfra.NewCompatibleProvider(cfg.BaseURL, cfg.ProviderAPIKey)
+	}
+	return aiInfra.NewOpenAIProvider(openai.NewClient(cfg.OpenAIAPIKey))
+}
+
+// NewModels returns the configured model of each AI task
+func NewModels(cfg config.AIConfig) aiInfra.Models {
+	return aiInfra.Models{
+		Analysis:  cfg.AnalysisModel,
+		Answer:    cfg.AnswerModel,
+		Summary:   cfg.SummaryModel,
+		Embedding: cfg.EmbeddingModel,
+	}
+}
+
+// NewClassifier returns the LLM article classifier when enabled, or nil
+// for the default rule-based classifier
+func NewClassifier(cfg config.AIConfig, provider aiInfra.LLMProvider, logger *slog.Logger) news.Classifier {
+	if !cfg.LLMClassifier || !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAIClassifier(provider, cfg.AnalysisModel, news.NewRuleClassifier(), logger)
+}
+
+// NewSentimentAnalyzer returns the LLM sentiment analyzer when enabled,
+// or nil for the default lexicon analyzer
+func NewSentimentAnalyzer(cfg config.AIConfig, provider aiInfra.LLMProvider, logger *slog.Logger) news.SentimentAnalyzer {
+	if !cfg.LLMSentiment || !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAISentimentAnalyzer(provider, cfg.AnalysisModel, news.NewLexiconSentimentAnalyzer(), logger)
+}
+
+// NewEmbedder returns the LLM embedder, or nil for the offline hashing
+// embedder when disabled or no provider is configured
+func NewEmbedder(cfg config.AIConfig, provider aiInfra.LLMProvider) embedding.Embedder {
+	if !cfg.LLMEmbeddings || !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAIEmbedder(provider, cfg.EmbeddingModel, cfg.EmbeddingDimensions)
+}
+
+// NewVectorIndex returns the configured in-process vector index
+func NewVectorIndex(cfg config.AIConfig) embedding.Index {
+	if cfg.VectorIndex == "hnsw" {
+		return embedding.NewHNSWIndex(embedding.DefaultHNSWConfig())
+	}
+	return embedding.NewFlatIndex()
+}
+
+// NewQueryAnalyzer returns the LLM query analyzer, or nil for the default
+// rule-based analyzer when no provider is configured
+func NewQueryAnalyzer(cfg config.AIConfig, provider aiInfra.LLMProvider, logger *slog.Logger) ai.QueryAnalyzer {
+	if !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAIQueryAnalyzer(provider, cfg.AnalysisModel, ai.NewRuleQueryAnalyzer(), logger)
+}
+
+// NewRewriter returns the LLM follow-up question rewriter when a provider
+// is configured, or nil for the rule-based rewriter
+func NewRewriter(cfg config.AIConfig, provider aiInfra.LLMProvider, logger *slog.Logger) conversation.Rewriter {
+	if !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAIRewriter(provider, cfg.AnalysisModel, conversation.NewRuleRewriter(), logger)
+}
+
+// NewConversationSummarizer returns the LLM conversation summarizer when a
+// provider is configured, or nil for the rule-based summarizer
+func NewConversationSummarizer(cfg config.AIConfig, provider aiInfra.LLMProvider, logger *slog.Logger) conversation.Summarizer {
+	if !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAISummarizer(provider, cfg.SummaryModel, conversation.NewRuleSummarizer(), logger)
+}
+
+// NewReranker returns the LLM reranker when enabled, or nil for the
+// default heuristic reranker
+func NewReranker(cfg config.AIConfig, provider aiInfra.LLMProvider, logger *slog.Logger) ai.Reranker {
+	if !cfg.LLMReranker || !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAIReranker(provider, cfg.AnalysisModel, ai.NewHeuristicReranker(ai.DefaultHeuristicWeights()), logger)
+}
+
+// NewRetrievalConfig converts the retrieval weights to the pipeline configuration
+func NewRetrievalConfig(cfg config.AIConfig) aiInfra.RetrievalConfig {
+	retrievalConfig := aiInfra.DefaultRetrievalConfig()
+	retrievalConfig.LexicalWeight = cfg.LexicalWeight
+	retrievalConfig.SemanticWeight = cfg.SemanticWeight
+	return retrievalConfig
+}
+
+// NewContractFallback returns the LLM contract award extractor when
+// enabled, or nil to rely on the rule-based extractor alone
+func NewContractFallback(cfg config.AIConfig, provider aiInfra.LLMProvider) contract.Extractor {
+	if !cfg.LLMContractExtraction || !cfg.LLMConfigured() {
+		return nil
+	}
+	return aiInfra.NewOpenAIContractExtractor(provider, cfg.AnalysisModel)
+}
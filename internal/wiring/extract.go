@@ -0,0 +1,20 @@
+package wiring
+
+import (
+	"log/slog"
+
+	"github.com/Neph-dev/october_backend/config"
+	"github.com/Neph-dev/october_backend/internal/domain/news"
+	"github.com/Neph-dev/october_backend/internal/infra/extract"
+)
+
+// NewExtractor creates the full-text extractor, or returns nil when extraction is disabled
+func NewExtractor(cfg config.ExtractConfig, logger *slog.Logger) news.Extractor {
+	if !cfg.Enabled {
+		return nil
+	}
+	return extract.NewExtractor(extract.Config{
+		Timeout:      cfg.Timeout,
+		PerHostDelay: cfg.PerHostDelay,
+	}, logger)
+}
@@ -0,0 +1,29 @@
+// Package wiring builds the components the commands share from the
+// application configuration, so that the API server and the feed processor
+// are configured alike.
+package wiring
+
+import (
+	"github.com/Neph-dev/october_backend/config"
+	feedDomain "github.com/Neph-dev/october_backend/internal/domain/feed"
+	"github.com/Neph-dev/october_backend/internal/infra/feed"
+)
+
+// NewPoolConfig converts the feed configuration into the worker pool settings
+func NewPoolConfig(cfg config.FeedConfig) feed.PoolConfig {
+	return feed.PoolConfig{
+		Workers:            cfg.Workers,
+		PerHostConcurrency: cfg.PerHostConcurrency,
+		PerHostDelay:       cfg.PerHostDelay,
+		FeedTimeout:        cfg.FeedTimeout,
+	}
+}
+
+// NewSchedulePolicy converts the feed configuration into the scheduler's policy
+func NewSchedulePolicy(cfg config.FeedConfig) feedDomain.SchedulePolicy {
+	return feedDomain.SchedulePolicy{
+		MinInterval:     cfg.MinInterval,
+		MaxInterval:     cfg.MaxInterval,
+		DefaultInterval: cfg.DefaultInterval,
+	}
+}